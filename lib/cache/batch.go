@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"iter"
+
+	"github.com/eko/gocache/lib/v4/store"
+)
+
+// getMany returns the objects stored for the given keys using the cache
+// batch implementation when available or one Get call per key otherwise
+func getMany[T any](ctx context.Context, cache CacheInterface[T], keys []any) (map[any]T, error) {
+	if batchCache, ok := cache.(BatchCacheInterface[T]); ok {
+		return batchCache.GetMany(ctx, keys)
+	}
+
+	objects := make(map[any]T, len(keys))
+	for _, key := range keys {
+		object, err := cache.Get(ctx, key)
+		if errors.Is(err, store.NotFound{}) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		objects[key] = object
+	}
+
+	return objects, nil
+}
+
+// setMany stores the given items using the cache batch implementation when
+// available or one Set call per item otherwise
+func setMany[T any](ctx context.Context, cache CacheInterface[T], items map[any]T, options ...store.Option) error {
+	if batchCache, ok := cache.(BatchCacheInterface[T]); ok {
+		return batchCache.SetMany(ctx, items, options...)
+	}
+
+	errs := []error{}
+	for key, object := range items {
+		if err := cache.Set(ctx, key, object, options...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// deleteMany removes the given keys using the cache batch implementation when
+// available or one Delete call per key otherwise
+func deleteMany[T any](ctx context.Context, cache CacheInterface[T], keys []any) error {
+	if batchCache, ok := cache.(BatchCacheInterface[T]); ok {
+		return batchCache.DeleteMany(ctx, keys)
+	}
+
+	errs := []error{}
+	for _, key := range keys {
+		if err := cache.Delete(ctx, key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// scanKeys iterates over the keys held by the cache when it supports it or yields
+// a single store.ErrUnsupported error otherwise
+func scanKeys[T any](ctx context.Context, cache CacheInterface[T], options ...store.ScanOption) iter.Seq2[any, error] {
+	if scannerCache, ok := cache.(ScannerCacheInterface); ok {
+		return scannerCache.Keys(ctx, options...)
+	}
+
+	return func(yield func(any, error) bool) {
+		yield(nil, store.ErrUnsupported)
+	}
+}
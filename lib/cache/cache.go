@@ -4,11 +4,13 @@ import (
 	"context"
 	"crypto"
 	"fmt"
+	"iter"
 	"reflect"
 	"time"
 
 	"github.com/eko/gocache/lib/v4/codec"
 	"github.com/eko/gocache/lib/v4/store"
+	"golang.org/x/sync/singleflight"
 )
 
 const (
@@ -18,7 +20,10 @@ const (
 
 // Cache represents the configuration needed by a cache
 type Cache[T any] struct {
-	codec codec.CodecInterface
+	codec *codec.Codec
+	// keyEncoder replaces getCacheKey default key conversion when set
+	keyEncoder  func(key any) any
+	fetchFlight singleflight.Group
 }
 
 // New instantiates a new cache entry
@@ -60,6 +65,13 @@ func (c *Cache[T]) GetWithTTL(ctx context.Context, key any) (T, time.Duration, e
 	return *new(T), duration, nil
 }
 
+// Fetch returns the object stored in cache if it exists, or loads it using the given
+// loader and stores it with the given options otherwise, along with whether it was found
+// in cache. Concurrent fetches of a key share a single call to the loader.
+func (c *Cache[T]) Fetch(ctx context.Context, key any, loader FetchFunction[T], options ...store.Option) (T, bool, error) {
+	return fetch(ctx, &c.fetchFlight, flightKey(c.getCacheKey(key)), c, key, loader, options...)
+}
+
 // Set populates the cache item using the given key
 func (c *Cache[T]) Set(ctx context.Context, key any, object T, options ...store.Option) error {
 	cacheKey := c.getCacheKey(key)
@@ -72,6 +84,128 @@ func (c *Cache[T]) Delete(ctx context.Context, key any) error {
 	return c.codec.Delete(ctx, cacheKey)
 }
 
+// GetMany returns the objects stored in cache for the given keys.
+// Keys that are not found are omitted from the returned map.
+func (c *Cache[T]) GetMany(ctx context.Context, keys []any) (map[any]T, error) {
+	cacheKeys := make([]any, 0, len(keys))
+	keysByCacheKey := make(map[any]any, len(keys))
+	for _, key := range keys {
+		cacheKey := c.getCacheKey(key)
+		cacheKeys = append(cacheKeys, cacheKey)
+		keysByCacheKey[cacheKey] = key
+	}
+
+	values, err := c.codec.GetMany(ctx, cacheKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make(map[any]T, len(values))
+	for cacheKey, value := range values {
+		key, ok := keysByCacheKey[cacheKey]
+		if !ok {
+			continue
+		}
+
+		if v, ok := value.(T); ok {
+			objects[key] = v
+		} else {
+			objects[key] = *new(T)
+		}
+	}
+
+	return objects, nil
+}
+
+// SetMany populates several cache items at once using the same options
+func (c *Cache[T]) SetMany(ctx context.Context, items map[any]T, options ...store.Option) error {
+	values := make(map[any]any, len(items))
+	for key, object := range items {
+		values[c.getCacheKey(key)] = object
+	}
+
+	return c.codec.SetMany(ctx, values, options...)
+}
+
+// DeleteMany removes the cache items using the given keys
+func (c *Cache[T]) DeleteMany(ctx context.Context, keys []any) error {
+	cacheKeys := make([]any, 0, len(keys))
+	for _, key := range keys {
+		cacheKeys = append(cacheKeys, c.getCacheKey(key))
+	}
+
+	return c.codec.DeleteMany(ctx, cacheKeys)
+}
+
+// Keys iterates over the keys held by the cache store, optionally filtered
+// using store.WithScanMatch. A single store.ErrUnsupported error is yielded
+// if the store cannot list its keys.
+func (c *Cache[T]) Keys(ctx context.Context, options ...store.ScanOption) iter.Seq2[any, error] {
+	return c.codec.Keys(ctx, options...)
+}
+
+// Increment atomically adds the given delta to the counter stored for the given
+// key and returns its new value. T must be an integer type (or any) and
+// store.ErrUnsupported is returned if the store does not support counters.
+func (c *Cache[T]) Increment(ctx context.Context, key any, delta int64, options ...store.Option) (T, error) {
+	value, err := c.codec.Increment(ctx, c.getCacheKey(key), delta, options...)
+	if err != nil {
+		return *new(T), err
+	}
+
+	return counterObject[T](value)
+}
+
+// Decrement atomically subtracts the given delta from the counter stored for
+// the given key and returns its new value. T must be an integer type (or any) and
+// store.ErrUnsupported is returned if the store does not support counters.
+func (c *Cache[T]) Decrement(ctx context.Context, key any, delta int64, options ...store.Option) (T, error) {
+	value, err := c.codec.Decrement(ctx, c.getCacheKey(key), delta, options...)
+	if err != nil {
+		return *new(T), err
+	}
+
+	return counterObject[T](value)
+}
+
+// SetIfNotExists populates the cache item using the given key only if it does not
+// exist yet, a store.ConditionFailed error being returned otherwise
+func (c *Cache[T]) SetIfNotExists(ctx context.Context, key any, object T, options ...store.Option) error {
+	return c.codec.SetIfNotExists(ctx, c.getCacheKey(key), object, options...)
+}
+
+// GetWithVersion returns the object stored in cache along with its version,
+// to be used with CompareAndSwap
+func (c *Cache[T]) GetWithVersion(ctx context.Context, key any) (T, store.Version, error) {
+	value, version, err := c.codec.GetWithVersion(ctx, c.getCacheKey(key))
+	if err != nil {
+		return *new(T), version, err
+	}
+
+	if v, ok := value.(T); ok {
+		return v, version, nil
+	}
+
+	return *new(T), version, nil
+}
+
+// CompareAndSwap populates the cache item using the given key only if the stored
+// object still matches the given version, a store.ConditionFailed error being
+// returned otherwise
+func (c *Cache[T]) CompareAndSwap(ctx context.Context, key any, object T, version store.Version, options ...store.Option) error {
+	return c.codec.CompareAndSwap(ctx, c.getCacheKey(key), object, version, options...)
+}
+
+// Touch changes the expiration of the given key without rewriting its value
+func (c *Cache[T]) Touch(ctx context.Context, key any, ttl time.Duration) error {
+	return c.codec.Touch(ctx, c.getCacheKey(key), ttl)
+}
+
+// SlidingExpiration returns the sliding expiration given to the key, if any
+func (c *Cache[T]) SlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	return c.codec.SlidingExpiration(ctx, c.getCacheKey(key))
+}
+
 // Invalidate invalidates cache item from given options
 func (c *Cache[T]) Invalidate(ctx context.Context, options ...store.InvalidateOption) error {
 	return c.codec.Invalidate(ctx, options...)
@@ -82,6 +216,11 @@ func (c *Cache[T]) Clear(ctx context.Context) error {
 	return c.codec.Clear(ctx)
 }
 
+// Capabilities returns the features supported by the cache store
+func (c *Cache[T]) Capabilities() store.Capabilities {
+	return c.codec.Capabilities()
+}
+
 // GetCodec returns the current codec
 func (c *Cache[T]) GetCodec() codec.CodecInterface {
 	return c.codec
@@ -95,7 +234,11 @@ func (c *Cache[T]) GetType() string {
 // getCacheKey returns the cache key for the given key object by returning
 // the key if type is string or by computing a checksum of key structure
 // if its type is other than string
-func (c *Cache[T]) getCacheKey(key any) string {
+func (c *Cache[T]) getCacheKey(key any) any {
+	if c.keyEncoder != nil {
+		return c.keyEncoder(key)
+	}
+
 	switch v := key.(type) {
 	case string:
 		return v
@@ -3,6 +3,8 @@ package cache
 import (
 	"context"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -125,6 +127,438 @@ func TestCacheGetWhenNotFound(t *testing.T) {
 	assert.Equal(t, returnedErr, err)
 }
 
+func TestCacheFetchWhenFound(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+	store.EXPECT().Get(gomock.Any(), "my-key").Return("my-value", nil)
+
+	cache := New[string](store)
+
+	loader := func(_ context.Context) (string, error) {
+		return "", errors.New("should not be called")
+	}
+
+	// When
+	value, hit, err := cache.Fetch(ctx, "my-key", loader)
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, hit)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestCacheFetchWhenNotFound(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+	store.EXPECT().Get(gomock.Any(), "my-key").Return(nil, libstore.NotFoundWithCause(nil))
+	store.EXPECT().Set(gomock.Any(), "my-key", "my-value", libstore.OptionsMatcher{
+		Expiration: 5 * time.Second,
+		Tags:       []string{"my-tag"},
+	}).Return(nil)
+
+	cache := New[string](store)
+
+	loader := func(_ context.Context) (string, error) {
+		return "my-value", nil
+	}
+
+	// When
+	value, hit, err := cache.Fetch(ctx, "my-key", loader, libstore.WithExpiration(5*time.Second), libstore.WithTags([]string{"my-tag"}))
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, hit)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestCacheFetchWhenLoaderFails(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	loaderErr := errors.New("unable to load value")
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+	store.EXPECT().Get(gomock.Any(), "my-key").Return(nil, libstore.NotFoundWithCause(nil))
+
+	cache := New[string](store)
+
+	loader := func(_ context.Context) (string, error) {
+		return "", loaderErr
+	}
+
+	// When
+	value, hit, err := cache.Fetch(ctx, "my-key", loader)
+
+	// Then
+	assert.ErrorIs(t, err, loaderErr)
+	assert.False(t, hit)
+	assert.Empty(t, value)
+}
+
+func TestCacheFetchConcurrently(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+	store.EXPECT().Get(gomock.Any(), "my-key").Return(nil, libstore.NotFoundWithCause(nil))
+	store.EXPECT().Set(gomock.Any(), "my-key", "my-value").Return(nil)
+
+	cache := New[string](store)
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	loader := func(_ context.Context) (string, error) {
+		calls.Add(1)
+		<-release
+		return "my-value", nil
+	}
+
+	// When
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Go(func() {
+			value, hit, err := cache.Fetch(ctx, "my-key", loader)
+			assert.Nil(t, err)
+			assert.False(t, hit)
+			assert.Equal(t, "my-value", value)
+		})
+	}
+
+	// let the other fetches join the running one
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	// Then
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestCacheGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	key := &struct {
+		Hello string
+	}{
+		Hello: "world",
+	}
+	cacheKey := checksum(key)
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+	store.EXPECT().Get(ctx, "my-key").Return("my-value", nil)
+	store.EXPECT().Get(ctx, cacheKey).Return("my-other-value", nil)
+	store.EXPECT().Get(ctx, "missing-key").Return(nil, libstore.NotFoundWithCause(errors.New("not found")))
+
+	cache := New[string](store)
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"my-key", key, "missing-key"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]string{"my-key": "my-value", key: "my-other-value"}, values)
+}
+
+func TestCacheSetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+	store.EXPECT().Set(ctx, "key-1", "value-1", libstore.OptionsMatcher{
+		Expiration: 5 * time.Second,
+	}).Return(nil)
+	store.EXPECT().Set(ctx, "key-2", "value-2", libstore.OptionsMatcher{
+		Expiration: 5 * time.Second,
+	}).Return(nil)
+
+	cache := New[string](store)
+
+	// When
+	err := cache.SetMany(ctx, map[any]string{"key-1": "value-1", "key-2": "value-2"}, libstore.WithExpiration(5*time.Second))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestCacheDeleteMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+	store.EXPECT().Delete(ctx, "key-1").Return(nil)
+	store.EXPECT().Delete(ctx, "key-2").Return(nil)
+
+	cache := New[string](store)
+
+	// When
+	err := cache.DeleteMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+type counterStore struct {
+	*mockstore.MockStoreInterface
+	*mockstore.MockCounterStoreInterface
+}
+
+func TestCacheIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := &counterStore{
+		MockStoreInterface:        mockstore.NewMockStoreInterface(ctrl),
+		MockCounterStoreInterface: mockstore.NewMockCounterStoreInterface(ctrl),
+	}
+	store.MockCounterStoreInterface.EXPECT().Increment(ctx, "my-counter", int64(3), gomock.Any()).Return(int64(10), nil)
+
+	cache := New[int](store)
+
+	// When
+	value, err := cache.Increment(ctx, "my-counter", 3, libstore.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 10, value)
+}
+
+func TestCacheDecrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := &counterStore{
+		MockStoreInterface:        mockstore.NewMockStoreInterface(ctrl),
+		MockCounterStoreInterface: mockstore.NewMockCounterStoreInterface(ctrl),
+	}
+	store.MockCounterStoreInterface.EXPECT().Decrement(ctx, "my-counter", int64(1)).Return(int64(-1), nil)
+
+	cache := New[any](store)
+
+	// When
+	value, err := cache.Decrement(ctx, "my-counter", 1)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), value)
+}
+
+func TestCacheIncrementWhenStoreDoesNotSupportCounters(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+
+	cache := New[int64](store)
+
+	// When
+	value, err := cache.Increment(ctx, "my-counter", 1)
+
+	// Then
+	assert.ErrorIs(t, err, libstore.ErrUnsupported)
+	assert.Equal(t, int64(0), value)
+}
+
+type conditionalStore struct {
+	*mockstore.MockStoreInterface
+	*mockstore.MockConditionalStoreInterface
+}
+
+func TestCacheSetIfNotExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := &conditionalStore{
+		MockStoreInterface:            mockstore.NewMockStoreInterface(ctrl),
+		MockConditionalStoreInterface: mockstore.NewMockConditionalStoreInterface(ctrl),
+	}
+	store.MockConditionalStoreInterface.EXPECT().SetIfNotExists(ctx, "my-lock", "owner", gomock.Any()).Return(nil)
+
+	cache := New[string](store)
+
+	// When
+	err := cache.SetIfNotExists(ctx, "my-lock", "owner", libstore.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestCacheGetWithVersion(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	version := libstore.NewVersion(uint64(42))
+
+	store := &conditionalStore{
+		MockStoreInterface:            mockstore.NewMockStoreInterface(ctrl),
+		MockConditionalStoreInterface: mockstore.NewMockConditionalStoreInterface(ctrl),
+	}
+	store.MockConditionalStoreInterface.EXPECT().GetWithVersion(ctx, "my-key").Return("my-value", version, nil)
+
+	cache := New[string](store)
+
+	// When
+	value, actualVersion, err := cache.GetWithVersion(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, version, actualVersion)
+}
+
+func TestCacheCompareAndSwapWhenConditionFails(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	version := libstore.NewVersion(uint64(42))
+
+	store := &conditionalStore{
+		MockStoreInterface:            mockstore.NewMockStoreInterface(ctrl),
+		MockConditionalStoreInterface: mockstore.NewMockConditionalStoreInterface(ctrl),
+	}
+	store.MockConditionalStoreInterface.EXPECT().CompareAndSwap(ctx, "my-key", "new-value", version).
+		Return(libstore.ConditionFailedWithCause(nil))
+
+	cache := New[string](store)
+
+	// When
+	err := cache.CompareAndSwap(ctx, "my-key", "new-value", version)
+
+	// Then
+	assert.ErrorIs(t, err, libstore.ConditionFailed{})
+}
+
+func TestCacheSetIfNotExistsWhenStoreDoesNotSupportConditionalWrites(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+
+	cache := New[string](store)
+
+	// When
+	err := cache.SetIfNotExists(ctx, "my-lock", "owner")
+
+	// Then
+	assert.ErrorIs(t, err, libstore.ErrUnsupported)
+}
+
+type touchStore struct {
+	*mockstore.MockStoreInterface
+	*mockstore.MockTouchStoreInterface
+}
+
+func TestCacheTouch(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := &touchStore{
+		MockStoreInterface:      mockstore.NewMockStoreInterface(ctrl),
+		MockTouchStoreInterface: mockstore.NewMockTouchStoreInterface(ctrl),
+	}
+	store.MockTouchStoreInterface.EXPECT().Touch(ctx, "my-key", time.Hour).Return(nil)
+
+	cache := New[string](store)
+
+	// When
+	err := cache.Touch(ctx, "my-key", time.Hour)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+type slidingStore struct {
+	*mockstore.MockStoreInterface
+	*mockstore.MockSlidingStoreInterface
+}
+
+func TestCacheSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := &slidingStore{
+		MockStoreInterface:        mockstore.NewMockStoreInterface(ctrl),
+		MockSlidingStoreInterface: mockstore.NewMockSlidingStoreInterface(ctrl),
+	}
+	store.MockSlidingStoreInterface.EXPECT().SlidingExpiration(ctx, "my-key").Return(time.Hour, nil)
+
+	cache := New[string](store)
+
+	// When
+	sliding, err := cache.SlidingExpiration(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, time.Hour, sliding)
+}
+
+func TestCacheSlidingExpirationWhenUnsupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache := New[string](mockstore.NewMockStoreInterface(ctrl))
+
+	// When
+	_, err := cache.SlidingExpiration(ctx, "my-key")
+
+	// Then
+	assert.ErrorIs(t, err, libstore.ErrUnsupported)
+}
+
+func TestCacheCapabilities(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+
+	cache := New[string](store)
+
+	// When
+	capabilities := cache.Capabilities()
+
+	// Then
+	assert.Equal(t, time.Second, capabilities.TTLPrecision)
+	assert.True(t, capabilities.Tags)
+	assert.False(t, capabilities.Batch)
+}
+
 func TestCacheGetWithTTL(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
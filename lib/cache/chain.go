@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
 	"sync"
 	"time"
 
 	"github.com/eko/gocache/lib/v4/store"
+	"golang.org/x/sync/singleflight"
 )
 
 const (
@@ -19,16 +21,21 @@ type chainKeyValue[T any] struct {
 	key          any
 	value        T
 	ttl          time.Duration
+	sliding      time.Duration
 	cacheAddress *string
+	// source is the cache layer the value has been read from without its expiration,
+	// which is then read from this layer before setting the value back
+	source SetterCacheInterface[T]
 }
 
 // ChainCache represents the configuration needed by a cache aggregator
 type ChainCache[T any] struct {
-	caches     []SetterCacheInterface[T]
-	setChannel chan *chainKeyValue[T]
-	done       chan struct{}
-	closeOnce  sync.Once
-	setterWg   sync.WaitGroup
+	caches      []SetterCacheInterface[T]
+	setChannel  chan *chainKeyValue[T]
+	done        chan struct{}
+	closeOnce   sync.Once
+	setterWg    sync.WaitGroup
+	fetchFlight singleflight.Group
 }
 
 // NewChain instantiates a new cache aggregator.
@@ -77,6 +84,10 @@ func (c *ChainCache[T]) drain() {
 
 // setUntilCacheAddress sets a value in available caches, until a given cache layer
 func (c *ChainCache[T]) setUntilCacheAddress(item *chainKeyValue[T]) {
+	if item.source != nil && !c.readExpiration(item) {
+		return
+	}
+
 	for _, cache := range c.caches {
 		cacheAddress := fmt.Sprintf("%p", cache)
 
@@ -84,35 +95,143 @@ func (c *ChainCache[T]) setUntilCacheAddress(item *chainKeyValue[T]) {
 			return
 		}
 
-		cache.Set(context.Background(), item.key, item.value, store.WithExpiration(item.ttl))
+		option := store.WithExpiration(item.ttl)
+		if item.sliding > 0 {
+			option = store.WithSlidingExpiration(item.sliding)
+		}
+
+		cache.Set(context.Background(), item.key, item.value, option)
+	}
+}
+
+// readExpiration reads the TTL and the sliding expiration of a value from the cache layer
+// it has been read from, and returns false if this layer does not hold it anymore so that
+// an expired or deleted value is not set back
+func (c *ChainCache[T]) readExpiration(item *chainKeyValue[T]) bool {
+	ctx := context.Background()
+
+	_, ttl, err := item.source.GetWithTTL(ctx, item.key)
+	if err != nil {
+		return false
 	}
+
+	item.ttl = ttl
+	item.sliding, _ = slidingExpiration(ctx, item.source, item.key)
+
+	return true
 }
 
-// Get returns the object stored in cache if it exists
+// Get returns the object stored in the first cache layer holding it.
+// Layers which do not hold the key, are unavailable or time out are skipped,
+// while an invalid value or a done context stops the lookup. Values having a
+// sliding expiration are set back into the upper cache layers with the same one.
 func (c *ChainCache[T]) Get(ctx context.Context, key any) (T, error) {
+	object, _, err := c.GetWithTTL(ctx, key)
+	return object, err
+}
+
+// GetWithTTL returns the object stored in the first cache layer holding it along with
+// its TTL in this layer, following the same rules as Get
+func (c *ChainCache[T]) GetWithTTL(ctx context.Context, key any) (T, time.Duration, error) {
 	var object T
 	if len(c.caches) == 0 {
-		return object, errors.New("no cache configured in chain")
+		return object, 0, errors.New("no cache configured in chain")
 	}
 
 	var err error
 	var ttl time.Duration
 
-	for _, cache := range c.caches {
+	for i, cache := range c.caches {
 		cacheAddress := fmt.Sprintf("%p", cache)
 
 		object, ttl, err = cache.GetWithTTL(ctx, key)
 		if err == nil {
+			var sliding time.Duration
+			if i > 0 {
+				// layers unable to renew expirations on reads have no sliding expiration
+				sliding, _ = slidingExpiration(ctx, cache, key)
+			}
+
 			// Set the value back until this cache layer
 			select {
-			case c.setChannel <- &chainKeyValue[T]{key, object, ttl, &cacheAddress}:
+			case c.setChannel <- &chainKeyValue[T]{key, object, ttl, sliding, &cacheAddress, nil}:
 			case <-c.done:
 			}
-			return object, nil
+			return object, ttl, nil
+		}
+		if !fallsThrough(ctx, err) {
+			return *new(T), 0, err
 		}
 	}
 
-	return object, err
+	return object, 0, err
+}
+
+// GetMany returns the objects stored in cache for the given keys.
+// Each cache layer is only asked for the keys that were not found in the
+// previous ones and found values are set back into the upper cache layers,
+// with the TTL and sliding expiration read from their layer in the background.
+// Keys that are not found in any cache are omitted from the returned map.
+func (c *ChainCache[T]) GetMany(ctx context.Context, keys []any) (map[any]T, error) {
+	if len(c.caches) == 0 {
+		return nil, errors.New("no cache configured in chain")
+	}
+
+	objects := make(map[any]T, len(keys))
+	remaining := keys
+	errs := []error{}
+
+	for i, cache := range c.caches {
+		if len(remaining) == 0 {
+			break
+		}
+
+		cacheAddress := fmt.Sprintf("%p", cache)
+
+		values, err := getMany(ctx, cache, remaining)
+		if err != nil && !fallsThrough(ctx, err) {
+			return nil, err
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		missing := make([]any, 0, len(remaining))
+		for _, key := range remaining {
+			object, ok := values[key]
+			if !ok {
+				missing = append(missing, key)
+				continue
+			}
+
+			objects[key] = object
+			if i == 0 {
+				continue
+			}
+
+			// Set the value back until this cache layer, with the TTL of this layer
+			select {
+			case c.setChannel <- &chainKeyValue[T]{key, object, 0, 0, &cacheAddress, cache}:
+			case <-c.done:
+			}
+		}
+		remaining = missing
+	}
+
+	if len(errs) == len(c.caches) {
+		return nil, errors.Join(errs...)
+	}
+
+	return objects, nil
+}
+
+// Fetch returns the object stored in the first cache holding it, or loads it using the
+// given loader and sets it in available caches with the given options otherwise, along
+// with whether it was found in cache. Concurrent fetches of a key share a single call
+// to the loader.
+func (c *ChainCache[T]) Fetch(ctx context.Context, key any, loader FetchFunction[T], options ...store.Option) (T, bool, error) {
+	return fetch(ctx, &c.fetchFlight, flightKey(key), c, key, loader, options...)
 }
 
 // Set sets a value in available caches
@@ -128,6 +247,19 @@ func (c *ChainCache[T]) Set(ctx context.Context, key any, object T, options ...s
 	return errors.Join(errs...)
 }
 
+// SetMany sets several values at once in available caches
+func (c *ChainCache[T]) SetMany(ctx context.Context, items map[any]T, options ...store.Option) error {
+	errs := []error{}
+	for _, cache := range c.caches {
+		err := setMany(ctx, cache, items, options...)
+		if err != nil {
+			storeType := cache.GetCodec().GetStore().GetType()
+			errs = append(errs, fmt.Errorf("unable to set items into cache with store '%s': %w", storeType, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // Delete removes a value from all available caches
 func (c *ChainCache[T]) Delete(ctx context.Context, key any) error {
 	for _, cache := range c.caches {
@@ -137,6 +269,228 @@ func (c *ChainCache[T]) Delete(ctx context.Context, key any) error {
 	return nil
 }
 
+// DeleteMany removes several values from all available caches
+func (c *ChainCache[T]) DeleteMany(ctx context.Context, keys []any) error {
+	for _, cache := range c.caches {
+		deleteMany(ctx, cache, keys)
+	}
+
+	return nil
+}
+
+// Keys iterates over the keys held by all available caches, each key being
+// yielded only once even if it is stored in several cache layers.
+// Layers that cannot list their keys are skipped and a single store.ErrUnsupported
+// error is yielded if none of them can.
+func (c *ChainCache[T]) Keys(ctx context.Context, options ...store.ScanOption) iter.Seq2[any, error] {
+	return func(yield func(any, error) bool) {
+		seen := map[any]struct{}{}
+		supported := false
+
+		for _, cache := range c.caches {
+			for key, err := range scanKeys(ctx, cache, options...) {
+				if errors.Is(err, store.ErrUnsupported) {
+					break
+				}
+				supported = true
+
+				if err == nil {
+					if _, ok := seen[key]; ok {
+						continue
+					}
+					seen[key] = struct{}{}
+				}
+
+				if !yield(key, err) {
+					return
+				}
+			}
+		}
+
+		if !supported {
+			yield(nil, store.ErrUnsupported)
+		}
+	}
+}
+
+// Increment atomically adds the given delta to the counter stored for the given key.
+// The counter is held by the last cache layer supporting counters, which usually is
+// the one shared between instances, and the key is removed from the other layers
+// so that subsequent reads are not served a stale value.
+func (c *ChainCache[T]) Increment(ctx context.Context, key any, delta int64, options ...store.Option) (T, error) {
+	var object T
+	index, err := c.lastSupporting(func(cache SetterCacheInterface[T]) (err error) {
+		object, err = increment(ctx, cache, key, delta, options...)
+		return err
+	})
+	if err != nil {
+		return *new(T), err
+	}
+
+	c.deleteFromOtherLayers(ctx, key, index)
+
+	return object, nil
+}
+
+// Decrement atomically subtracts the given delta from the counter stored for the
+// given key, following the same rules as Increment.
+func (c *ChainCache[T]) Decrement(ctx context.Context, key any, delta int64, options ...store.Option) (T, error) {
+	var object T
+	index, err := c.lastSupporting(func(cache SetterCacheInterface[T]) (err error) {
+		object, err = decrement(ctx, cache, key, delta, options...)
+		return err
+	})
+	if err != nil {
+		return *new(T), err
+	}
+
+	c.deleteFromOtherLayers(ctx, key, index)
+
+	return object, nil
+}
+
+// SetIfNotExists sets the object only if the key does not exist yet.
+// As for counters, the condition is checked by the last cache layer supporting
+// conditional writes and the key is removed from the other layers on success.
+func (c *ChainCache[T]) SetIfNotExists(ctx context.Context, key any, object T, options ...store.Option) error {
+	index, err := c.lastSupporting(func(cache SetterCacheInterface[T]) error {
+		return setIfNotExists(ctx, cache, key, object, options...)
+	})
+	if err != nil {
+		return err
+	}
+
+	c.deleteFromOtherLayers(ctx, key, index)
+
+	return nil
+}
+
+// GetWithVersion returns the object stored in the last cache layer supporting
+// conditional writes along with its version
+func (c *ChainCache[T]) GetWithVersion(ctx context.Context, key any) (T, store.Version, error) {
+	var (
+		object  T
+		version store.Version
+	)
+	_, err := c.lastSupporting(func(cache SetterCacheInterface[T]) (err error) {
+		object, version, err = getWithVersion(ctx, cache, key)
+		return err
+	})
+
+	return object, version, err
+}
+
+// CompareAndSwap sets the object only if the stored one still matches the given version,
+// following the same rules as SetIfNotExists.
+func (c *ChainCache[T]) CompareAndSwap(ctx context.Context, key any, object T, version store.Version, options ...store.Option) error {
+	index, err := c.lastSupporting(func(cache SetterCacheInterface[T]) error {
+		return compareAndSwap(ctx, cache, key, object, version, options...)
+	})
+	if err != nil {
+		return err
+	}
+
+	c.deleteFromOtherLayers(ctx, key, index)
+
+	return nil
+}
+
+// Touch changes the expiration of the given key in all available caches.
+// Caches that do not hold the key or that are not able to change expirations are
+// skipped: store.NotFound is returned if no cache holds the key and
+// store.ErrUnsupported if none of them supports it.
+func (c *ChainCache[T]) Touch(ctx context.Context, key any, ttl time.Duration) error {
+	errs := []error{}
+	supported, found := false, false
+	for _, cache := range c.caches {
+		err := touch(ctx, cache, key, ttl)
+		if errors.Is(err, store.ErrUnsupported) {
+			continue
+		}
+		supported = true
+
+		if errors.Is(err, store.NotFound{}) {
+			continue
+		}
+		if err != nil {
+			storeType := cache.GetCodec().GetStore().GetType()
+			errs = append(errs, fmt.Errorf("unable to touch item in cache with store '%s': %w", storeType, err))
+			continue
+		}
+		found = true
+	}
+
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	if !supported {
+		return store.ErrUnsupported
+	}
+	if !found {
+		return store.NotFoundWithCause(nil)
+	}
+
+	return nil
+}
+
+// SlidingExpiration returns the sliding expiration given to the key by the first
+// cache layer holding one. Layers that are not able to renew expirations on reads
+// are skipped and store.ErrUnsupported is returned if none of them supports it.
+func (c *ChainCache[T]) SlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	errs := []error{}
+	supported := false
+	for _, cache := range c.caches {
+		sliding, err := slidingExpiration(ctx, cache, key)
+		if errors.Is(err, store.ErrUnsupported) {
+			continue
+		}
+		supported = true
+
+		if err != nil {
+			storeType := cache.GetCodec().GetStore().GetType()
+			errs = append(errs, fmt.Errorf("unable to get sliding expiration in cache with store '%s': %w", storeType, err))
+			continue
+		}
+		if sliding > 0 {
+			return sliding, nil
+		}
+	}
+
+	if len(errs) > 0 {
+		return 0, errors.Join(errs...)
+	}
+	if !supported {
+		return 0, store.ErrUnsupported
+	}
+
+	return 0, nil
+}
+
+// lastSupporting runs the given operation on the last cache layer supporting it,
+// which usually is the one shared between instances, and returns its index
+func (c *ChainCache[T]) lastSupporting(operation func(SetterCacheInterface[T]) error) (int, error) {
+	for i := len(c.caches) - 1; i >= 0; i-- {
+		err := operation(c.caches[i])
+		if errors.Is(err, store.ErrUnsupported) {
+			continue
+		}
+
+		return i, err
+	}
+
+	return -1, store.ErrUnsupported
+}
+
+// deleteFromOtherLayers removes the key from all the cache layers but the given one
+// so that subsequent reads are not served a stale value
+func (c *ChainCache[T]) deleteFromOtherLayers(ctx context.Context, key any, index int) {
+	for i, cache := range c.caches {
+		if i != index {
+			cache.Delete(ctx, key)
+		}
+	}
+}
+
 // Invalidate invalidates cache item from given options
 func (c *ChainCache[T]) Invalidate(ctx context.Context, options ...store.InvalidateOption) error {
 	for _, cache := range c.caches {
@@ -155,6 +509,31 @@ func (c *ChainCache[T]) Clear(ctx context.Context) error {
 	return nil
 }
 
+// Capabilities returns the features supported by every cache of the chain,
+// as values are written to all of them
+func (c *ChainCache[T]) Capabilities() store.Capabilities {
+	if len(c.caches) == 0 {
+		return store.Capabilities{}
+	}
+
+	capabilities := capabilitiesOf(c.caches[0])
+	for _, cache := range c.caches[1:] {
+		capabilities = capabilities.Intersect(capabilitiesOf(cache))
+	}
+
+	return capabilities
+}
+
+// capabilitiesOf returns the capabilities reported by the cache when available
+// or the ones of its store otherwise
+func capabilitiesOf[T any](cache SetterCacheInterface[T]) store.Capabilities {
+	if describer, ok := cache.(CapabilitiesCacheInterface); ok {
+		return describer.Capabilities()
+	}
+
+	return store.CapabilitiesOf(cache.GetCodec().GetStore())
+}
+
 // GetCaches returns all Chained caches
 func (c *ChainCache[T]) GetCaches() []SetterCacheInterface[T] {
 	return c.caches
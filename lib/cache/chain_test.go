@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"errors"
+	"iter"
 	"testing"
 	"time"
 
@@ -14,6 +15,31 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
+// optionalCache is a setter cache also implementing all the optional cache interfaces
+type optionalCache[T any] struct {
+	*mockcache.MockSetterCacheInterface[T]
+	*mockcache.MockBatchCacheInterface[T]
+	*mockcache.MockScannerCacheInterface
+	*mockcache.MockCounterCacheInterface[T]
+	*mockcache.MockConditionalCacheInterface[T]
+	*mockcache.MockTouchCacheInterface
+	*mockcache.MockSlidingCacheInterface
+	*mockcache.MockCapabilitiesCacheInterface
+}
+
+func newOptionalCache[T any](ctrl *gomock.Controller) *optionalCache[T] {
+	return &optionalCache[T]{
+		MockSetterCacheInterface:       mockcache.NewMockSetterCacheInterface[T](ctrl),
+		MockBatchCacheInterface:        mockcache.NewMockBatchCacheInterface[T](ctrl),
+		MockScannerCacheInterface:      mockcache.NewMockScannerCacheInterface(ctrl),
+		MockCounterCacheInterface:      mockcache.NewMockCounterCacheInterface[T](ctrl),
+		MockConditionalCacheInterface:  mockcache.NewMockConditionalCacheInterface[T](ctrl),
+		MockTouchCacheInterface:        mockcache.NewMockTouchCacheInterface(ctrl),
+		MockSlidingCacheInterface:      mockcache.NewMockSlidingCacheInterface(ctrl),
+		MockCapabilitiesCacheInterface: mockcache.NewMockCapabilitiesCacheInterface(ctrl),
+	}
+}
+
 func TestNewChain(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -143,6 +169,623 @@ func TestChainGetWhenAvailableInSecondCache(t *testing.T) {
 	assert.Equal(t, cacheValue, value)
 }
 
+func TestChainGetWhenAvailableInSecondCacheWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	withSlidingExpiration := gomock.Cond(func(x any) bool {
+		options, ok := x.([]store.Option)
+		return ok && store.ApplyOptions(options...).SlidingExpiration == time.Minute
+	})
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return(nil, 0*time.Second, store.NotFoundWithCause(nil))
+	cache1.EXPECT().Set(ctx, "my-key", "my-value", withSlidingExpiration).Return(nil)
+
+	cache2 := newOptionalCache[any](ctrl)
+	cache2.MockSetterCacheInterface.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", time.Minute, nil)
+	cache2.MockSlidingCacheInterface.EXPECT().SlidingExpiration(ctx, "my-key").Return(time.Minute, nil)
+
+	cache := NewChain[any](cache1, cache2)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Closing waits for the values to be set back into the upper cache layers
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestChainGetWithTTL(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", 5*time.Second, nil)
+
+	// Cache 2
+	cache2 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	// When
+	value, ttl, err := cache.GetWithTTL(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, 5*time.Second, ttl)
+}
+
+func TestChainFetch(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(gomock.Any(), "my-key").Return(nil, 0*time.Second, store.NotFoundWithCause(nil))
+	cache1.EXPECT().Set(gomock.Any(), "my-key", "my-value", &store.OptionsMatcher{Expiration: time.Minute}).Return(nil)
+
+	// Cache 2
+	cache2 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetWithTTL(gomock.Any(), "my-key").Return(nil, 0*time.Second, store.NotFoundWithCause(nil))
+	cache2.EXPECT().Set(gomock.Any(), "my-key", "my-value", &store.OptionsMatcher{Expiration: time.Minute}).Return(nil)
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	loader := func(_ context.Context) (any, error) {
+		return "my-value", nil
+	}
+
+	// When
+	value, hit, err := cache.Fetch(ctx, "my-key", loader, store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.False(t, hit)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestChainFetchWhenFound(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(gomock.Any(), "my-key").Return("my-value", 5*time.Second, nil)
+
+	// Cache 2
+	cache2 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	loader := func(_ context.Context) (any, error) {
+		return nil, errors.New("should not be called")
+	}
+
+	// When
+	value, hit, err := cache.Fetch(ctx, "my-key", loader)
+
+	// Then
+	assert.Nil(t, err)
+	assert.True(t, hit)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestChainGetWhenFirstCacheUnavailable(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return(nil, 0*time.Second,
+		store.UnavailableWithCause(errors.New("connection refused")))
+	cache1.EXPECT().Set(gomock.Any(), "my-key", "my-value", gomock.Any()).AnyTimes().Return(nil)
+
+	cache2 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", 0*time.Second, nil)
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestChainGetWhenInvalidValueInFirstCache(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := store.InvalidValueWithCause(errors.New("unable to decode value"))
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return(nil, 0*time.Second, expectedErr)
+
+	cache2 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, value)
+	assert.ErrorIs(t, err, store.ErrInvalidValue)
+}
+
+func TestChainGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockBatchCacheInterface.EXPECT().GetMany(ctx, []any{"key-1", "key-2", "key-3"}).Return(map[any]any{"key-1": "value-1"}, nil)
+	cache1.MockSetterCacheInterface.EXPECT().Set(context.Background(), "key-2", "value-2", &store.OptionsMatcher{
+		Expiration: 10 * time.Second,
+	}).Times(1).Return(nil)
+
+	// Cache 2
+	cache2 := newOptionalCache[any](ctrl)
+	cache2.MockBatchCacheInterface.EXPECT().GetMany(ctx, []any{"key-2", "key-3"}).Return(map[any]any{"key-2": "value-2"}, nil)
+	cache2.MockSetterCacheInterface.EXPECT().GetWithTTL(context.Background(), "key-2").Return("value-2", 10*time.Second, nil)
+	cache2.MockSlidingCacheInterface.EXPECT().SlidingExpiration(context.Background(), "key-2").Return(time.Duration(0), nil)
+	cache2.MockSetterCacheInterface.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key-1", "key-2", "key-3"})
+
+	// Closing waits for the values to be set back into the upper cache layers
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1", "key-2": "value-2"}, values)
+}
+
+func TestChainGetManyWhenValueRemovedBeforeSetBack(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockBatchCacheInterface.EXPECT().GetMany(ctx, []any{"key-1"}).Return(map[any]any{}, nil)
+	cache1.MockSetterCacheInterface.EXPECT().Set(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	// Cache 2
+	cache2 := newOptionalCache[any](ctrl)
+	cache2.MockBatchCacheInterface.EXPECT().GetMany(ctx, []any{"key-1"}).Return(map[any]any{"key-1": "value-1"}, nil)
+	cache2.MockSetterCacheInterface.EXPECT().GetWithTTL(context.Background(), "key-1").Return(nil, time.Duration(0), store.NotFoundWithCause(nil))
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key-1"})
+
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1"}, values)
+}
+
+func TestChainGetManyWhenCachesDoNotSupportBatches(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// Cache 1
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "key-1").Return("value-1", nil)
+	cache1.EXPECT().Get(ctx, "key-2").Return(nil, store.NotFoundWithCause(nil))
+	cache1.EXPECT().Set(context.Background(), "key-2", "value-2", &store.OptionsMatcher{
+		Expiration: 10 * time.Second,
+	}).Return(nil)
+
+	// Cache 2
+	cache2 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().Get(ctx, "key-2").Return("value-2", nil)
+	cache2.EXPECT().GetWithTTL(context.Background(), "key-2").Return("value-2", 10*time.Second, nil)
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key-1", "key-2"})
+
+	// Closing waits for the values to be set back into the upper cache layers
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1", "key-2": "value-2"}, values)
+}
+
+func TestChainGetManyWhenErrorInAllCaches(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("unable to reach cache")
+
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockBatchCacheInterface.EXPECT().GetMany(ctx, []any{"key-1"}).Return(nil, expectedErr)
+
+	cache2 := newOptionalCache[any](ctrl)
+	cache2.MockBatchCacheInterface.EXPECT().GetMany(ctx, []any{"key-1"}).Return(nil, expectedErr)
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key-1"})
+
+	// Then
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Nil(t, values)
+}
+
+func TestChainIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Delete(ctx, "my-counter").Return(nil)
+
+	cache2 := newOptionalCache[any](ctrl)
+	cache2.MockCounterCacheInterface.EXPECT().Increment(ctx, "my-counter", int64(2)).Return(int64(7), nil)
+
+	cache3 := newOptionalCache[any](ctrl)
+	cache3.MockCounterCacheInterface.EXPECT().Increment(ctx, "my-counter", int64(2)).Return(nil, store.ErrUnsupported)
+	cache3.MockSetterCacheInterface.EXPECT().Delete(ctx, "my-counter").Return(nil)
+
+	cache := NewChain[any](cache1, cache2, cache3)
+	defer cache.Close()
+
+	// When
+	value, err := cache.Increment(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), value)
+}
+
+func TestChainDecrementWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("unable to decrement counter")
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+
+	cache2 := newOptionalCache[any](ctrl)
+	cache2.MockCounterCacheInterface.EXPECT().Decrement(ctx, "my-counter", int64(1)).Return(nil, expectedErr)
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	// When
+	value, err := cache.Decrement(ctx, "my-counter", 1)
+
+	// Then
+	assert.Equal(t, expectedErr, err)
+	assert.Nil(t, value)
+}
+
+func TestChainIncrementWhenNoCacheSupportsCounters(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockCounterCacheInterface.EXPECT().Increment(ctx, "my-counter", int64(1)).Return(nil, store.ErrUnsupported)
+
+	cache := NewChain[any](cache1)
+	defer cache.Close()
+
+	// When
+	_, err := cache.Increment(ctx, "my-counter", 1)
+
+	// Then
+	assert.ErrorIs(t, err, store.ErrUnsupported)
+}
+
+func TestChainSetIfNotExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockConditionalCacheInterface.EXPECT().SetIfNotExists(ctx, "my-lock", "owner").Return(nil)
+
+	cache2 := newOptionalCache[any](ctrl)
+	cache2.MockConditionalCacheInterface.EXPECT().SetIfNotExists(ctx, "my-lock", "owner").Return(store.ErrUnsupported)
+	cache2.MockSetterCacheInterface.EXPECT().Delete(ctx, "my-lock").Return(nil)
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	// When
+	err := cache.SetIfNotExists(ctx, "my-lock", "owner")
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestChainCompareAndSwapWhenConditionFails(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	version := store.NewVersion("old-value")
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+
+	cache2 := newOptionalCache[any](ctrl)
+	cache2.MockConditionalCacheInterface.EXPECT().CompareAndSwap(ctx, "my-key", "new-value", version).Return(store.ConditionFailedWithCause(nil))
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	// When
+	err := cache.CompareAndSwap(ctx, "my-key", "new-value", version)
+
+	// Then
+	assert.ErrorIs(t, err, store.ConditionFailed{})
+}
+
+func TestChainGetWithVersion(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	version := store.NewVersion("my-value")
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+
+	cache2 := newOptionalCache[any](ctrl)
+	cache2.MockConditionalCacheInterface.EXPECT().GetWithVersion(ctx, "my-key").Return("my-value", version, nil)
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	// When
+	value, actualVersion, err := cache.GetWithVersion(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, version, actualVersion)
+}
+
+func TestChainTouch(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockTouchCacheInterface.EXPECT().Touch(ctx, "my-key", time.Minute).Return(store.NotFoundWithCause(nil))
+
+	cache2 := newOptionalCache[any](ctrl)
+	cache2.MockTouchCacheInterface.EXPECT().Touch(ctx, "my-key", time.Minute).Return(store.ErrUnsupported)
+
+	cache3 := newOptionalCache[any](ctrl)
+	cache3.MockTouchCacheInterface.EXPECT().Touch(ctx, "my-key", time.Minute).Return(nil)
+
+	cache := NewChain[any](cache1, cache2, cache3)
+	defer cache.Close()
+
+	// When
+	err := cache.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestChainSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+
+	cache2 := newOptionalCache[any](ctrl)
+	cache2.MockSlidingCacheInterface.EXPECT().SlidingExpiration(ctx, "my-key").Return(time.Minute, nil)
+
+	cache3 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+
+	cache := NewChain[any](cache1, cache2, cache3)
+	defer cache.Close()
+
+	// When
+	sliding, err := cache.SlidingExpiration(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, time.Minute, sliding)
+}
+
+func TestChainSlidingExpirationWhenUnsupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+
+	cache := NewChain[any](cache1)
+	defer cache.Close()
+
+	// When
+	sliding, err := cache.SlidingExpiration(ctx, "my-key")
+
+	// Then
+	assert.ErrorIs(t, err, store.ErrUnsupported)
+	assert.Equal(t, 0*time.Second, sliding)
+}
+
+func TestChainTouchWhenNotFound(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockTouchCacheInterface.EXPECT().Touch(ctx, "my-key", time.Minute).Return(store.NotFoundWithCause(nil))
+
+	cache2 := newOptionalCache[any](ctrl)
+	cache2.MockTouchCacheInterface.EXPECT().Touch(ctx, "my-key", time.Minute).Return(store.NotFoundWithCause(nil))
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	// When
+	err := cache.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.ErrorIs(t, err, store.NotFound{})
+}
+
+func TestChainTouchWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("unable to touch item")
+
+	store1 := mockstore.NewMockStoreInterface(ctrl)
+	store1.EXPECT().GetType().Return("store1")
+
+	codec1 := mockcodec.NewMockCodecInterface(ctrl)
+	codec1.EXPECT().GetStore().Return(store1)
+
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockTouchCacheInterface.EXPECT().Touch(ctx, "my-key", time.Minute).Return(expectedErr)
+	cache1.MockSetterCacheInterface.EXPECT().GetCodec().Return(codec1)
+
+	cache2 := newOptionalCache[any](ctrl)
+	cache2.MockTouchCacheInterface.EXPECT().Touch(ctx, "my-key", time.Minute).Return(nil)
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	// When
+	err := cache.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.ErrorIs(t, err, expectedErr)
+}
+func TestChainKeys(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockScannerCacheInterface.EXPECT().Keys(ctx).Return(keysSeq("key-1", "key-2"))
+
+	cache2 := newOptionalCache[any](ctrl)
+	cache2.MockScannerCacheInterface.EXPECT().Keys(ctx).Return(keysSeq(store.ErrUnsupported))
+
+	cache3 := newOptionalCache[any](ctrl)
+	cache3.MockScannerCacheInterface.EXPECT().Keys(ctx).Return(keysSeq("key-2", "key-3"))
+
+	cache := NewChain[any](cache1, cache2, cache3)
+	defer cache.Close()
+
+	// When
+	keys := []any{}
+	for key, err := range cache.Keys(ctx) {
+		assert.Nil(t, err)
+		keys = append(keys, key)
+	}
+
+	// Then
+	assert.Equal(t, []any{"key-1", "key-2", "key-3"}, keys)
+}
+
+func TestChainKeysWhenNoCacheSupportsScan(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockScannerCacheInterface.EXPECT().Keys(ctx).Return(keysSeq(store.ErrUnsupported))
+
+	cache := NewChain[any](cache1)
+	defer cache.Close()
+
+	// When
+	var errs []error
+	for _, err := range cache.Keys(ctx) {
+		errs = append(errs, err)
+	}
+
+	// Then
+	assert.Equal(t, []error{store.ErrUnsupported}, errs)
+}
+
+// keysSeq returns a sequence yielding the given keys, or the given errors
+func keysSeq(keys ...any) iter.Seq2[any, error] {
+	return func(yield func(any, error) bool) {
+		for _, key := range keys {
+			var ok bool
+			if err, isErr := key.(error); isErr {
+				ok = yield(nil, err)
+			} else {
+				ok = yield(key, nil)
+			}
+			if !ok {
+				return
+			}
+		}
+	}
+}
+
 func TestChainGetWhenNotAvailableInAnyCache(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -534,3 +1177,69 @@ func TestChainSetWhenErrorInChain(t *testing.T) {
 	assert.ErrorIs(t, err, interError)
 	assert.ErrorContains(t, err, "unable to set item into cache with store 'store1'")
 }
+
+func TestChainCapabilities(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockCapabilitiesCacheInterface.EXPECT().Capabilities().Return(store.Capabilities{
+		TTLPrecision:   time.Millisecond,
+		Tags:           true,
+		Cost:           true,
+		SynchronousSet: true,
+	})
+
+	cache2 := newOptionalCache[any](ctrl)
+	cache2.MockCapabilitiesCacheInterface.EXPECT().Capabilities().Return(store.Capabilities{
+		TTLPrecision: time.Second,
+		Tags:         true,
+		Batch:        true,
+		MaxValueSize: 1024 * 1024,
+	})
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	// When
+	capabilities := cache.Capabilities()
+
+	// Then
+	assert.Equal(t, store.Capabilities{
+		TTLPrecision: time.Second,
+		Tags:         true,
+		MaxValueSize: 1024 * 1024,
+	}, capabilities)
+}
+
+func TestChainCapabilitiesWhenCacheDoesNotDescribeThem(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockCapabilitiesCacheInterface.EXPECT().Capabilities().Return(store.Capabilities{
+		TTLPrecision: time.Millisecond,
+		Tags:         true,
+		Batch:        true,
+	})
+
+	store2 := mockstore.NewMockStoreInterface(ctrl)
+
+	codec2 := mockcodec.NewMockCodecInterface(ctrl)
+	codec2.EXPECT().GetStore().Return(store2)
+
+	cache2 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache2.EXPECT().GetCodec().Return(codec2)
+
+	cache := NewChain[any](cache1, cache2)
+	defer cache.Close()
+
+	// When
+	capabilities := cache.Capabilities()
+
+	// Then
+	assert.Equal(t, store.Capabilities{
+		TTLPrecision: time.Second,
+		Tags:         true,
+	}, capabilities)
+}
@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/eko/gocache/lib/v4/store"
+)
+
+// setIfNotExists sets the object using the cache implementation when available
+// or returns store.ErrUnsupported otherwise
+func setIfNotExists[T any](ctx context.Context, cache CacheInterface[T], key any, object T, options ...store.Option) error {
+	if conditionalCache, ok := cache.(ConditionalCacheInterface[T]); ok {
+		return conditionalCache.SetIfNotExists(ctx, key, object, options...)
+	}
+
+	return store.ErrUnsupported
+}
+
+// getWithVersion returns the object and its version using the cache implementation
+// when available or returns store.ErrUnsupported otherwise
+func getWithVersion[T any](ctx context.Context, cache CacheInterface[T], key any) (T, store.Version, error) {
+	if conditionalCache, ok := cache.(ConditionalCacheInterface[T]); ok {
+		return conditionalCache.GetWithVersion(ctx, key)
+	}
+
+	return *new(T), store.Version{}, store.ErrUnsupported
+}
+
+// compareAndSwap sets the object using the cache implementation when available
+// or returns store.ErrUnsupported otherwise
+func compareAndSwap[T any](ctx context.Context, cache CacheInterface[T], key any, object T, version store.Version, options ...store.Option) error {
+	if conditionalCache, ok := cache.(ConditionalCacheInterface[T]); ok {
+		return conditionalCache.CompareAndSwap(ctx, key, object, version, options...)
+	}
+
+	return store.ErrUnsupported
+}
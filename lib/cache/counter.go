@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/eko/gocache/lib/v4/store"
+)
+
+// counterObject converts a counter value returned by a store to the cache type
+func counterObject[T any](value int64) (T, error) {
+	var object T
+
+	v := reflect.ValueOf(&object).Elem()
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if v.OverflowInt(value) {
+			return object, fmt.Errorf("counter value %d overflows %s", value, v.Type())
+		}
+		v.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if value < 0 || v.OverflowUint(uint64(value)) {
+			return object, fmt.Errorf("counter value %d overflows %s", value, v.Type())
+		}
+		v.SetUint(uint64(value))
+	case reflect.Interface:
+		if !reflect.TypeOf(value).AssignableTo(v.Type()) {
+			return object, fmt.Errorf("counter value cannot be assigned to %s", v.Type())
+		}
+		v.Set(reflect.ValueOf(value))
+	default:
+		return object, fmt.Errorf("counter value cannot be assigned to %s", v.Type())
+	}
+
+	return object, nil
+}
+
+// increment adds the given delta to the counter using the cache implementation
+// when available or returns store.ErrUnsupported otherwise
+func increment[T any](ctx context.Context, cache CacheInterface[T], key any, delta int64, options ...store.Option) (T, error) {
+	if counterCache, ok := cache.(CounterCacheInterface[T]); ok {
+		return counterCache.Increment(ctx, key, delta, options...)
+	}
+
+	return *new(T), store.ErrUnsupported
+}
+
+// decrement subtracts the given delta from the counter using the cache implementation
+// when available or returns store.ErrUnsupported otherwise
+func decrement[T any](ctx context.Context, cache CacheInterface[T], key any, delta int64, options ...store.Option) (T, error) {
+	if counterCache, ok := cache.(CounterCacheInterface[T]); ok {
+		return counterCache.Decrement(ctx, key, delta, options...)
+	}
+
+	return *new(T), store.ErrUnsupported
+}
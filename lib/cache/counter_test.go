@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounterObject(t *testing.T) {
+	// When
+	intValue, intErr := counterObject[int](42)
+	uintValue, uintErr := counterObject[uint16](42)
+	anyValue, anyErr := counterObject[any](42)
+
+	// Then
+	assert.Nil(t, intErr)
+	assert.Equal(t, 42, intValue)
+
+	assert.Nil(t, uintErr)
+	assert.Equal(t, uint16(42), uintValue)
+
+	assert.Nil(t, anyErr)
+	assert.Equal(t, int64(42), anyValue)
+}
+
+func TestCounterObjectWhenInvalid(t *testing.T) {
+	// When
+	_, overflowErr := counterObject[int8](1000)
+	_, negativeErr := counterObject[uint](-1)
+	_, stringErr := counterObject[string](42)
+
+	// Then
+	assert.EqualError(t, overflowErr, "counter value 1000 overflows int8")
+	assert.EqualError(t, negativeErr, "counter value -1 overflows uint")
+	assert.EqualError(t, stringErr, "counter value cannot be assigned to string")
+}
@@ -0,0 +1,27 @@
+package cache
+
+import (
+	"context"
+	"errors"
+
+	"github.com/eko/gocache/lib/v4/store"
+)
+
+// fallsThrough reports whether an error returned by a cache lets the caller fall back
+// on another source of the value, a lower cache layer or the load function: the key was
+// not found, or the store is unavailable, timed out or cannot handle the key.
+// Invalid values, which have been stored but cannot be read back, are reported instead
+// of being silently replaced, as well as errors occurring once the context is done.
+func fallsThrough(ctx context.Context, err error) bool {
+	return ctx.Err() == nil && !errors.Is(err, store.ErrInvalidValue)
+}
+
+// ErrNegativelyCached is wrapped by the store.NotFound error returned by LoadableCache when
+// the load function previously reported the key as not found and this absence has been
+// cached, see WithNegativeCaching
+var ErrNegativelyCached = errors.New("key is cached as not found")
+
+// ErrLoadBackedOff is wrapped, along with the last error of the load function, by the error
+// returned by LoadableCache when the load function recently failed to load the key and its
+// retry delay is not elapsed, see WithErrorCaching and WithErrorBackoff
+var ErrLoadBackedOff = errors.New("load function recently failed, retry delayed")
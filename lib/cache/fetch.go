@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/eko/gocache/lib/v4/store"
+	"golang.org/x/sync/singleflight"
+)
+
+// FetchFunction loads the value of a key missing from the cache, see Cache.Fetch
+type FetchFunction[T any] func(ctx context.Context) (T, error)
+
+// fetched is the result shared by the callers fetching the same key
+type fetched[T any] struct {
+	value T
+	hit   bool
+}
+
+// fetch returns the value stored in the given cache for the key, or loads it using the
+// loader and stores it into the cache with the given options when the key is not found
+// or the cache is unavailable. A single fetch runs at a time for each flight key, the
+// other callers waiting for its result: it is thus given a context detached from the
+// caller's one, each caller still stopping to wait once its own context is done.
+func fetch[T any](ctx context.Context, flight *singleflight.Group, flightKey string, cache CacheInterface[T], key any, loader FetchFunction[T], options ...store.Option) (T, bool, error) {
+	fn := func() (any, error) {
+		ctx := context.WithoutCancel(ctx)
+
+		value, err := cache.Get(ctx, key)
+		if err == nil {
+			return fetched[T]{value: value, hit: true}, nil
+		}
+		if !fallsThrough(ctx, err) {
+			return nil, err
+		}
+
+		value, err = loader(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// the loaded value is returned even when it cannot be stored
+		return fetched[T]{value: value}, cache.Set(ctx, key, value, options...)
+	}
+
+	var result any
+	var err error
+	if ctx.Done() == nil {
+		result, err, _ = flight.Do(flightKey, fn)
+	} else {
+		select {
+		case r := <-flight.DoChan(flightKey, fn):
+			result, err = r.Val, r.Err
+		case <-ctx.Done():
+			return *new(T), false, ctx.Err()
+		}
+	}
+
+	f, _ := result.(fetched[T])
+	return f.value, f.hit, err
+}
+
+// flightKey returns the key identifying the fetches of the given key
+func flightKey(key any) string {
+	switch v := key.(type) {
+	case string:
+		return v
+	case CacheKeyGenerator:
+		return v.GetCacheKey()
+	default:
+		return checksum(key)
+	}
+}
@@ -0,0 +1,252 @@
+package cache
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/eko/gocache/lib/v4/store"
+)
+
+// GetFunc is the signature of the Get operation of a cache
+type GetFunc[T any] func(ctx context.Context, key any) (T, error)
+
+// GetWithTTLFunc is the signature of the GetWithTTL operation of a cache
+type GetWithTTLFunc[T any] func(ctx context.Context, key any) (T, time.Duration, error)
+
+// SetFunc is the signature of the Set operation of a cache
+type SetFunc[T any] func(ctx context.Context, key any, object T, options ...store.Option) error
+
+// CounterFunc is the signature of the Increment and Decrement operations of a cache
+type CounterFunc[T any] func(ctx context.Context, key any, delta int64, options ...store.Option) (T, error)
+
+// GetWithVersionFunc is the signature of the GetWithVersion operation of a cache
+type GetWithVersionFunc[T any] func(ctx context.Context, key any) (T, store.Version, error)
+
+// CompareAndSwapFunc is the signature of the CompareAndSwap operation of a cache
+type CompareAndSwapFunc[T any] func(ctx context.Context, key any, object T, version store.Version, options ...store.Option) error
+
+// Interceptor wraps some operations of a cache, as store.Interceptor does for stores.
+// Operations whose function is nil are not intercepted.
+type Interceptor[T any] struct {
+	Get               func(next GetFunc[T]) GetFunc[T]
+	GetWithTTL        func(next GetWithTTLFunc[T]) GetWithTTLFunc[T]
+	Set               func(next SetFunc[T]) SetFunc[T]
+	Delete            func(next store.DeleteFunc) store.DeleteFunc
+	Invalidate        func(next store.InvalidateFunc) store.InvalidateFunc
+	Clear             func(next store.ClearFunc) store.ClearFunc
+	Keys              func(next store.KeysFunc) store.KeysFunc
+	Increment         func(next CounterFunc[T]) CounterFunc[T]
+	Decrement         func(next CounterFunc[T]) CounterFunc[T]
+	SetIfNotExists    func(next SetFunc[T]) SetFunc[T]
+	GetWithVersion    func(next GetWithVersionFunc[T]) GetWithVersionFunc[T]
+	CompareAndSwap    func(next CompareAndSwapFunc[T]) CompareAndSwapFunc[T]
+	Touch             func(next store.TouchFunc) store.TouchFunc
+	SlidingExpiration func(next store.SlidingExpirationFunc) store.SlidingExpirationFunc
+}
+
+// InterceptedCache is a cache whose operations go through a chain of interceptors.
+// Batch operations fall back to intercepted Get, Set and Delete calls. The optional
+// operations the wrapped cache does not support return store.ErrUnsupported at the
+// end of the chain.
+type InterceptedCache[T any] struct {
+	cache             CacheInterface[T]
+	get               GetFunc[T]
+	getWithTTL        GetWithTTLFunc[T]
+	set               SetFunc[T]
+	delete            store.DeleteFunc
+	invalidate        store.InvalidateFunc
+	clear             store.ClearFunc
+	keys              store.KeysFunc
+	increment         CounterFunc[T]
+	decrement         CounterFunc[T]
+	setIfNotExists    SetFunc[T]
+	getWithVersion    GetWithVersionFunc[T]
+	compareAndSwap    CompareAndSwapFunc[T]
+	touch             store.TouchFunc
+	slidingExpiration store.SlidingExpirationFunc
+}
+
+// NewIntercepted creates a new cache calling the given interceptors before the
+// wrapped cache. The first interceptor is the outermost one, called first.
+func NewIntercepted[T any](cache CacheInterface[T], interceptors ...Interceptor[T]) *InterceptedCache[T] {
+	c := &InterceptedCache[T]{
+		cache:      cache,
+		get:        cache.Get,
+		getWithTTL: getWithTTLFunc(cache),
+		set:        cache.Set,
+		delete:     cache.Delete,
+		invalidate: cache.Invalidate,
+		clear:      cache.Clear,
+	}
+	c.keys = c.cacheKeys
+	c.increment = c.cacheIncrement
+	c.decrement = c.cacheDecrement
+	c.setIfNotExists = c.cacheSetIfNotExists
+	c.getWithVersion = c.cacheGetWithVersion
+	c.compareAndSwap = c.cacheCompareAndSwap
+	c.touch = c.cacheTouch
+	c.slidingExpiration = c.cacheSlidingExpiration
+
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		c.get = intercept(c.get, interceptors[i].Get)
+		c.getWithTTL = intercept(c.getWithTTL, interceptors[i].GetWithTTL)
+		c.set = intercept(c.set, interceptors[i].Set)
+		c.delete = intercept(c.delete, interceptors[i].Delete)
+		c.invalidate = intercept(c.invalidate, interceptors[i].Invalidate)
+		c.clear = intercept(c.clear, interceptors[i].Clear)
+		c.keys = intercept(c.keys, interceptors[i].Keys)
+		c.increment = intercept(c.increment, interceptors[i].Increment)
+		c.decrement = intercept(c.decrement, interceptors[i].Decrement)
+		c.setIfNotExists = intercept(c.setIfNotExists, interceptors[i].SetIfNotExists)
+		c.getWithVersion = intercept(c.getWithVersion, interceptors[i].GetWithVersion)
+		c.compareAndSwap = intercept(c.compareAndSwap, interceptors[i].CompareAndSwap)
+		c.touch = intercept(c.touch, interceptors[i].Touch)
+		c.slidingExpiration = intercept(c.slidingExpiration, interceptors[i].SlidingExpiration)
+	}
+
+	return c
+}
+
+// getWithTTLFunc returns the GetWithTTL operation of the cache, or a function
+// returning store.ErrUnsupported if it has none
+func getWithTTLFunc[T any](cache CacheInterface[T]) GetWithTTLFunc[T] {
+	if ttlCache, ok := cache.(interface {
+		GetWithTTL(ctx context.Context, key any) (T, time.Duration, error)
+	}); ok {
+		return ttlCache.GetWithTTL
+	}
+
+	return func(context.Context, any) (T, time.Duration, error) {
+		return *new(T), 0, store.ErrUnsupported
+	}
+}
+
+// intercept wraps next with the given interceptor function, if any
+func intercept[F any](next F, interceptor func(next F) F) F {
+	if interceptor == nil {
+		return next
+	}
+
+	return interceptor(next)
+}
+
+// Get returns the object stored in cache through the interceptors
+func (c *InterceptedCache[T]) Get(ctx context.Context, key any) (T, error) {
+	return c.get(ctx, key)
+}
+
+// GetWithTTL returns the object stored in cache and its corresponding TTL through the interceptors
+func (c *InterceptedCache[T]) GetWithTTL(ctx context.Context, key any) (T, time.Duration, error) {
+	return c.getWithTTL(ctx, key)
+}
+
+// Set populates the cache item using the given key through the interceptors
+func (c *InterceptedCache[T]) Set(ctx context.Context, key any, object T, options ...store.Option) error {
+	return c.set(ctx, key, object, options...)
+}
+
+// Delete removes the cache item using the given key through the interceptors
+func (c *InterceptedCache[T]) Delete(ctx context.Context, key any) error {
+	return c.delete(ctx, key)
+}
+
+// Invalidate invalidates cache item from given options through the interceptors
+func (c *InterceptedCache[T]) Invalidate(ctx context.Context, options ...store.InvalidateOption) error {
+	return c.invalidate(ctx, options...)
+}
+
+// Clear resets all cache data through the interceptors
+func (c *InterceptedCache[T]) Clear(ctx context.Context) error {
+	return c.clear(ctx)
+}
+
+// Keys iterates over the keys held by the cache through the interceptors
+func (c *InterceptedCache[T]) Keys(ctx context.Context, options ...store.ScanOption) iter.Seq2[any, error] {
+	return c.keys(ctx, options...)
+}
+
+// Increment atomically adds the given delta to the counter held by the cache through the interceptors
+func (c *InterceptedCache[T]) Increment(ctx context.Context, key any, delta int64, options ...store.Option) (T, error) {
+	return c.increment(ctx, key, delta, options...)
+}
+
+// Decrement atomically subtracts the given delta from the counter held by the cache through the interceptors
+func (c *InterceptedCache[T]) Decrement(ctx context.Context, key any, delta int64, options ...store.Option) (T, error) {
+	return c.decrement(ctx, key, delta, options...)
+}
+
+// SetIfNotExists sets a value only if it does not exist yet through the interceptors
+func (c *InterceptedCache[T]) SetIfNotExists(ctx context.Context, key any, object T, options ...store.Option) error {
+	return c.setIfNotExists(ctx, key, object, options...)
+}
+
+// GetWithVersion returns a value along with its version through the interceptors
+func (c *InterceptedCache[T]) GetWithVersion(ctx context.Context, key any) (T, store.Version, error) {
+	return c.getWithVersion(ctx, key)
+}
+
+// CompareAndSwap sets a value only if it still matches the given version through the interceptors
+func (c *InterceptedCache[T]) CompareAndSwap(ctx context.Context, key any, object T, version store.Version, options ...store.Option) error {
+	return c.compareAndSwap(ctx, key, object, version, options...)
+}
+
+// Touch changes the expiration of the given key through the interceptors
+func (c *InterceptedCache[T]) Touch(ctx context.Context, key any, ttl time.Duration) error {
+	return c.touch(ctx, key, ttl)
+}
+
+// SlidingExpiration returns the sliding expiration given to the key through the interceptors
+func (c *InterceptedCache[T]) SlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	return c.slidingExpiration(ctx, key)
+}
+
+// cacheKeys iterates over the keys held by the wrapped cache
+func (c *InterceptedCache[T]) cacheKeys(ctx context.Context, options ...store.ScanOption) iter.Seq2[any, error] {
+	return scanKeys(ctx, c.cache, options...)
+}
+
+// cacheIncrement atomically adds the given delta to the counter held by the wrapped cache
+func (c *InterceptedCache[T]) cacheIncrement(ctx context.Context, key any, delta int64, options ...store.Option) (T, error) {
+	return increment(ctx, c.cache, key, delta, options...)
+}
+
+// cacheDecrement atomically subtracts the given delta from the counter held by the wrapped cache
+func (c *InterceptedCache[T]) cacheDecrement(ctx context.Context, key any, delta int64, options ...store.Option) (T, error) {
+	return decrement(ctx, c.cache, key, delta, options...)
+}
+
+// cacheSetIfNotExists sets a value in the wrapped cache only if it does not exist yet
+func (c *InterceptedCache[T]) cacheSetIfNotExists(ctx context.Context, key any, object T, options ...store.Option) error {
+	return setIfNotExists(ctx, c.cache, key, object, options...)
+}
+
+// cacheGetWithVersion returns a value from the wrapped cache along with its version
+func (c *InterceptedCache[T]) cacheGetWithVersion(ctx context.Context, key any) (T, store.Version, error) {
+	return getWithVersion(ctx, c.cache, key)
+}
+
+// cacheCompareAndSwap sets a value in the wrapped cache only if it still matches the given version
+func (c *InterceptedCache[T]) cacheCompareAndSwap(ctx context.Context, key any, object T, version store.Version, options ...store.Option) error {
+	return compareAndSwap(ctx, c.cache, key, object, version, options...)
+}
+
+// cacheTouch changes the expiration of the given key in the wrapped cache
+func (c *InterceptedCache[T]) cacheTouch(ctx context.Context, key any, ttl time.Duration) error {
+	return touch(ctx, c.cache, key, ttl)
+}
+
+// cacheSlidingExpiration returns the sliding expiration given to the key in the wrapped cache
+func (c *InterceptedCache[T]) cacheSlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	return slidingExpiration(ctx, c.cache, key)
+}
+
+// GetCache returns the wrapped cache
+func (c *InterceptedCache[T]) GetCache() CacheInterface[T] {
+	return c.cache
+}
+
+// GetType returns the type of the wrapped cache
+func (c *InterceptedCache[T]) GetType() string {
+	return c.cache.GetType()
+}
@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	mockcache "github.com/eko/gocache/lib/v4/internal/mocks/cache"
+	mockstore "github.com/eko/gocache/lib/v4/internal/mocks/store"
+	"github.com/eko/gocache/lib/v4/store"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewIntercepted(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetType().Return(ChainType)
+
+	// When
+	cache := NewIntercepted[any](cache1)
+
+	// Then
+	assert.IsType(t, new(InterceptedCache[any]), cache)
+	assert.Equal(t, cache1, cache.GetCache())
+	assert.Equal(t, ChainType, cache.GetType())
+}
+
+func TestInterceptedSet(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	calls := []string{}
+	prefixer := func(prefix string) Interceptor[any] {
+		return Interceptor[any]{
+			Set: func(next SetFunc[any]) SetFunc[any] {
+				return func(ctx context.Context, key any, object any, options ...store.Option) error {
+					calls = append(calls, prefix)
+					return next(ctx, prefix+key.(string), object, options...)
+				}
+			},
+		}
+	}
+
+	cache1 := mockcache.NewMockCacheInterface[any](ctrl)
+	cache1.EXPECT().Set(ctx, "b:a:my-key", "my-value").Return(nil)
+
+	cache := NewIntercepted[any](cache1, prefixer("a:"), prefixer("b:"))
+
+	// When
+	err := cache.Set(ctx, "my-key", "my-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a:", "b:"}, calls)
+}
+
+func TestInterceptedGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("injected failure")
+	failure := Interceptor[any]{
+		Get: func(next GetFunc[any]) GetFunc[any] {
+			return func(ctx context.Context, key any) (any, error) {
+				if key == "key-2" {
+					return nil, expectedErr
+				}
+				return next(ctx, key)
+			}
+		},
+	}
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "key-1").Return("value-1", nil)
+
+	cache := NewIntercepted[any](cache1, failure)
+
+	// When
+	values, err := getMany[any](ctx, cache, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, values)
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestInterceptedGetWithTTL(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", time.Minute, nil)
+
+	cache := NewIntercepted[any](cache1)
+
+	// When
+	value, ttl, err := cache.GetWithTTL(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, time.Minute, ttl)
+}
+
+func TestInterceptedGetWithTTLWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockCacheInterface[any](ctrl)
+
+	cache := NewIntercepted[any](cache1)
+
+	// When
+	value, ttl, err := cache.GetWithTTL(ctx, "my-key")
+
+	// Then
+	assert.ErrorIs(t, err, store.ErrUnsupported)
+	assert.Nil(t, value)
+	assert.Equal(t, time.Duration(0), ttl)
+}
+
+func TestInterceptedTouch(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store1 := &touchStore{
+		MockStoreInterface:      mockstore.NewMockStoreInterface(ctrl),
+		MockTouchStoreInterface: mockstore.NewMockTouchStoreInterface(ctrl),
+	}
+	store1.MockTouchStoreInterface.EXPECT().Touch(ctx, "v2:my-key", time.Hour).Return(nil)
+
+	rewrite := Interceptor[string]{
+		Touch: func(next store.TouchFunc) store.TouchFunc {
+			return func(ctx context.Context, key any, ttl time.Duration) error {
+				return next(ctx, "v2:"+key.(string), ttl)
+			}
+		},
+	}
+
+	cache := NewIntercepted[string](New[string](store1), rewrite)
+
+	// When
+	err := cache.Touch(ctx, "my-key", time.Hour)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestInterceptedIncrementWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockCacheInterface[int64](ctrl)
+
+	calls := []string{}
+	logging := Interceptor[int64]{
+		Increment: func(next CounterFunc[int64]) CounterFunc[int64] {
+			return func(ctx context.Context, key any, delta int64, options ...store.Option) (int64, error) {
+				calls = append(calls, key.(string))
+				return next(ctx, key, delta, options...)
+			}
+		},
+	}
+
+	cache := NewIntercepted[int64](cache1, logging)
+
+	// When
+	_, err := cache.Increment(ctx, "my-key", 1)
+
+	// Then
+	assert.ErrorIs(t, err, store.ErrUnsupported)
+	assert.Equal(t, []string{"my-key"}, calls)
+}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"iter"
 	"time"
 
 	"github.com/eko/gocache/lib/v4/codec"
@@ -18,6 +19,53 @@ type CacheInterface[T any] interface {
 	GetType() string
 }
 
+// BatchCacheInterface represents the interface for caches that are able to
+// handle several keys in a single operation
+type BatchCacheInterface[T any] interface {
+	GetMany(ctx context.Context, keys []any) (map[any]T, error)
+	SetMany(ctx context.Context, items map[any]T, options ...store.Option) error
+	DeleteMany(ctx context.Context, keys []any) error
+}
+
+// ScannerCacheInterface represents the interface for caches that are able to
+// iterate over the keys they hold
+type ScannerCacheInterface interface {
+	Keys(ctx context.Context, options ...store.ScanOption) iter.Seq2[any, error]
+}
+
+// CounterCacheInterface represents the interface for caches that are able to
+// atomically increment or decrement integer values
+type CounterCacheInterface[T any] interface {
+	Increment(ctx context.Context, key any, delta int64, options ...store.Option) (T, error)
+	Decrement(ctx context.Context, key any, delta int64, options ...store.Option) (T, error)
+}
+
+// ConditionalCacheInterface represents the interface for caches that are able to
+// write a value only when a condition is met
+type ConditionalCacheInterface[T any] interface {
+	SetIfNotExists(ctx context.Context, key any, object T, options ...store.Option) error
+	GetWithVersion(ctx context.Context, key any) (T, store.Version, error)
+	CompareAndSwap(ctx context.Context, key any, object T, version store.Version, options ...store.Option) error
+}
+
+// TouchCacheInterface represents the interface for caches that are able to
+// change the expiration of a key without rewriting its value
+type TouchCacheInterface interface {
+	Touch(ctx context.Context, key any, ttl time.Duration) error
+}
+
+// SlidingCacheInterface represents the interface for caches that are able to
+// report the sliding expiration given to a key
+type SlidingCacheInterface interface {
+	SlidingExpiration(ctx context.Context, key any) (time.Duration, error)
+}
+
+// CapabilitiesCacheInterface represents the interface for caches describing
+// the features supported by their stores
+type CapabilitiesCacheInterface interface {
+	Capabilities() store.Capabilities
+}
+
 type CacheKeyGenerator interface {
 	GetCacheKey() string
 }
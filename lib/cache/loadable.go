@@ -4,9 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"iter"
+	"math"
+	"math/rand/v2"
 	"reflect"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/eko/gocache/lib/v4/metrics"
 	"github.com/eko/gocache/lib/v4/store"
 	"golang.org/x/sync/singleflight"
 )
@@ -14,43 +21,154 @@ import (
 const (
 	// LoadableType represents the loadable cache type as a string value
 	LoadableType = "loadable"
+
+	// metadataPrefix prefixes the keys of the entries holding the load durations and the
+	// TTLs of the values when probabilistic early expiration or fractional refresh-ahead is
+	// enabled, see loadMetadata
+	metadataPrefix = "gocache_metadata_"
+
+	// loadDurationWeight is the inverse of the weight given to each load in the moving
+	// average of the load durations, see recordLoadDuration
+	loadDurationWeight = 8
 )
 
+// loadMetadata holds what is stored along with a loaded value in order to refresh it
+// ahead of its expiration, formatted as the duration the value took to load followed by
+// its TTL, e.g. "12ms 1m0s"
+type loadMetadata struct {
+	// recompute is the duration the value took to load
+	recompute time.Duration
+	// ttl is the TTL given by the load function, zero when unknown
+	ttl time.Duration
+}
+
+// String formats the metadata in order to store it
+func (m loadMetadata) String() string {
+	return m.recompute.String() + " " + m.ttl.String()
+}
+
+// parseLoadMetadata parses metadata formatted by loadMetadata.String, the TTL
+// being optional
+func parseLoadMetadata(s string) (loadMetadata, bool) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return loadMetadata{}, false
+	}
+
+	var (
+		m   loadMetadata
+		err error
+	)
+	if m.recompute, err = time.ParseDuration(fields[0]); err != nil {
+		return loadMetadata{}, false
+	}
+	if len(fields) == 2 {
+		if m.ttl, err = time.ParseDuration(fields[1]); err != nil {
+			return loadMetadata{}, false
+		}
+	}
+
+	return m, true
+}
+
 type loadableKeyValue[T any] struct {
 	key     any
 	value   T
 	options []store.Option
+	// cacheKey and generation identify the load of the value, see keyLoads
+	cacheKey   string
+	generation uint64
+	// metadata is stored along with the value, see metadataEntry
+	metadata loadMetadata
+	// batch holds the values loaded by batches, stored at once in place of key and value
+	batch []*loadableKeyValue[T]
+}
+
+// entries returns the items holding the values of the given item
+func (item *loadableKeyValue[T]) entries() []*loadableKeyValue[T] {
+	if item.batch != nil {
+		return item.batch
+	}
+
+	return []*loadableKeyValue[T]{item}
 }
 
+// negativeLoad is held by the temporary-while-setter-works cache for the keys whose
+// negative entry is waiting to be stored
+type negativeLoad struct{}
+
 type LoadFunction[T any] func(ctx context.Context, key any) (T, []store.Option, error)
 
+// LoadableStats allows to return some statistics of the loads and background refreshes
+type LoadableStats = metrics.LoaderStats
+
 // LoadableCache represents a cache that uses a function to load data
 type LoadableCache[T any] struct {
-	singleFlight singleflight.Group
-	loadFunc     LoadFunction[T]
-	cache        CacheInterface[T]
-	setChannel   chan *loadableKeyValue[T]
-	setCache     sync.Map
-	done         chan struct{}
-	closeOnce    sync.Once
-	setterWg     sync.WaitGroup
+	singleFlight  singleflight.Group
+	refreshFlight singleflight.Group
+	refreshSlots  semaphore
+	loadSlots     semaphore
+	failures      sync.Map
+	stats         LoadableStats
+	statsMtx      sync.Mutex
+	loadDuration  atomic.Int64
+	loadFunc      LoadFunction[T]
+	batcher       *batcher[T]
+	cache         CacheInterface[T]
+	setChannel    chan *loadableKeyValue[T]
+	setCache      sync.Map
+	loads         map[string]*keyLoads
+	loadsMtx      sync.Mutex
+	done          chan struct{}
+	closeOnce     sync.Once
+	setterWg      sync.WaitGroup
+	options       *loadableOptions
+	negative      *negativeCaching[T]
 }
 
 // NewLoadable instantiates a new cache that uses a function to load data.
 //
-// It starts a background goroutine responsible for storing the loaded values into
-// the cache: call Close when the cache is not used anymore to release it.
-func NewLoadable[T any](loadFunc LoadFunction[T], cache CacheInterface[T]) *LoadableCache[T] {
+// It starts background goroutines responsible for storing the loaded values into
+// the cache, see WithSetterWorkers and WithSynchronousSet: call Close when the cache
+// is not used anymore to release them.
+func NewLoadable[T any](loadFunc LoadFunction[T], cache CacheInterface[T], options ...LoadableOption) *LoadableCache[T] {
+	return newLoadable(loadFunc, nil, cache, options...)
+}
+
+func newLoadable[T any](loadFunc LoadFunction[T], batchLoadFunc BatchLoadFunction[T], cache CacheInterface[T], options ...LoadableOption) *LoadableCache[T] {
 	loadable := &LoadableCache[T]{
 		singleFlight: singleflight.Group{},
 		loadFunc:     loadFunc,
 		cache:        cache,
-		setChannel:   make(chan *loadableKeyValue[T], 10000),
+		loads:        map[string]*keyLoads{},
 		done:         make(chan struct{}),
+		options:      applyLoadableOptions(options...),
+	}
+
+	if loadable.options.negativeCacheTTL > 0 {
+		negative, ok := loadable.options.negativeCaching.(*negativeCaching[T])
+		if !ok {
+			panic(fmt.Sprintf("cache: the negative caching marker is a %s, not a %s",
+				loadable.options.negativeCaching.markerType(), reflect.TypeFor[T]()))
+		}
+		loadable.negative = negative
 	}
 
-	loadable.setterWg.Add(1)
-	go loadable.setter()
+	loadable.setChannel = make(chan *loadableKeyValue[T], loadable.options.setterQueueSize)
+	loadable.refreshSlots = newSemaphore(loadable.options.maxRefreshes)
+	loadable.loadSlots = newSemaphore(loadable.options.maxLoads)
+
+	if batchLoadFunc != nil {
+		loadable.batcher = newBatcher(batchLoadFunc, loadable)
+		loadable.loadFunc = loadable.batcher.load
+	}
+
+	if !loadable.options.synchronousSet {
+		loadable.setterWg.Add(loadable.options.setterWorkers)
+		for range loadable.options.setterWorkers {
+			go loadable.setter()
+		}
+	}
 
 	return loadable
 }
@@ -83,83 +201,819 @@ func (c *LoadableCache[T]) drain() {
 }
 
 // setItem stores a loaded value into the cache and releases it from the
-// temporary-while-setter-works cache
+// temporary-while-setter-works cache. Values discarded in the meantime are not stored,
+// and the ones discarded while being stored are deleted again, no lock being held
+// during the round-trips to the cache.
 func (c *LoadableCache[T]) setItem(item *loadableKeyValue[T]) {
-	c.Set(context.Background(), item.key, item.value, item.options...)
+	defer c.finish(item)
+
+	ctx := context.Background()
+
+	if item.batch == nil {
+		if c.current(item.cacheKey, item.generation) {
+			c.cache.Set(ctx, item.key, item.value, item.options...)
+			if key, metadata, ok := c.metadataEntry(item); ok {
+				c.cache.Set(ctx, key, metadata, item.options...)
+			}
+			c.revert(ctx, []*loadableKeyValue[T]{item})
+		}
+		return
+	}
+
+	stored := make([]*loadableKeyValue[T], 0, len(item.batch))
+	items := make(map[any]T, len(item.batch))
+	for _, entry := range item.batch {
+		if c.current(entry.cacheKey, entry.generation) {
+			stored = append(stored, entry)
+			items[entry.key] = entry.value
+			if key, metadata, ok := c.metadataEntry(entry); ok {
+				items[key] = metadata
+			}
+		}
+	}
 
-	cacheKey := c.getCacheKey(item.key)
-	c.setCache.Delete(cacheKey)
+	if len(items) > 0 {
+		setMany(ctx, c.cache, items, item.options...)
+		c.revert(ctx, stored)
+	}
+}
+
+// revert deletes from the cache the given stored entries whose keys have been set or
+// deleted while they were being stored, along with their metadata, so that they are
+// loaded again rather than overriding the values written in the meantime
+func (c *LoadableCache[T]) revert(ctx context.Context, entries []*loadableKeyValue[T]) {
+	discarded := c.discarded(entries)
+	if len(discarded) == 0 {
+		return
+	}
+
+	keys := make([]any, 0, 2*len(discarded))
+	for _, entry := range discarded {
+		keys = append(keys, entry.key)
+		if key, _, ok := c.metadataEntry(entry); ok {
+			keys = append(keys, key)
+		}
+	}
+
+	deleteMany(ctx, c.cache, keys)
 }
 
-// Get returns the object stored in cache if it exists
+// Get returns the object stored in cache if it exists, or loads it using the load function
+// when the key is not found or the cache is unavailable. Invalid values are returned as errors.
+// When negative caching is enabled, keys cached as not found are returned as store.NotFound
+// errors wrapping ErrNegativelyCached.
 func (c *LoadableCache[T]) Get(ctx context.Context, key any) (T, error) {
 	cacheKey := c.getCacheKey(key)
-	if value, err, _ := c.singleFlight.Do(
-		cacheKey,
-		func() (any, error) {
-			// try temporary-while-setter-works cache
-			if v, ok := c.setCache.Load(cacheKey); ok {
-				return v, nil
+	return c.do(ctx, cacheKey, func(ctx context.Context) (any, error) {
+		// try temporary-while-setter-works cache
+		if v, ok := c.setCache.Load(cacheKey); ok {
+			return c.cached(v)
+		}
+		// try main cache
+		v, ttl, err := c.getWithTTL(ctx, key)
+		if err == nil {
+			return c.fresh(ctx, key, cacheKey, v, ttl)
+		}
+		if !fallsThrough(ctx, err) {
+			return v, err
+		}
+		// Unable to find in cache, try to load it from load function
+		return c.load(ctx, key, cacheKey)
+	})
+}
+
+// do runs the given function once at a time for each key and returns its result,
+// unless the given context is done before: the call then goes on for the other
+// callers waiting for it. The function is thus given a context keeping the values
+// of the given one but not cancelled along with it, the given context only bounding
+// the wait of the caller.
+func (c *LoadableCache[T]) do(ctx context.Context, cacheKey string, fn func(ctx context.Context) (any, error)) (T, error) {
+	if ctx.Done() == nil {
+		// the caller cannot stop waiting, spare the goroutine running the call
+		value, err, _ := c.singleFlight.Do(cacheKey, func() (any, error) { return fn(ctx) })
+		return c.result(value, err)
+	}
+
+	shared := context.WithoutCancel(ctx)
+	select {
+	case r := <-c.singleFlight.DoChan(cacheKey, func() (any, error) { return fn(shared) }):
+		return c.result(r.Val, r.Err)
+	case <-ctx.Done():
+		return *new(T), ctx.Err()
+	}
+}
+
+// GetMany returns the objects stored in cache for the given keys, the ones
+// that are not available in cache are loaded using the load function.
+// Keys the load function cannot find are omitted from the returned map.
+func (c *LoadableCache[T]) GetMany(ctx context.Context, keys []any) (map[any]T, error) {
+	objects := make(map[any]T, len(keys))
+
+	remaining := make([]any, 0, len(keys))
+	for _, key := range keys {
+		// try temporary-while-setter-works cache
+		if v, ok := c.setCache.Load(c.getCacheKey(key)); ok {
+			if _, err := c.cached(v); err != nil {
+				continue
 			}
-			// try main cache
-			if v, err := c.cache.Get(ctx, key); err == nil {
-				return v, err
+			if object, ok := v.(T); ok {
+				objects[key] = object
+				continue
 			}
-			// Unable to find in cache, try to load it from load function
-			if value, options, err := c.loadFunc(ctx, key); err == nil {
-
-				// cache locally until main cache is set
-				c.setCache.Store(cacheKey, value)
-
-				select {
-				case c.setChannel <- &loadableKeyValue[T]{
-					key:     key,
-					value:   value,
-					options: options,
-				}:
-				case <-c.done:
-					// no setter left to hand the value over to, do not retain it
-					c.setCache.Delete(cacheKey)
-				}
-
-				return value, err
-			} else {
-				return *new(T), err
+		}
+		remaining = append(remaining, key)
+	}
+
+	if len(remaining) == 0 {
+		return objects, nil
+	}
+
+	// try main cache
+	values, err := getMany(ctx, c.cache, remaining)
+	if err != nil && !fallsThrough(ctx, err) {
+		return nil, err
+	}
+	if err != nil {
+		values = map[any]T{}
+	}
+
+	missing := make([]any, 0, len(remaining))
+	for _, key := range remaining {
+		if object, ok := values[key]; ok {
+			if _, err := c.cached(object); err == nil {
+				objects[key] = object
 			}
-		},
-	); err != nil {
+			continue
+		}
+		missing = append(missing, key)
+	}
+
+	// Unable to find in cache, try to load them from load function
+	results := c.loadMany(ctx, missing)
+	for i, key := range missing {
+		if errors.Is(results[i].err, store.NotFound{}) {
+			continue
+		}
+		if results[i].err != nil {
+			return nil, results[i].err
+		}
+		objects[key] = results[i].object
+	}
+
+	return objects, nil
+}
+
+type loadResult[T any] struct {
+	object T
+	err    error
+}
+
+// loadMany loads the values of the given keys, concurrently when they are loaded by
+// batches so that they are coalesced into the same batches
+func (c *LoadableCache[T]) loadMany(ctx context.Context, keys []any) []loadResult[T] {
+	results := make([]loadResult[T], len(keys))
+	if c.batcher == nil {
+		for i, key := range keys {
+			object, err := c.loadOnce(ctx, key)
+			results[i] = loadResult[T]{object, err}
+			if err != nil && !errors.Is(err, store.NotFound{}) {
+				break
+			}
+		}
+		return results
+	}
+
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Go(func() {
+			object, err := c.loadOnce(ctx, key)
+			results[i] = loadResult[T]{object, err}
+		})
+	}
+	wg.Wait()
+
+	return results
+}
+
+// loadOnce loads the value of the given key, a single load running at a time for each key
+func (c *LoadableCache[T]) loadOnce(ctx context.Context, key any) (T, error) {
+	cacheKey := c.getCacheKey(key)
+	return c.do(ctx, cacheKey, func(ctx context.Context) (any, error) {
+		if v, ok := c.setCache.Load(cacheKey); ok {
+			return c.cached(v)
+		}
+		return c.load(ctx, key, cacheKey)
+	})
+}
+
+// load loads the value of the given key using the load function and hands
+// it over to the setter in order to store it into the cache. The load function
+// is given a context detached from the caller's one, see detach.
+func (c *LoadableCache[T]) load(ctx context.Context, key any, cacheKey string) (any, error) {
+	ctx, cancel := detach(ctx, c.options.loadTimeout)
+	defer cancel()
+
+	generation := c.begin(cacheKey)
+	defer c.end(cacheKey)
+
+	if err := c.backedOff(cacheKey); err != nil {
+		c.updateStats(func(stats *LoadableStats) { stats.LoadsBackedOff++ })
 		return *new(T), err
-	} else if value, ok := value.(T); ok {
+	}
+
+	start := time.Now()
+	value, options, err := c.callLoadFunc(ctx, key)
+	c.recordLoad(cacheKey, err)
+	if err != nil {
+		if errors.Is(err, store.NotFound{}) {
+			c.loadNegative(key, cacheKey, generation)
+		}
+		return *new(T), err
+	}
+
+	if c.batcher != nil {
+		// the batch has already been handed over to the setter
+		return value, nil
+	}
+
+	delta := time.Since(start)
+	c.recordLoadDuration(delta)
+
+	// cache locally until main cache is set, unless the key has been set or deleted
+	if !c.keep(cacheKey, generation, value) {
+		return value, nil
+	}
+
+	c.handOver(&loadableKeyValue[T]{
+		key:        key,
+		value:      value,
+		options:    c.storeOptions(options),
+		cacheKey:   cacheKey,
+		generation: generation,
+		metadata:   loadMetadata{recompute: delta, ttl: loadedTTL(options)},
+	})
+
+	return value, nil
+}
+
+// recordLoadDuration updates the moving average of the durations the loads take, used
+// as delta by probabilistic early expiration for the values stored without their own
+func (c *LoadableCache[T]) recordLoadDuration(delta time.Duration) {
+	if c.options.earlyExpirationBeta <= 0 {
+		return
+	}
+
+	for {
+		average := c.loadDuration.Load()
+		updated := int64(delta)
+		if average > 0 {
+			updated = average + (int64(delta)-average)/loadDurationWeight
+		}
+		if c.loadDuration.CompareAndSwap(average, updated) {
+			return
+		}
+	}
+}
+
+// loadedTTL returns the TTL given by the options returned by the load function,
+// zero when they do not set any expiration
+func loadedTTL(options []store.Option) time.Duration {
+	opts := store.ApplyOptions(options...)
+	if !opts.ExpireAt.IsZero() {
+		return max(time.Until(opts.ExpireAt), 0)
+	}
+
+	return opts.Expiration
+}
+
+// metadataEntry returns the key and the value of the entry holding the metadata of the
+// value of the given item, stored along with it with the same expiration when the
+// metadata is used to refresh the values and the values of the cache can hold strings
+func (c *LoadableCache[T]) metadataEntry(item *loadableKeyValue[T]) (string, T, bool) {
+	if !c.options.storesMetadata() || item.metadata == (loadMetadata{}) {
+		return "", *new(T), false
+	}
+
+	value, ok := fromString[T](item.metadata.String())
+	return metadataPrefix + item.cacheKey, value, ok
+}
+
+// loadMetadata returns the metadata stored along with the value of the given key
+func (c *LoadableCache[T]) loadMetadata(ctx context.Context, cacheKey string) (loadMetadata, bool) {
+	if _, ok := fromString[T](""); !ok {
+		return loadMetadata{}, false
+	}
+
+	v, err := c.cache.Get(ctx, metadataPrefix+cacheKey)
+	if err != nil {
+		return loadMetadata{}, false
+	}
+
+	s, ok := toString(v)
+	if !ok {
+		return loadMetadata{}, false
+	}
+
+	return parseLoadMetadata(s)
+}
+
+// refreshesAhead returns whether a value having the given remaining TTL has to be
+// refreshed before its expiration, because of refresh-ahead or of probabilistic early
+// expiration
+func (c *LoadableCache[T]) refreshesAhead(ctx context.Context, cacheKey string, remaining time.Duration) bool {
+	if remaining < c.options.refreshAhead {
+		return true
+	}
+
+	if !c.options.storesMetadata() {
+		return false
+	}
+
+	metadata, _ := c.loadMetadata(ctx, cacheKey)
+	if remaining < time.Duration(c.options.refreshAheadFraction*float64(metadata.ttl)) {
+		return true
+	}
+
+	return c.expiresEarly(metadata.recompute, remaining)
+}
+
+// expiresEarly returns whether a value having the given remaining TTL has to be
+// refreshed before its expiration, according to the XFetch algorithm. The given duration
+// the value took to load is read from the cache, the moving average of the durations of
+// the loads of the instance being used when it has not been stored along with the value.
+func (c *LoadableCache[T]) expiresEarly(delta time.Duration, remaining time.Duration) bool {
+	if c.options.earlyExpirationBeta <= 0 {
+		return false
+	}
+
+	if delta <= 0 {
+		delta = time.Duration(c.loadDuration.Load())
+	}
+	if delta <= 0 {
+		return false
+	}
+
+	gap := float64(delta) * c.options.earlyExpirationBeta * -math.Log(1-rand.Float64())
+
+	return gap >= float64(remaining)
+}
+
+// callLoadFunc calls the load function without exceeding the maximum number of
+// concurrent loads, the batches limiting themselves their loads
+func (c *LoadableCache[T]) callLoadFunc(ctx context.Context, key any) (T, []store.Option, error) {
+	if c.batcher != nil {
+		return c.loadFunc(ctx, key)
+	}
+
+	if err := c.loadSlots.acquire(ctx); err != nil {
+		return *new(T), nil, err
+	}
+	defer c.loadSlots.release()
+
+	return c.loadFunc(ctx, key)
+}
+
+// loadFailure holds the last error returned by the load function for a key, along
+// with the number of consecutive failures and the time until which it is returned
+type loadFailure struct {
+	err      error
+	failures int
+	until    time.Time
+}
+
+// recordLoad updates the statistics of the loads and the failures of the given key,
+// when errors are cached
+func (c *LoadableCache[T]) recordLoad(cacheKey string, err error) {
+	failed := err != nil && !errors.Is(err, store.NotFound{})
+	c.updateStats(func(stats *LoadableStats) {
+		stats.Loads++
+		if failed {
+			stats.LoadErrors++
+		}
+	})
+
+	if !c.options.cachesErrors() {
+		return
+	}
+
+	if !failed {
+		c.failures.Delete(cacheKey)
+		return
+	}
+
+	failures := 1
+	if v, ok := c.failures.Load(cacheKey); ok {
+		failures = v.(*loadFailure).failures + 1
+	}
+
+	c.failures.Store(cacheKey, &loadFailure{
+		err:      err,
+		failures: failures,
+		until:    time.Now().Add(c.options.retryDelay(failures)),
+	})
+}
+
+// backedOff returns the last error of the load function for the given key while
+// its retry delay is not elapsed, when errors are cached
+func (c *LoadableCache[T]) backedOff(cacheKey string) error {
+	if !c.options.cachesErrors() {
+		return nil
+	}
+
+	v, ok := c.failures.Load(cacheKey)
+	if !ok {
+		return nil
+	}
+
+	failure := v.(*loadFailure)
+	if time.Now().After(failure.until) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %w", ErrLoadBackedOff, failure.err)
+}
+
+// forget discards the values being loaded and the failures of a key set or deleted
+func (c *LoadableCache[T]) forget(key any) {
+	cacheKey := c.getCacheKey(key)
+	c.discard(cacheKey)
+	c.failures.Delete(cacheKey)
+}
+
+// detach returns a context keeping the values of the given one, such as traces, but
+// not cancelled along with it: the value loaded for a caller is shared with the other
+// callers waiting for it, and stored into the cache. The context is cancelled once the
+// given timeout, if any, is elapsed.
+func detach(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx = context.WithoutCancel(ctx)
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// loadNegative hands the negative entry of a key the load function reports as not
+// found over to the setter, when negative caching is enabled
+func (c *LoadableCache[T]) loadNegative(key any, cacheKey string, generation uint64) {
+	if c.negative == nil {
+		return
+	}
+
+	// cache locally until main cache is set, unless the key has been set or deleted
+	if !c.keep(cacheKey, generation, negativeLoad{}) {
+		return
+	}
+
+	c.handOver(&loadableKeyValue[T]{
+		key:        key,
+		value:      c.negative.marker,
+		options:    []store.Option{store.WithExpiration(c.options.negativeCacheTTL)},
+		cacheKey:   cacheKey,
+		generation: generation,
+	})
+}
+
+// fromString returns the given string as a value of the cache, if its values are
+// able to hold strings or byte slices
+func fromString[T any](s string) (T, bool) {
+	if _, isBytes := any(*new(T)).([]byte); isBytes {
+		value, ok := any([]byte(s)).(T)
+		return value, ok
+	}
+
+	value, ok := any(s).(T)
+	return value, ok
+}
+
+// toString returns the string held by a value of the cache
+func toString(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	default:
+		return "", false
+	}
+}
+
+// handOver gives an item to the setter in order to store it into the cache
+func (c *LoadableCache[T]) handOver(item *loadableKeyValue[T]) {
+	c.retain(item)
+
+	if c.options.synchronousSet {
+		c.setItem(item)
+		return
+	}
+
+	select {
+	case c.setChannel <- item:
+		return
+	case <-c.done:
+		// no setter left to hand the value over to, do not retain it
+		c.drop(item)
+		return
+	default:
+	}
+
+	// the setter queue is full
+	switch c.options.setterOverflow {
+	case SetterOverflowDrop:
+		c.drop(item)
+		c.updateStats(func(stats *LoadableStats) { stats.SetsDropped++ })
+	case SetterOverflowWriteInline:
+		c.setItem(item)
+	default:
+		select {
+		case c.setChannel <- item:
+		case <-c.done:
+			c.drop(item)
+		}
+	}
+}
+
+// getWithTTL returns the object stored in the underlying cache, along with its TTL
+// when values are served stale and the cache is able to return it
+func (c *LoadableCache[T]) getWithTTL(ctx context.Context, key any) (T, time.Duration, error) {
+	if c.options.readsTTL() {
+		value, ttl, err := getWithTTLFunc(c.cache)(ctx, key)
+		if !errors.Is(err, store.ErrUnsupported) {
+			return value, ttl, err
+		}
+	}
+
+	value, err := c.cache.Get(ctx, key)
+	return value, 0, err
+}
+
+// fresh returns a value read from the cache, reloading it in the background when
+// it is about to expire or served stale, or in the foreground once the
+// stale-while-revalidate grace period is over, the value being returned if the
+// load function fails
+func (c *LoadableCache[T]) fresh(ctx context.Context, key any, cacheKey string, value T, ttl time.Duration) (any, error) {
+	v, err := c.cached(value)
+	if err != nil || ttl <= 0 {
+		return v, err
+	}
+
+	// time remaining before the soft expiration of the value
+	remaining := ttl - c.options.staleWindow()
+	if remaining > 0 {
+		if c.refreshesAhead(ctx, cacheKey, remaining) {
+			c.revalidate(ctx, key, cacheKey)
+		}
+		return v, nil
+	}
+
+	if -remaining < c.options.staleWhileRevalidate {
+		c.revalidate(ctx, key, cacheKey)
+		return v, nil
+	}
+
+	loaded, err := c.load(ctx, key, cacheKey)
+	if err != nil && !errors.Is(err, store.NotFound{}) && ctx.Err() == nil {
+		return v, nil
+	}
+
+	return loaded, err
+}
+
+// revalidate reloads the value of the given key in the background, a single
+// refresh running at a time for each key
+func (c *LoadableCache[T]) revalidate(ctx context.Context, key any, cacheKey string) {
+	c.refreshFlight.DoChan(cacheKey, func() (any, error) {
+		if !c.refreshSlots.tryAcquire() {
+			c.updateStats(func(stats *LoadableStats) { stats.RefreshesSkipped++ })
+			return nil, nil
+		}
+		defer c.refreshSlots.release()
+
+		value, err := c.load(ctx, key, cacheKey)
+		c.updateStats(func(stats *LoadableStats) {
+			stats.Refreshes++
+			if err != nil {
+				stats.RefreshErrors++
+			}
+		})
+
 		return value, err
-	} else {
-		zero := *new(T)
-		return zero, errors.New(
-			fmt.Sprintf(
-				"type assertion failed: expected %s, got %s",
-				reflect.TypeOf(zero),
-				reflect.TypeOf(value),
-			),
-		)
+	})
+}
+
+// semaphore limits the number of operations running at the same time, a nil
+// semaphore meaning no limit
+type semaphore chan struct{}
+
+func newSemaphore(limit int) semaphore {
+	if limit <= 0 {
+		return nil
+	}
+
+	return make(semaphore, limit)
+}
+
+// tryAcquire returns whether an operation can start without exceeding the limit
+func (s semaphore) tryAcquire() bool {
+	if s == nil {
+		return true
+	}
+
+	select {
+	case s <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// acquire waits for an operation to be able to start, until the given context is done
+func (s semaphore) acquire(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release releases the slot taken by an operation
+func (s semaphore) release() {
+	if s != nil {
+		<-s
 	}
 }
 
-// Set sets a value in available caches
+func (c *LoadableCache[T]) updateStats(update func(stats *LoadableStats)) {
+	c.statsMtx.Lock()
+	defer c.statsMtx.Unlock()
+
+	update(&c.stats)
+}
+
+// GetStats returns the statistics of the loads and background refreshes
+func (c *LoadableCache[T]) GetStats() LoadableStats {
+	c.statsMtx.Lock()
+	defer c.statsMtx.Unlock()
+
+	stats := c.stats
+	stats.SetQueueDepth = len(c.setChannel)
+
+	return stats
+}
+
+// storeOptions returns the options to store a loaded value with: the expiration given by
+// the load function is extended so that the value can be served stale once expired
+func (c *LoadableCache[T]) storeOptions(options []store.Option) []store.Option {
+	window := c.options.staleWindow()
+	if window <= 0 {
+		return options
+	}
+
+	opts := store.ApplyOptions(options...)
+	switch {
+	case !opts.ExpireAt.IsZero():
+		return append(options, store.WithExpireAt(opts.ExpireAt.Add(window)))
+	case opts.Expiration > 0:
+		return append(options, store.WithExpiration(opts.Expiration+window))
+	}
+
+	return options
+}
+
+// cached returns a value read from the cache or from the temporary-while-setter-works
+// cache, negative entries being returned as store.NotFound errors
+func (c *LoadableCache[T]) cached(value any) (any, error) {
+	if _, ok := value.(negativeLoad); ok {
+		return nil, store.NotFoundWithCause(ErrNegativelyCached)
+	}
+
+	if c.negative != nil {
+		if typed, ok := value.(T); ok && c.negative.isMarker(typed) {
+			return nil, store.NotFoundWithCause(ErrNegativelyCached)
+		}
+	}
+
+	return value, nil
+}
+
+// result converts a value returned by the single flight group into an object
+func (c *LoadableCache[T]) result(value any, err error) (T, error) {
+	if err != nil {
+		return *new(T), err
+	}
+
+	if object, ok := value.(T); ok {
+		return object, nil
+	}
+
+	zero := *new(T)
+	return zero, store.InvalidValueWithCause(
+		fmt.Errorf(
+			"type assertion failed: expected %s, got %s",
+			reflect.TypeOf(zero),
+			reflect.TypeOf(value),
+		),
+	)
+}
+
+// Set sets a value in available caches, replacing the negative entry of the key if any
 func (c *LoadableCache[T]) Set(ctx context.Context, key any, object T, options ...store.Option) error {
+	c.forget(key)
 	return c.cache.Set(ctx, key, object, options...)
 }
 
-// Delete removes a value from cache
+// SetMany sets several values at once in available caches
+func (c *LoadableCache[T]) SetMany(ctx context.Context, items map[any]T, options ...store.Option) error {
+	for key := range items {
+		c.forget(key)
+	}
+	return setMany(ctx, c.cache, items, options...)
+}
+
+// Delete removes a value from cache, along with the negative entry of the key if any
 func (c *LoadableCache[T]) Delete(ctx context.Context, key any) error {
+	c.forget(key)
 	return c.cache.Delete(ctx, key)
 }
 
+// DeleteMany removes several values from cache
+func (c *LoadableCache[T]) DeleteMany(ctx context.Context, keys []any) error {
+	for _, key := range keys {
+		c.forget(key)
+	}
+	return deleteMany(ctx, c.cache, keys)
+}
+
+// Keys iterates over the keys held by the underlying cache.
+// The keys of the entries holding the metadata of the values are skipped.
+func (c *LoadableCache[T]) Keys(ctx context.Context, options ...store.ScanOption) iter.Seq2[any, error] {
+	keys := scanKeys(ctx, c.cache, options...)
+	if !c.options.storesMetadata() {
+		return keys
+	}
+
+	return func(yield func(any, error) bool) {
+		for key, err := range keys {
+			if s, ok := key.(string); ok && strings.HasPrefix(s, metadataPrefix) {
+				continue
+			}
+			if !yield(key, err) {
+				return
+			}
+		}
+	}
+}
+
+// Increment atomically adds the given delta to the counter held by the underlying cache
+func (c *LoadableCache[T]) Increment(ctx context.Context, key any, delta int64, options ...store.Option) (T, error) {
+	return increment(ctx, c.cache, key, delta, options...)
+}
+
+// Decrement atomically subtracts the given delta from the counter held by the underlying cache
+func (c *LoadableCache[T]) Decrement(ctx context.Context, key any, delta int64, options ...store.Option) (T, error) {
+	return decrement(ctx, c.cache, key, delta, options...)
+}
+
+// SetIfNotExists sets a value in the underlying cache only if it does not exist yet
+func (c *LoadableCache[T]) SetIfNotExists(ctx context.Context, key any, object T, options ...store.Option) error {
+	return setIfNotExists(ctx, c.cache, key, object, options...)
+}
+
+// GetWithVersion returns a value from the underlying cache along with its version
+func (c *LoadableCache[T]) GetWithVersion(ctx context.Context, key any) (T, store.Version, error) {
+	return getWithVersion(ctx, c.cache, key)
+}
+
+// CompareAndSwap sets a value in the underlying cache only if it still matches the given version
+func (c *LoadableCache[T]) CompareAndSwap(ctx context.Context, key any, object T, version store.Version, options ...store.Option) error {
+	return compareAndSwap(ctx, c.cache, key, object, version, options...)
+}
+
+// Touch changes the expiration of the given key in the underlying cache
+func (c *LoadableCache[T]) Touch(ctx context.Context, key any, ttl time.Duration) error {
+	return touch(ctx, c.cache, key, ttl)
+}
+
+// SlidingExpiration returns the sliding expiration given to the key in the underlying cache
+func (c *LoadableCache[T]) SlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	return slidingExpiration(ctx, c.cache, key)
+}
+
 // Invalidate invalidates cache item from given options
 func (c *LoadableCache[T]) Invalidate(ctx context.Context, options ...store.InvalidateOption) error {
+	c.discardAll()
 	return c.cache.Invalidate(ctx, options...)
 }
 
 // Clear resets all cache data
 func (c *LoadableCache[T]) Clear(ctx context.Context) error {
+	c.discardAll()
+	c.failures.Clear()
 	return c.cache.Clear(ctx)
 }
 
@@ -168,6 +1022,11 @@ func (c *LoadableCache[T]) GetType() string {
 	return LoadableType
 }
 
+// GetName returns the name under which the statistics of the cache are recorded
+func (c *LoadableCache[T]) GetName() string {
+	return c.options.name
+}
+
 // Close releases the background goroutine started by NewLoadable, after having
 // stored the values that were still waiting to be set into the cache.
 // It is safe to call Close multiple times.
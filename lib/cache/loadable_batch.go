@@ -0,0 +1,180 @@
+package cache
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/eko/gocache/lib/v4/store"
+)
+
+// BatchLoadFunction loads the values of several keys at once. Keys missing from the
+// returned map are reported as not found.
+type BatchLoadFunction[T any] func(ctx context.Context, keys []any) (map[any]T, error)
+
+// NewBatchLoadable instantiates a new cache that uses a function to load data by batches.
+//
+// The keys missing from the cache at the same time are coalesced into a single call of the
+// batch load function, made once the batch window is elapsed or the batch is full (see
+// WithBatchWindow and WithMaxBatchSize). Each caller then gets the value of its key, and the
+// loaded values are stored into the cache by batches when the cache supports it. The values
+// are stored with the default options of the cache.
+//
+// As with NewLoadable, call Close when the cache is not used anymore.
+func NewBatchLoadable[T any](batchLoadFunc BatchLoadFunction[T], cache CacheInterface[T], options ...LoadableOption) *LoadableCache[T] {
+	return newLoadable(nil, batchLoadFunc, cache, options...)
+}
+
+// pendingBatch is a batch of keys waiting to be loaded
+type pendingBatch[T any] struct {
+	ctx    context.Context
+	keys   []any
+	once   sync.Once
+	done   chan struct{}
+	values map[any]T
+	err    error
+}
+
+// batcher coalesces the keys to load into batches given to the batch load function
+type batcher[T any] struct {
+	loadFunc BatchLoadFunction[T]
+	window   time.Duration
+	maxSize  int
+	timeout  time.Duration
+	owner    *LoadableCache[T]
+	mu       sync.Mutex
+	pending  *pendingBatch[T]
+}
+
+func newBatcher[T any](loadFunc BatchLoadFunction[T], owner *LoadableCache[T]) *batcher[T] {
+	return &batcher[T]{
+		loadFunc: loadFunc,
+		window:   owner.options.batchWindow,
+		maxSize:  owner.options.maxBatchSize,
+		timeout:  owner.options.loadTimeout,
+		owner:    owner,
+	}
+}
+
+// load adds the given key to the pending batch, unless it is already part of it,
+// and waits for the batch to be loaded
+func (b *batcher[T]) load(ctx context.Context, key any) (T, []store.Option, error) {
+	b.mu.Lock()
+	batch := b.pending
+	if batch == nil {
+		batch = &pendingBatch[T]{ctx: ctx, done: make(chan struct{})}
+		b.pending = batch
+		time.AfterFunc(b.window, func() { b.dispatch(batch) })
+	}
+	// each key is loaded, and registered by beginBatch, once per batch
+	if !slices.Contains(batch.keys, key) {
+		batch.keys = append(batch.keys, key)
+	}
+	full := len(batch.keys) >= b.maxSize
+	if full {
+		b.pending = nil
+	}
+	b.mu.Unlock()
+
+	if full {
+		go b.dispatch(batch)
+	}
+
+	select {
+	case <-batch.done:
+	case <-ctx.Done():
+		return *new(T), nil, ctx.Err()
+	}
+
+	if batch.err != nil {
+		return *new(T), nil, batch.err
+	}
+
+	value, ok := batch.values[key]
+	if !ok {
+		return *new(T), nil, store.NotFoundWithCause(nil)
+	}
+
+	return value, nil, nil
+}
+
+// dispatch loads the given batch using the batch load function, once
+func (b *batcher[T]) dispatch(batch *pendingBatch[T]) {
+	batch.once.Do(func() {
+		b.mu.Lock()
+		if b.pending == batch {
+			b.pending = nil
+		}
+		b.mu.Unlock()
+
+		// the batch outlives the caller starting it, as other callers wait for it
+		ctx, cancel := detach(batch.ctx, b.timeout)
+		defer cancel()
+
+		defer close(batch.done)
+
+		if batch.err = b.owner.loadSlots.acquire(ctx); batch.err != nil {
+			return
+		}
+		defer b.owner.loadSlots.release()
+
+		generations := b.owner.beginBatch(batch.keys)
+		defer b.owner.endBatch(generations)
+
+		start := time.Now()
+		batch.values, batch.err = b.loadFunc(ctx, batch.keys)
+		if batch.err == nil {
+			delta := time.Since(start)
+			b.owner.recordLoadDuration(delta)
+			b.owner.loadBatch(batch.values, generations, delta)
+		}
+	})
+}
+
+// beginBatch registers the loads of the keys of a batch, see begin
+func (c *LoadableCache[T]) beginBatch(keys []any) map[any]uint64 {
+	generations := make(map[any]uint64, len(keys))
+	for _, key := range keys {
+		generations[key] = c.begin(c.getCacheKey(key))
+	}
+
+	return generations
+}
+
+// endBatch unregisters the loads of the keys of a batch
+func (c *LoadableCache[T]) endBatch(generations map[any]uint64) {
+	for key := range generations {
+		c.end(c.getCacheKey(key))
+	}
+}
+
+// loadBatch hands the values loaded by a batch for the requested keys over to the setter
+// in order to store them into the cache at once, along with the duration the batch took
+// to load. Their TTL is left to the cache, the batch load function not returning any.
+func (c *LoadableCache[T]) loadBatch(values map[any]T, generations map[any]uint64, delta time.Duration) {
+	batch := make([]*loadableKeyValue[T], 0, len(values))
+	for key, value := range values {
+		generation, requested := generations[key]
+		if !requested {
+			continue
+		}
+
+		// cache locally until main cache is set, unless the key has been set or deleted
+		cacheKey := c.getCacheKey(key)
+		if !c.keep(cacheKey, generation, value) {
+			continue
+		}
+
+		batch = append(batch, &loadableKeyValue[T]{key: key, value: value, cacheKey: cacheKey, generation: generation, metadata: loadMetadata{recompute: delta}})
+	}
+
+	if len(batch) == 0 {
+		return
+	}
+
+	c.handOver(&loadableKeyValue[T]{
+		batch:   batch,
+		options: c.storeOptions(nil),
+	})
+}
@@ -0,0 +1,268 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mockcache "github.com/eko/gocache/lib/v4/internal/mocks/cache"
+	"github.com/eko/gocache/lib/v4/store"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewBatchLoadable(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	cache1 := mockcache.NewMockSetterCacheInterface[string](ctrl)
+
+	batchLoadFunc := func(_ context.Context, keys []any) (map[any]string, error) {
+		return map[any]string{}, nil
+	}
+
+	// When
+	cache := NewBatchLoadable[string](batchLoadFunc, cache1, WithBatchWindow(5*time.Millisecond), WithMaxBatchSize(10))
+	defer cache.Close()
+
+	// Then
+	assert.IsType(t, new(LoadableCache[string]), cache)
+	assert.NotNil(t, cache.batcher)
+	assert.Equal(t, 5*time.Millisecond, cache.batcher.window)
+	assert.Equal(t, 10, cache.batcher.maxSize)
+}
+
+func TestBatchLoadableGetCoalescesConcurrentMisses(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := newOptionalCache[string](ctrl)
+	cache1.MockSetterCacheInterface.EXPECT().Get(ctx, gomock.Any()).Return("", store.NotFoundWithCause(nil)).Times(3)
+	cache1.MockBatchCacheInterface.EXPECT().SetMany(gomock.Any(), map[any]string{"key-1": "value-1", "key-2": "value-2"}).Return(nil)
+
+	var batches [][]any
+	var mu sync.Mutex
+	batchLoadFunc := func(_ context.Context, keys []any) (map[any]string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, keys)
+		return map[any]string{"key-1": "value-1", "key-2": "value-2"}, nil
+	}
+
+	cache := NewBatchLoadable[string](batchLoadFunc, cache1, WithBatchWindow(50*time.Millisecond))
+
+	// When
+	values := make([]string, 3)
+	errs := make([]error, 3)
+
+	var wg sync.WaitGroup
+	for i, key := range []string{"key-1", "key-2", "key-3"} {
+		wg.Go(func() {
+			values[i], errs[i] = cache.Get(ctx, key)
+		})
+	}
+	wg.Wait()
+
+	// Closing waits for the loaded values to be stored into the cache
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.Len(t, batches, 1)
+	assert.ElementsMatch(t, []any{"key-1", "key-2", "key-3"}, batches[0])
+
+	assert.Nil(t, errs[0])
+	assert.Equal(t, "value-1", values[0])
+	assert.Nil(t, errs[1])
+	assert.Equal(t, "value-2", values[1])
+	assert.ErrorIs(t, errs[2], store.NotFound{})
+}
+
+func TestBatchLoadableGetWhenBatchFull(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := newOptionalCache[string](ctrl)
+	cache1.MockSetterCacheInterface.EXPECT().Get(ctx, gomock.Any()).Return("", store.NotFoundWithCause(nil)).Times(4)
+	cache1.MockBatchCacheInterface.EXPECT().SetMany(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	var batchSizes []int
+	var mu sync.Mutex
+	batchLoadFunc := func(_ context.Context, keys []any) (map[any]string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		batchSizes = append(batchSizes, len(keys))
+
+		values := map[any]string{}
+		for _, key := range keys {
+			values[key] = "value"
+		}
+		return values, nil
+	}
+
+	// the window is long enough for the batches to be loaded only once full
+	cache := NewBatchLoadable[string](batchLoadFunc, cache1, WithBatchWindow(time.Minute), WithMaxBatchSize(2))
+
+	// When
+	var wg sync.WaitGroup
+	for _, key := range []string{"key-1", "key-2", "key-3", "key-4"} {
+		wg.Go(func() {
+			value, err := cache.Get(ctx, key)
+			assert.Nil(t, err)
+			assert.Equal(t, "value", value)
+		})
+	}
+	wg.Wait()
+
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.Equal(t, []int{2, 2}, batchSizes)
+}
+
+func TestBatchLoadableGetWhenBatchLoadFails(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[string](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return("", store.NotFoundWithCause(nil))
+
+	loadErr := errors.New("database unavailable")
+	batchLoadFunc := func(_ context.Context, keys []any) (map[any]string, error) {
+		return nil, loadErr
+	}
+
+	cache := NewBatchLoadable[string](batchLoadFunc, cache1)
+	defer cache.Close()
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.ErrorIs(t, err, loadErr)
+	assert.Empty(t, value)
+}
+
+func TestBatchLoadableLoadWhenKeyAlreadyPending(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := newOptionalCache[string](ctrl)
+	cache1.MockBatchCacheInterface.EXPECT().SetMany(gomock.Any(), map[any]string{"key-1": "value-1"}).Return(nil)
+
+	var batches [][]any
+	var mu sync.Mutex
+	batchLoadFunc := func(_ context.Context, keys []any) (map[any]string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, keys)
+		return map[any]string{"key-1": "value-1"}, nil
+	}
+
+	cache := NewBatchLoadable[string](batchLoadFunc, cache1, WithBatchWindow(50*time.Millisecond))
+
+	// When
+	values := make([]string, 2)
+	errs := make([]error, 2)
+
+	var wg sync.WaitGroup
+	for i := range 2 {
+		wg.Go(func() {
+			values[i], _, errs[i] = cache.batcher.load(ctx, "key-1")
+		})
+	}
+	wg.Wait()
+
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.Equal(t, [][]any{{"key-1"}}, batches)
+	assert.Equal(t, []string{"value-1", "value-1"}, values)
+	assert.Equal(t, []error{nil, nil}, errs)
+	assert.Empty(t, cache.loads)
+}
+
+func TestBatchLoadableGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := newOptionalCache[string](ctrl)
+	cache1.MockBatchCacheInterface.EXPECT().GetMany(ctx, []any{"key-1", "key-2", "key-3", "key-4"}).Return(map[any]string{"key-1": "value-1"}, nil)
+	cache1.MockBatchCacheInterface.EXPECT().SetMany(gomock.Any(), map[any]string{"key-2": "value-2", "key-3": "value-3"}).Return(nil)
+
+	var loadCallCount int32
+	var loadedKeys []string
+	batchLoadFunc := func(_ context.Context, keys []any) (map[any]string, error) {
+		atomic.AddInt32(&loadCallCount, 1)
+		for _, key := range keys {
+			loadedKeys = append(loadedKeys, key.(string))
+		}
+		return map[any]string{"key-2": "value-2", "key-3": "value-3"}, nil
+	}
+
+	cache := NewBatchLoadable[string](batchLoadFunc, cache1)
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key-1", "key-2", "key-3", "key-4"})
+
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]string{"key-1": "value-1", "key-2": "value-2", "key-3": "value-3"}, values)
+	assert.Equal(t, int32(1), loadCallCount)
+
+	sort.Strings(loadedKeys)
+	assert.Equal(t, []string{"key-2", "key-3", "key-4"}, loadedKeys)
+}
+
+func TestBatchLoadableDeleteWhileLoading(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := newOptionalCache[string](ctrl)
+	cache1.MockBatchCacheInterface.EXPECT().GetMany(ctx, []any{"key-1", "key-2"}).Return(map[any]string{}, nil)
+	cache1.MockSetterCacheInterface.EXPECT().Delete(ctx, "key-1").Return(nil)
+	// the value of the deleted key is not stored
+	cache1.MockBatchCacheInterface.EXPECT().SetMany(gomock.Any(), map[any]string{"key-2": "value-2"}).Return(nil)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	batchLoadFunc := func(_ context.Context, keys []any) (map[any]string, error) {
+		close(started)
+		<-release
+		return map[any]string{"key-1": "value-1", "key-2": "value-2"}, nil
+	}
+
+	cache := NewBatchLoadable[string](batchLoadFunc, cache1)
+
+	go func() {
+		<-started
+		assert.Nil(t, cache.Delete(ctx, "key-1"))
+		close(release)
+	}()
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key-1", "key-2"})
+
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]string{"key-1": "value-1", "key-2": "value-2"}, values)
+}
@@ -0,0 +1,145 @@
+package cache
+
+// keyLoads tracks the loads of a key until their values are stored into the cache,
+// in order to discard them when the key is set or deleted, or when the cache is
+// invalidated or cleared in the meantime.
+//
+// The loads lock is only held while reading or updating the generations and the
+// temporary-while-setter-works cache, never during a round-trip to the cache: a value is
+// stored when its generation is current, and deleted again if the generation has been
+// discarded while it was being stored, see setItem.
+type keyLoads struct {
+	// generation is incremented each time the values being loaded are discarded
+	generation uint64
+	// refs counts the loads and the values waiting to be stored
+	refs int
+}
+
+// begin registers a load of the given key and returns the generation its value belongs to
+func (c *LoadableCache[T]) begin(cacheKey string) uint64 {
+	c.loadsMtx.Lock()
+	defer c.loadsMtx.Unlock()
+
+	loads, ok := c.loads[cacheKey]
+	if !ok {
+		loads = &keyLoads{}
+		c.loads[cacheKey] = loads
+	}
+	loads.refs++
+
+	return loads.generation
+}
+
+// retain registers the values of an item handed over to the setter, whose loads
+// are still registered
+func (c *LoadableCache[T]) retain(item *loadableKeyValue[T]) {
+	c.loadsMtx.Lock()
+	defer c.loadsMtx.Unlock()
+
+	for _, entry := range item.entries() {
+		c.loads[entry.cacheKey].refs++
+	}
+}
+
+// end unregisters a load, or a value handed over to the setter, of the given key
+func (c *LoadableCache[T]) end(cacheKey string) {
+	c.loadsMtx.Lock()
+	defer c.loadsMtx.Unlock()
+
+	loads := c.loads[cacheKey]
+	loads.refs--
+	if loads.refs == 0 {
+		delete(c.loads, cacheKey)
+	}
+}
+
+// current returns whether the values of the given generation of a key can still be stored
+func (c *LoadableCache[T]) current(cacheKey string, generation uint64) bool {
+	c.loadsMtx.Lock()
+	defer c.loadsMtx.Unlock()
+
+	return c.isCurrent(cacheKey, generation)
+}
+
+// isCurrent is current for callers holding the loads lock
+func (c *LoadableCache[T]) isCurrent(cacheKey string, generation uint64) bool {
+	loads, ok := c.loads[cacheKey]
+	return ok && loads.generation == generation
+}
+
+// keep caches the given value locally until main cache is set, unless its
+// generation has been discarded
+func (c *LoadableCache[T]) keep(cacheKey string, generation uint64, value any) bool {
+	c.loadsMtx.Lock()
+	defer c.loadsMtx.Unlock()
+
+	if !c.isCurrent(cacheKey, generation) {
+		return false
+	}
+
+	c.setCache.Store(cacheKey, value)
+
+	return true
+}
+
+// discard discards the values being loaded for the given key, so that the next reads
+// load the key again: the values not stored yet are not stored anymore, and the ones
+// being stored are deleted from the cache once stored
+func (c *LoadableCache[T]) discard(cacheKey string) {
+	c.loadsMtx.Lock()
+	if loads, ok := c.loads[cacheKey]; ok {
+		loads.generation++
+	}
+	c.setCache.Delete(cacheKey)
+	c.loadsMtx.Unlock()
+
+	c.singleFlight.Forget(cacheKey)
+}
+
+// discardAll discards the values being loaded for all the keys
+func (c *LoadableCache[T]) discardAll() {
+	c.loadsMtx.Lock()
+	for cacheKey, loads := range c.loads {
+		loads.generation++
+		c.singleFlight.Forget(cacheKey)
+	}
+	c.setCache.Clear()
+	c.loadsMtx.Unlock()
+}
+
+// discarded returns the entries of an item whose generation has been discarded
+// while they were being stored
+func (c *LoadableCache[T]) discarded(entries []*loadableKeyValue[T]) []*loadableKeyValue[T] {
+	c.loadsMtx.Lock()
+	defer c.loadsMtx.Unlock()
+
+	discarded := []*loadableKeyValue[T]{}
+	for _, entry := range entries {
+		if !c.isCurrent(entry.cacheKey, entry.generation) {
+			discarded = append(discarded, entry)
+		}
+	}
+
+	return discarded
+}
+
+// finish releases the values of an item from the temporary-while-setter-works cache,
+// unless they have been discarded, and unregisters them
+func (c *LoadableCache[T]) finish(item *loadableKeyValue[T]) {
+	c.loadsMtx.Lock()
+	for _, entry := range item.entries() {
+		if c.isCurrent(entry.cacheKey, entry.generation) {
+			c.setCache.Delete(entry.cacheKey)
+		}
+	}
+	c.loadsMtx.Unlock()
+
+	for _, entry := range item.entries() {
+		c.end(entry.cacheKey)
+	}
+}
+
+// drop gives up storing the values of an item into the cache
+func (c *LoadableCache[T]) drop(item *loadableKeyValue[T]) {
+	c.finish(item)
+}
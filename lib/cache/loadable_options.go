@@ -0,0 +1,327 @@
+package cache
+
+import (
+	"reflect"
+	"time"
+)
+
+const (
+	defaultBatchWindow     = time.Millisecond
+	defaultMaxBatchSize    = 100
+	defaultSetterWorkers   = 1
+	defaultSetterQueueSize = 10000
+)
+
+// SetterOverflowPolicy defines how a loadable cache stores the loaded values when the
+// queue of its setter is full
+type SetterOverflowPolicy int
+
+const (
+	// SetterOverflowBlock waits for the queue to have room for the value, the default
+	SetterOverflowBlock SetterOverflowPolicy = iota
+	// SetterOverflowDrop does not store the value into the cache
+	SetterOverflowDrop
+	// SetterOverflowWriteInline stores the value into the cache before returning it
+	SetterOverflowWriteInline
+)
+
+// LoadableOption represents a loadable cache option function
+type LoadableOption func(o *loadableOptions)
+
+type loadableOptions struct {
+	negativeCacheTTL     time.Duration
+	negativeCaching      negativeCachingOption
+	staleWhileRevalidate time.Duration
+	staleIfError         time.Duration
+	refreshAhead         time.Duration
+	refreshAheadFraction float64
+	maxRefreshes         int
+	earlyExpirationBeta  float64
+	batchWindow          time.Duration
+	maxBatchSize         int
+	loadTimeout          time.Duration
+	maxLoads             int
+	errorCacheTTL        time.Duration
+	backoffInitial       time.Duration
+	backoffMax           time.Duration
+	synchronousSet       bool
+	setterWorkers        int
+	setterQueueSize      int
+	setterOverflow       SetterOverflowPolicy
+	name                 string
+}
+
+// staleWindow returns the duration values are kept in cache after their expiration
+// in order to be served stale
+func (o *loadableOptions) staleWindow() time.Duration {
+	return max(o.staleWhileRevalidate, o.staleIfError)
+}
+
+// readsTTL returns whether the TTL of the values is needed to serve them
+func (o *loadableOptions) readsTTL() bool {
+	return o.staleWindow() > 0 || o.refreshAhead > 0 || o.storesMetadata()
+}
+
+// storesMetadata returns whether the metadata of the values is stored along with them
+// in order to refresh them ahead of their expiration
+func (o *loadableOptions) storesMetadata() bool {
+	return o.refreshAheadFraction > 0 || o.earlyExpirationBeta > 0
+}
+
+// cachesErrors returns whether the errors of the load function are cached
+func (o *loadableOptions) cachesErrors() bool {
+	return o.errorCacheTTL > 0 || o.backoffInitial > 0
+}
+
+// retryDelay returns the duration the last error of the load function is returned
+// for after the given number of consecutive failures
+func (o *loadableOptions) retryDelay(failures int) time.Duration {
+	delay := o.backoffInitial
+	for i := 1; i < failures && delay < o.backoffMax; i++ {
+		delay *= 2
+	}
+
+	return max(min(delay, o.backoffMax), o.errorCacheTTL)
+}
+
+func applyLoadableOptions(opts ...LoadableOption) *loadableOptions {
+	o := &loadableOptions{
+		batchWindow:     defaultBatchWindow,
+		maxBatchSize:    defaultMaxBatchSize,
+		setterWorkers:   defaultSetterWorkers,
+		setterQueueSize: defaultSetterQueueSize,
+		name:            LoadableType,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// negativeCachingOption is the negative caching of a loadable cache, of any type
+type negativeCachingOption interface {
+	markerType() reflect.Type
+}
+
+// negativeCaching holds the marker the absence of the values is stored as, along with
+// the function reporting whether a value read from the cache is the marker
+type negativeCaching[T any] struct {
+	marker   T
+	isMarker func(value T) bool
+}
+
+func (n *negativeCaching[T]) markerType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// WithNegativeCaching caches the absence of the values the load function reports as not
+// found, returning a store.NotFound error, for the given ttl. Get then returns an error
+// wrapping ErrNegativelyCached without calling the load function again, until the ttl
+// expires or the key is set or deleted.
+//
+// The absence is stored as the given marker, a value of the type of the cache that the
+// load function never returns, such as an empty string or a struct with an invalid
+// identifier: values read from the cache equal to the marker are reported as absent.
+// The type of the marker must be the one of the cache, which has to be given explicitly
+// for caches of interface types, as in WithNegativeCaching[any]: NewLoadable and
+// NewBatchLoadable panic otherwise. Use WithNegativeCachingFunc for types which are
+// not comparable.
+func WithNegativeCaching[T comparable](ttl time.Duration, marker T) LoadableOption {
+	return WithNegativeCachingFunc(ttl, marker, func(value T) bool {
+		return value == marker
+	})
+}
+
+// WithNegativeCachingFunc caches the absence of the values as WithNegativeCaching does,
+// the given function reporting whether a value read from the cache is the marker.
+func WithNegativeCachingFunc[T any](ttl time.Duration, marker T, isMarker func(value T) bool) LoadableOption {
+	return func(o *loadableOptions) {
+		o.negativeCacheTTL = ttl
+		o.negativeCaching = &negativeCaching[T]{marker: marker, isMarker: isMarker}
+	}
+}
+
+// WithStaleWhileRevalidate keeps serving the values for the given grace period after their
+// expiration, while a single background goroutine reloads them using the load function.
+//
+// The expiration given by the load function is the soft TTL of the value, which is stored
+// with a hard TTL extended by the grace period: the caches must be able to return the TTL
+// of the values, as Cache and ChainCache do, for them to be served stale.
+func WithStaleWhileRevalidate(grace time.Duration) LoadableOption {
+	return func(o *loadableOptions) {
+		o.staleWhileRevalidate = grace
+	}
+}
+
+// WithStaleIfError keeps the values for the given duration after their expiration in order
+// to serve them when the load function fails to reload them, instead of returning its error.
+// Values reported as not found by the load function are not served stale.
+func WithStaleIfError(window time.Duration) LoadableOption {
+	return func(o *loadableOptions) {
+		o.staleIfError = window
+	}
+}
+
+// WithRefreshAhead reloads the values read less than the given duration before their
+// expiration in the background, so that frequently read values never expire. The same
+// duration applying to every value, WithRefreshAheadFraction should be preferred when the
+// load function gives different expirations to the values.
+//
+// As for stale-while-revalidate, the caches must be able to return the TTL of the values.
+func WithRefreshAhead(before time.Duration) LoadableOption {
+	return func(o *loadableOptions) {
+		o.refreshAhead = before
+	}
+}
+
+// WithRefreshAheadFraction reloads the values in the background once their remaining TTL
+// is less than the given fraction, between 0 and 1, of the TTL given by the load function,
+// so that frequently read values never expire. For instance, values are refreshed once 80%
+// of their TTL is elapsed with a 0.2 fraction, whatever their expiration.
+//
+// The TTL of each value is stored along with it, with the same expiration, under the key of
+// the value prefixed with "gocache_metadata_". Such entries are only stored when the values
+// of the cache are able to hold strings and the load function gives them an expiration:
+// the other values are not refreshed ahead. As for stale-while-revalidate, the caches must
+// be able to return the TTL of the values.
+func WithRefreshAheadFraction(fraction float64) LoadableOption {
+	return func(o *loadableOptions) {
+		o.refreshAheadFraction = fraction
+	}
+}
+
+// WithMaxConcurrentRefreshes limits the number of background refreshes, triggered by
+// refresh-ahead or stale-while-revalidate, running at the same time. Refreshes exceeding
+// the limit are skipped and will be triggered again by the next reads.
+func WithMaxConcurrentRefreshes(limit int) LoadableOption {
+	return func(o *loadableOptions) {
+		o.maxRefreshes = limit
+	}
+}
+
+// WithProbabilisticEarlyExpiration refreshes the values in the background slightly before
+// their expiration, following the XFetch algorithm: a value is refreshed when
+// delta * beta * -ln(rand()) exceeds its remaining TTL, delta being the duration the value
+// took to load. Each instance thus decides independently to
+// refresh a value, the probability of doing so increasing as its expiration approaches,
+// which prevents a fleet of instances from loading it all at once. A beta of 1 is a good
+// default, greater values favouring earlier refreshes.
+//
+// The duration each value took to load is stored along with it, with the same expiration,
+// under the key of the value prefixed with "gocache_metadata_", so that the instances only
+// reading the value refresh it early too. Such entries are only stored when the values of
+// the cache are able to hold strings: otherwise, the moving average of the durations of the
+// loads of the instance is used. As for stale-while-revalidate, the caches must be able to
+// return the TTL of the values.
+func WithProbabilisticEarlyExpiration(beta float64) LoadableOption {
+	return func(o *loadableOptions) {
+		o.earlyExpirationBeta = beta
+	}
+}
+
+// WithBatchWindow sets how long a batch cache created with NewBatchLoadable waits for other
+// keys to load once a key is missing, before calling the batch load function. Defaults to 1ms.
+func WithBatchWindow(window time.Duration) LoadableOption {
+	return func(o *loadableOptions) {
+		o.batchWindow = window
+	}
+}
+
+// WithMaxBatchSize sets the maximum number of keys given at once to the batch load function
+// of a cache created with NewBatchLoadable, and stored at once into the cache. Defaults to 100.
+func WithMaxBatchSize(size int) LoadableOption {
+	return func(o *loadableOptions) {
+		if size > 0 {
+			o.maxBatchSize = size
+		}
+	}
+}
+
+// WithLoadTimeout sets the maximum duration of the calls to the load function. The load
+// function is given a context detached from the caller's one, which keeps its values but
+// is not cancelled along with it, as the loaded value is shared with the other callers
+// waiting for it: the load is only cancelled once this timeout is elapsed, if set.
+func WithLoadTimeout(timeout time.Duration) LoadableOption {
+	return func(o *loadableOptions) {
+		o.loadTimeout = timeout
+	}
+}
+
+// WithMaxConcurrentLoads limits the number of calls to the load function running at the
+// same time, each batch counting as a single call for the caches created with
+// NewBatchLoadable. Loads exceeding the limit wait for a running one to complete.
+func WithMaxConcurrentLoads(limit int) LoadableOption {
+	return func(o *loadableOptions) {
+		o.maxLoads = limit
+	}
+}
+
+// WithErrorCaching caches the errors of the load function, other than store.NotFound ones,
+// for the given ttl: Get then returns an error wrapping ErrLoadBackedOff and the last error
+// of the load function without calling it again, until the ttl expires or the key is set
+// or deleted. Errors are cached in memory, for each key.
+func WithErrorCaching(ttl time.Duration) LoadableOption {
+	return func(o *loadableOptions) {
+		o.errorCacheTTL = ttl
+	}
+}
+
+// WithErrorBackoff caches the errors of the load function as WithErrorCaching does, for a
+// duration starting at initial and doubling after each consecutive failure of the load
+// function for a key, up to max.
+func WithErrorBackoff(initial, max time.Duration) LoadableOption {
+	return func(o *loadableOptions) {
+		o.backoffInitial = initial
+		o.backoffMax = max
+	}
+}
+
+// WithSynchronousSet stores the loaded values into the cache before returning them, instead
+// of handing them over to the background setter, so that the values can be read from the
+// cache right after.
+func WithSynchronousSet() LoadableOption {
+	return func(o *loadableOptions) {
+		o.synchronousSet = true
+	}
+}
+
+// WithSetterWorkers sets the number of goroutines storing the loaded values into the cache
+// in the background. Defaults to 1.
+func WithSetterWorkers(workers int) LoadableOption {
+	return func(o *loadableOptions) {
+		if workers > 0 {
+			o.setterWorkers = workers
+		}
+	}
+}
+
+// WithSetterQueueSize sets the number of loaded values waiting to be stored into the cache
+// by the background setter, beyond which the overflow policy applies. Defaults to 10000.
+func WithSetterQueueSize(size int) LoadableOption {
+	return func(o *loadableOptions) {
+		if size >= 0 {
+			o.setterQueueSize = size
+		}
+	}
+}
+
+// WithSetterOverflow sets how the loaded values are stored when the queue of the background
+// setter is full. Defaults to SetterOverflowBlock.
+func WithSetterOverflow(policy SetterOverflowPolicy) LoadableOption {
+	return func(o *loadableOptions) {
+		o.setterOverflow = policy
+	}
+}
+
+// WithLoaderName sets the name under which the statistics of the loadable cache are
+// recorded by a metric cache, so that several loadable caches can be told apart.
+// Defaults to "loadable".
+func WithLoaderName(name string) LoadableOption {
+	return func(o *loadableOptions) {
+		if name != "" {
+			o.name = name
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -151,6 +152,1083 @@ func TestLoadableGetWhenAvailableInLoadFunc(t *testing.T) {
 	assert.Equal(t, int32(1), loadCallCount)
 }
 
+func TestLoadableGetWhenCallerCancelled(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	type traceKey struct{}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), traceKey{}, "my-trace"))
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(gomock.Any(), "my-key").Return(nil, store.NotFoundWithCause(nil))
+	stored := make(chan struct{})
+	cache1.EXPECT().Set(context.Background(), "my-key", "my-value").DoAndReturn(
+		func(_ context.Context, _ any, _ any, _ ...store.Option) error {
+			close(stored)
+			return nil
+		},
+	)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var loadCtxErr error
+	var loadCtxTrace any
+	loadFunc := func(ctx context.Context, key any) (any, []store.Option, error) {
+		close(started)
+		<-release
+		loadCtxErr = ctx.Err()
+		loadCtxTrace = ctx.Value(traceKey{})
+		return "my-value", []store.Option{}, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+
+	// When
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	value, err := cache.Get(ctx, "my-key")
+
+	// the load goes on and its value is stored into the cache
+	close(release)
+	<-stored
+
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, value)
+	assert.Nil(t, loadCtxErr)
+	assert.Equal(t, "my-trace", loadCtxTrace)
+}
+
+func TestLoadableGetWhenCallerCancelledWhileReadingCache(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	started := make(chan struct{})
+	var readCtxErr error
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(gomock.Any(), "my-key").DoAndReturn(func(ctx context.Context, _ any) (any, error) {
+		close(started)
+		// the caller starting the call is gone before the cache replies
+		time.Sleep(50 * time.Millisecond)
+		readCtxErr = ctx.Err()
+		return nil, store.NotFoundWithCause(nil)
+	})
+	cache1.EXPECT().Set(gomock.Any(), "my-key", "my-value").Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		return "my-value", []store.Option{}, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+
+	// When
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	var wg sync.WaitGroup
+	var value any
+	var err error
+	wg.Go(func() {
+		<-started
+		// joins the call started by the cancelled caller
+		value, err = cache.Get(context.Background(), "my-key")
+	})
+
+	_, cancelledErr := cache.Get(ctx, "my-key")
+	wg.Wait()
+
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.ErrorIs(t, cancelledErr, context.Canceled)
+	assert.Nil(t, readCtxErr)
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestLoadableGetWithLoadTimeout(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, store.NotFoundWithCause(nil))
+
+	loadFunc := func(ctx context.Context, key any) (any, []store.Option, error) {
+		<-ctx.Done()
+		return nil, nil, ctx.Err()
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithLoadTimeout(10*time.Millisecond))
+	defer cache.Close()
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, value)
+}
+
+func TestLoadableGetWhenCacheUnavailable(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, store.TimeoutWithCause(context.DeadlineExceeded))
+	cache1.EXPECT().Set(gomock.Any(), "my-key", "my-value").AnyTimes().Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		return "my-value", []store.Option{}, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+	defer cache.Close()
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestLoadableGetWhenInvalidValueInCache(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, store.InvalidValueWithCause(errors.New("unable to decode value")))
+
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		t.Fatal("the load function should not be called")
+		return nil, nil, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+	defer cache.Close()
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, value)
+	assert.ErrorIs(t, err, store.ErrInvalidValue)
+}
+
+func TestLoadableGetWithNegativeCaching(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, store.NotFoundWithCause(nil))
+	cache1.EXPECT().Set(gomock.Any(), "my-key", "not-found", &store.OptionsMatcher{Expiration: time.Minute}).Return(nil)
+	cache1.EXPECT().Get(ctx, "my-key").Return("not-found", nil)
+
+	var loadCallCount int32
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		atomic.AddInt32(&loadCallCount, 1)
+		return nil, nil, store.NotFoundWithCause(errors.New("no such row"))
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithNegativeCaching[any](time.Minute, "not-found"))
+
+	// When
+	_, loadErr := cache.Get(ctx, "my-key")
+
+	// Closing waits for the negative entry to be stored
+	assert.Nil(t, cache.Close())
+
+	_, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.ErrorIs(t, loadErr, store.NotFound{})
+	assert.NotErrorIs(t, loadErr, ErrNegativelyCached)
+	assert.ErrorIs(t, err, store.NotFound{})
+	assert.ErrorIs(t, err, ErrNegativelyCached)
+	assert.Equal(t, int32(1), loadCallCount)
+}
+
+func TestLoadableGetWithNegativeCachingWithStructValues(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	type user struct {
+		ID   int
+		Name string
+	}
+	missing := user{ID: -1}
+
+	cache1 := mockcache.NewMockSetterCacheInterface[user](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(user{}, store.NotFoundWithCause(nil))
+	cache1.EXPECT().Set(gomock.Any(), "my-key", missing, &store.OptionsMatcher{Expiration: time.Minute}).Return(nil)
+	cache1.EXPECT().Get(ctx, "my-key").Return(missing, nil)
+	cache1.EXPECT().Get(ctx, "other-key").Return(user{ID: 1, Name: "John"}, nil)
+
+	var loadCallCount int32
+	loadFunc := func(_ context.Context, key any) (user, []store.Option, error) {
+		atomic.AddInt32(&loadCallCount, 1)
+		return user{}, nil, store.NotFoundWithCause(nil)
+	}
+
+	cache := NewLoadable[user](loadFunc, cache1, WithNegativeCaching(time.Minute, missing))
+
+	// When
+	_, loadErr := cache.Get(ctx, "my-key")
+
+	// Closing waits for the negative entry to be stored
+	assert.Nil(t, cache.Close())
+
+	_, err := cache.Get(ctx, "my-key")
+	value, otherErr := cache.Get(ctx, "other-key")
+
+	// Then
+	assert.ErrorIs(t, loadErr, store.NotFound{})
+	assert.ErrorIs(t, err, ErrNegativelyCached)
+	assert.Nil(t, otherErr)
+	assert.Equal(t, user{ID: 1, Name: "John"}, value)
+	assert.Equal(t, int32(1), loadCallCount)
+}
+
+func TestNewLoadableWhenNegativeCachingMarkerIsNotAValue(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	cache1 := mockcache.NewMockSetterCacheInterface[int](ctrl)
+
+	loadFunc := func(_ context.Context, key any) (int, []store.Option, error) {
+		return 0, nil, store.NotFoundWithCause(nil)
+	}
+
+	// When - Then
+	assert.PanicsWithValue(t, `cache: the negative caching marker is a string, not a int`, func() {
+		NewLoadable[int](loadFunc, cache1, WithNegativeCaching(time.Minute, "not-found"))
+	})
+}
+
+func TestLoadableSetClearsNegativeEntry(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[[]byte](ctrl)
+	cache1.EXPECT().Set(ctx, "my-key", []byte("my-value")).Return(nil)
+	cache1.EXPECT().Get(ctx, "my-key").Return([]byte("my-value"), nil)
+
+	loadFunc := func(_ context.Context, key any) ([]byte, []store.Option, error) {
+		return nil, nil, errors.New("should not be called")
+	}
+
+	missing := []byte("not-found")
+	cache := NewLoadable[[]byte](loadFunc, cache1, WithNegativeCachingFunc(time.Minute, missing, func(value []byte) bool {
+		return bytes.Equal(value, missing)
+	}))
+	defer cache.Close()
+
+	// a negative entry is waiting to be stored
+	cache.setCache.Store("my-key", negativeLoad{})
+	_, negativeErr := cache.Get(ctx, "my-key")
+
+	// When
+	err := cache.Set(ctx, "my-key", []byte("my-value"))
+	value, getErr := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.ErrorIs(t, negativeErr, ErrNegativelyCached)
+	assert.Nil(t, err)
+	assert.Nil(t, getErr)
+	assert.Equal(t, []byte("my-value"), value)
+}
+
+func TestLoadableGetManyWithNegativeCaching(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := newOptionalCache[string](ctrl)
+	cache1.MockBatchCacheInterface.EXPECT().GetMany(ctx, []any{"key1", "key2"}).Return(map[any]string{
+		"key1": "value1",
+		"key2": "",
+	}, nil)
+
+	loadFunc := func(_ context.Context, key any) (string, []store.Option, error) {
+		return "", nil, errors.New("should not be called")
+	}
+
+	cache := NewLoadable[string](loadFunc, cache1, WithNegativeCaching(time.Minute, ""))
+	defer cache.Close()
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key1", "key2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]string{"key1": "value1"}, values)
+}
+
+func TestLoadableGetWithStaleWhileRevalidate(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// the soft TTL expired 10 seconds ago
+	cache1 := mockcache.NewMockSetterCacheInterface[string](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("stale value", 50*time.Second, nil)
+	cache1.EXPECT().Set(gomock.Any(), "my-key", "fresh value", &store.OptionsMatcher{Expiration: 2 * time.Minute}).Return(nil)
+
+	loaded := make(chan struct{})
+	loadFunc := func(_ context.Context, key any) (string, []store.Option, error) {
+		defer close(loaded)
+		return "fresh value", []store.Option{store.WithExpiration(time.Minute)}, nil
+	}
+
+	cache := NewLoadable[string](loadFunc, cache1, WithStaleWhileRevalidate(time.Minute))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "stale value", value)
+
+	<-loaded
+	assert.Nil(t, cache.Close())
+}
+
+func TestLoadableGetWithStaleWhileRevalidateWhenFresh(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[string](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", 90*time.Second, nil)
+
+	loadFunc := func(_ context.Context, key any) (string, []store.Option, error) {
+		return "", nil, errors.New("should not be called")
+	}
+
+	cache := NewLoadable[string](loadFunc, cache1, WithStaleWhileRevalidate(time.Minute))
+	defer cache.Close()
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestLoadableGetWithStaleIfError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// the soft TTL expired 30 seconds ago
+	cache1 := mockcache.NewMockSetterCacheInterface[string](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("stale value", 30*time.Second, nil)
+
+	var loadCallCount int32
+	loadFunc := func(_ context.Context, key any) (string, []store.Option, error) {
+		atomic.AddInt32(&loadCallCount, 1)
+		return "", nil, errors.New("database unavailable")
+	}
+
+	cache := NewLoadable[string](loadFunc, cache1, WithStaleIfError(time.Minute))
+	defer cache.Close()
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "stale value", value)
+	assert.Equal(t, int32(1), loadCallCount)
+}
+
+func TestLoadableGetWithStaleIfErrorWhenNotFound(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[string](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("stale value", 30*time.Second, nil)
+
+	loadFunc := func(_ context.Context, key any) (string, []store.Option, error) {
+		return "", nil, store.NotFoundWithCause(errors.New("no such row"))
+	}
+
+	cache := NewLoadable[string](loadFunc, cache1, WithStaleIfError(time.Minute))
+	defer cache.Close()
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.ErrorIs(t, err, store.NotFound{})
+	assert.Empty(t, value)
+}
+
+func TestLoadableGetWithRefreshAhead(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// 80% of the TTL is elapsed
+	cache1 := mockcache.NewMockSetterCacheInterface[string](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", 12*time.Second, nil)
+	cache1.EXPECT().Set(gomock.Any(), "my-key", "fresh value", &store.OptionsMatcher{Expiration: time.Minute}).Return(nil)
+
+	loaded := make(chan struct{})
+	loadFunc := func(_ context.Context, key any) (string, []store.Option, error) {
+		defer close(loaded)
+		return "fresh value", []store.Option{store.WithExpiration(time.Minute)}, nil
+	}
+
+	cache := NewLoadable[string](loadFunc, cache1, WithRefreshAhead(15*time.Second))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+
+	<-loaded
+	assert.Eventually(t, func() bool {
+		return cache.GetStats() == LoadableStats{Loads: 1, Refreshes: 1}
+	}, time.Second, time.Millisecond)
+	assert.Nil(t, cache.Close())
+}
+
+func TestLoadableGetWithRefreshAheadFraction(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// 80% of the TTL stored along with the value is elapsed
+	cache1 := mockcache.NewMockSetterCacheInterface[string](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", 12*time.Second, nil)
+	cache1.EXPECT().Get(ctx, "gocache_metadata_my-key").Return("1ms 1m0s", nil)
+	cache1.EXPECT().Set(gomock.Any(), "my-key", "fresh value", &store.OptionsMatcher{Expiration: 2 * time.Minute}).Return(nil)
+	cache1.EXPECT().Set(gomock.Any(), "gocache_metadata_my-key", gomock.Cond(func(value string) bool {
+		metadata, ok := parseLoadMetadata(value)
+		return ok && metadata.ttl == 2*time.Minute
+	}), &store.OptionsMatcher{Expiration: 2 * time.Minute}).Return(nil)
+
+	loaded := make(chan struct{})
+	loadFunc := func(_ context.Context, key any) (string, []store.Option, error) {
+		defer close(loaded)
+		return "fresh value", []store.Option{store.WithExpiration(2 * time.Minute)}, nil
+	}
+
+	cache := NewLoadable[string](loadFunc, cache1, WithRefreshAheadFraction(0.25))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+
+	<-loaded
+	assert.Eventually(t, func() bool {
+		return cache.GetStats() == LoadableStats{Loads: 1, Refreshes: 1}
+	}, time.Second, time.Millisecond)
+	assert.Nil(t, cache.Close())
+}
+
+func TestLoadableGetWithRefreshAheadFractionWhenFarFromExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// the same remaining TTL is far from the expiration of a value having a longer TTL
+	cache1 := mockcache.NewMockSetterCacheInterface[string](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", 12*time.Second, nil)
+	cache1.EXPECT().Get(ctx, "gocache_metadata_my-key").Return("1ms 10m0s", nil)
+
+	loadFunc := func(_ context.Context, key any) (string, []store.Option, error) {
+		return "", nil, errors.New("should not be called")
+	}
+
+	cache := NewLoadable[string](loadFunc, cache1, WithRefreshAheadFraction(0.25))
+	defer cache.Close()
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, LoadableStats{}, cache.GetStats())
+}
+
+func TestLoadableGetWithRefreshAheadWhenLoadFails(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[string](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", 5*time.Second, nil)
+
+	loadFunc := func(_ context.Context, key any) (string, []store.Option, error) {
+		return "", nil, errors.New("database unavailable")
+	}
+
+	cache := NewLoadable[string](loadFunc, cache1, WithRefreshAhead(15*time.Second))
+	defer cache.Close()
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Eventually(t, func() bool {
+		return cache.GetStats() == LoadableStats{Loads: 1, LoadErrors: 1, Refreshes: 1, RefreshErrors: 1}
+	}, time.Second, time.Millisecond)
+}
+
+func TestLoadableGetWithMaxConcurrentRefreshes(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[string](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "key-1").Return("value-1", 5*time.Second, nil)
+	cache1.EXPECT().GetWithTTL(ctx, "key-2").Return("value-2", 5*time.Second, nil)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	loadFunc := func(_ context.Context, key any) (string, []store.Option, error) {
+		close(started)
+		<-release
+		return "", nil, errors.New("database unavailable")
+	}
+
+	cache := NewLoadable[string](loadFunc, cache1, WithRefreshAhead(15*time.Second), WithMaxConcurrentRefreshes(1))
+	defer cache.Close()
+
+	// When
+	_, err1 := cache.Get(ctx, "key-1")
+	<-started
+
+	_, err2 := cache.Get(ctx, "key-2")
+
+	// Then
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Eventually(t, func() bool {
+		return cache.GetStats() == LoadableStats{RefreshesSkipped: 1}
+	}, time.Second, time.Millisecond)
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return cache.GetStats() == LoadableStats{Loads: 1, LoadErrors: 1, Refreshes: 1, RefreshErrors: 1, RefreshesSkipped: 1}
+	}, time.Second, time.Millisecond)
+}
+
+func TestLoadableGetWithProbabilisticEarlyExpirationRecordsLoadDuration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[string](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("", 0*time.Second, store.NotFoundWithCause(nil))
+	cache1.EXPECT().Set(gomock.Any(), "my-key", "my-value", &store.OptionsMatcher{Expiration: time.Minute}).Return(nil)
+	cache1.EXPECT().Set(gomock.Any(), "gocache_metadata_my-key", gomock.Cond(func(value string) bool {
+		metadata, ok := parseLoadMetadata(value)
+		return ok && metadata.recompute >= 10*time.Millisecond && metadata.ttl == time.Minute
+	}), &store.OptionsMatcher{Expiration: time.Minute}).Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (string, []store.Option, error) {
+		time.Sleep(10 * time.Millisecond)
+		return "my-value", []store.Option{store.WithExpiration(time.Minute)}, nil
+	}
+
+	cache := NewLoadable[string](loadFunc, cache1, WithProbabilisticEarlyExpiration(1))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Closing waits for the value and its load duration to be stored
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.GreaterOrEqual(t, time.Duration(cache.loadDuration.Load()), 10*time.Millisecond)
+}
+
+func TestLoadableGetWithProbabilisticEarlyExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[string](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", 10*time.Second, nil)
+	cache1.EXPECT().Get(ctx, "gocache_metadata_my-key").Return("1s 1m0s", nil)
+	cache1.EXPECT().Set(gomock.Any(), "my-key", "fresh value", gomock.Any()).Return(nil)
+	cache1.EXPECT().Set(gomock.Any(), "gocache_metadata_my-key", gomock.Any(), gomock.Any()).Return(nil)
+
+	loaded := make(chan struct{})
+	loadFunc := func(_ context.Context, key any) (string, []store.Option, error) {
+		defer close(loaded)
+		return "fresh value", []store.Option{store.WithExpiration(time.Minute)}, nil
+	}
+
+	// a huge beta always refreshes the values before their expiration, the load duration
+	// being read from the cache although the instance has not loaded anything yet
+	cache := NewLoadable[string](loadFunc, cache1, WithProbabilisticEarlyExpiration(1e9))
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+
+	<-loaded
+	assert.Eventually(t, func() bool {
+		return cache.GetStats() == LoadableStats{Loads: 1, Refreshes: 1}
+	}, time.Second, time.Millisecond)
+	assert.Nil(t, cache.Close())
+}
+
+func TestLoadableGetWithProbabilisticEarlyExpirationWhenFarFromExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[string](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", time.Hour, nil)
+	cache1.EXPECT().Get(ctx, "gocache_metadata_my-key").Return("", store.NotFoundWithCause(nil))
+
+	loadFunc := func(_ context.Context, key any) (string, []store.Option, error) {
+		return "", nil, errors.New("should not be called")
+	}
+
+	// without a stored load duration, the average of the instance is used
+	cache := NewLoadable[string](loadFunc, cache1, WithProbabilisticEarlyExpiration(1))
+	cache.recordLoadDuration(time.Nanosecond)
+	defer cache.Close()
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, LoadableStats{}, cache.GetStats())
+}
+
+func TestLoadableGetWithProbabilisticEarlyExpirationWhenNoValueLoaded(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[map[string]int](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return(map[string]int{"a": 1}, 10*time.Second, nil)
+
+	loadFunc := func(_ context.Context, key any) (map[string]int, []store.Option, error) {
+		return nil, nil, errors.New("should not be called")
+	}
+
+	cache := NewLoadable[map[string]int](loadFunc, cache1, WithProbabilisticEarlyExpiration(1e9))
+	defer cache.Close()
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{"a": 1}, value)
+	assert.Equal(t, LoadableStats{}, cache.GetStats())
+}
+
+func TestLoadableGetWithErrorCaching(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, store.NotFoundWithCause(nil)).Times(2)
+
+	var loadCallCount int32
+	loadErr := errors.New("database unavailable")
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		atomic.AddInt32(&loadCallCount, 1)
+		return nil, nil, loadErr
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithErrorCaching(time.Minute))
+	defer cache.Close()
+
+	// When
+	_, err1 := cache.Get(ctx, "my-key")
+	_, err2 := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.ErrorIs(t, err1, loadErr)
+	assert.NotErrorIs(t, err1, ErrLoadBackedOff)
+	assert.ErrorIs(t, err2, loadErr)
+	assert.ErrorIs(t, err2, ErrLoadBackedOff)
+	assert.Equal(t, int32(1), loadCallCount)
+	assert.Equal(t, LoadableStats{Loads: 1, LoadErrors: 1, LoadsBackedOff: 1}, cache.GetStats())
+}
+
+func TestLoadableSetClearsCachedError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, store.NotFoundWithCause(nil)).Times(2)
+	cache1.EXPECT().Set(ctx, "my-key", "my-value").Return(nil)
+
+	var loadCallCount int32
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		atomic.AddInt32(&loadCallCount, 1)
+		return nil, nil, errors.New("database unavailable")
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithErrorCaching(time.Minute))
+	defer cache.Close()
+
+	// When
+	_, err1 := cache.Get(ctx, "my-key")
+	assert.Nil(t, cache.Set(ctx, "my-key", "my-value"))
+	_, err2 := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Error(t, err1)
+	assert.NotErrorIs(t, err2, ErrLoadBackedOff)
+	assert.Equal(t, int32(2), loadCallCount)
+}
+
+func TestLoadableErrorBackoffRetryDelay(t *testing.T) {
+	// Given
+	options := applyLoadableOptions(WithErrorBackoff(time.Second, 10*time.Second))
+
+	// When - Then
+	assert.Equal(t, time.Second, options.retryDelay(1))
+	assert.Equal(t, 2*time.Second, options.retryDelay(2))
+	assert.Equal(t, 4*time.Second, options.retryDelay(3))
+	assert.Equal(t, 8*time.Second, options.retryDelay(4))
+	assert.Equal(t, 10*time.Second, options.retryDelay(5))
+	assert.Equal(t, 10*time.Second, options.retryDelay(100))
+}
+
+func TestLoadableGetWithErrorBackoff(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, store.NotFoundWithCause(nil)).AnyTimes()
+
+	var loadCallCount int32
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		atomic.AddInt32(&loadCallCount, 1)
+		return nil, nil, errors.New("database unavailable")
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithErrorBackoff(50*time.Millisecond, time.Minute))
+	defer cache.Close()
+
+	// When
+	_, err := cache.Get(ctx, "my-key")
+	assert.NotErrorIs(t, err, ErrLoadBackedOff)
+
+	// the first retry delay is elapsed
+	time.Sleep(70 * time.Millisecond)
+	_, err = cache.Get(ctx, "my-key")
+	assert.NotErrorIs(t, err, ErrLoadBackedOff)
+
+	// the second retry delay is twice as long
+	time.Sleep(70 * time.Millisecond)
+	_, err = cache.Get(ctx, "my-key")
+
+	// Then
+	assert.ErrorIs(t, err, ErrLoadBackedOff)
+	assert.Equal(t, int32(2), loadCallCount)
+}
+
+func TestLoadableGetWithMaxConcurrentLoads(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, gomock.Any()).Return(nil, store.NotFoundWithCause(nil)).Times(10)
+	cache1.EXPECT().Set(context.Background(), gomock.Any(), "a value").Return(nil).Times(10)
+
+	var running, maxRunning int32
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+
+		for {
+			previous := atomic.LoadInt32(&maxRunning)
+			if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+		return "a value", []store.Option{}, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithMaxConcurrentLoads(2))
+
+	// When
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Go(func() {
+			value, err := cache.Get(ctx, fmt.Sprintf("key-%d", i))
+			assert.Nil(t, err)
+			assert.Equal(t, "a value", value)
+		})
+	}
+	wg.Wait()
+
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.LessOrEqual(t, maxRunning, int32(2))
+}
+
+func TestLoadableGetWithSynchronousSet(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	var stored atomic.Bool
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, store.NotFoundWithCause(nil))
+	cache1.EXPECT().Set(context.Background(), "my-key", "my-value").DoAndReturn(
+		func(_ context.Context, _ any, _ any, _ ...store.Option) error {
+			stored.Store(true)
+			return nil
+		},
+	)
+
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		return "my-value", []store.Option{}, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithSynchronousSet())
+	defer cache.Close()
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.True(t, stored.Load())
+}
+
+// blockingSetCache returns a cache whose Set calls for the given key block until the
+// returned channel is closed, signaling when they start
+func blockingSetCache(ctrl *gomock.Controller, blockedKey string) (*mockcache.MockSetterCacheInterface[any], chan struct{}, chan struct{}) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, store.NotFoundWithCause(nil)).AnyTimes()
+	cache1.EXPECT().Set(context.Background(), blockedKey, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ any, _ any, _ ...store.Option) error {
+			close(started)
+			<-release
+			return nil
+		},
+	)
+
+	return cache1, started, release
+}
+
+func TestLoadableGetWhenSetterQueueFullAndDropPolicy(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1, started, release := blockingSetCache(ctrl, "key-1")
+	cache1.EXPECT().Set(context.Background(), "key-2", "key-2").Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		return key, []store.Option{}, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithSetterQueueSize(1), WithSetterOverflow(SetterOverflowDrop))
+
+	// When
+	_, err1 := cache.Get(ctx, "key-1")
+	<-started
+
+	_, err2 := cache.Get(ctx, "key-2")
+	value, err3 := cache.Get(ctx, "key-3")
+
+	stats := cache.GetStats()
+
+	close(release)
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+	assert.Equal(t, "key-3", value)
+	assert.Equal(t, 1, stats.SetQueueDepth)
+	assert.Equal(t, 1, stats.SetsDropped)
+}
+
+func TestLoadableGetWhenSetterQueueFullAndWriteInlinePolicy(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1, started, release := blockingSetCache(ctrl, "key-1")
+	cache1.EXPECT().Set(context.Background(), "key-2", "key-2").Return(nil)
+
+	var storedInline atomic.Bool
+	cache1.EXPECT().Set(context.Background(), "key-3", "key-3").DoAndReturn(
+		func(_ context.Context, _ any, _ any, _ ...store.Option) error {
+			storedInline.Store(true)
+			return nil
+		},
+	)
+
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		return key, []store.Option{}, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithSetterQueueSize(1), WithSetterOverflow(SetterOverflowWriteInline))
+
+	// When
+	_, err1 := cache.Get(ctx, "key-1")
+	<-started
+
+	_, err2 := cache.Get(ctx, "key-2")
+	_, err3 := cache.Get(ctx, "key-3")
+
+	// Then
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Nil(t, err3)
+	assert.True(t, storedInline.Load())
+
+	close(release)
+	assert.Nil(t, cache.Close())
+}
+
+func TestLoadableGetWithSetterWorkers(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1, started, release := blockingSetCache(ctrl, "key-1")
+
+	stored := make(chan struct{})
+	cache1.EXPECT().Set(context.Background(), "key-2", "key-2").DoAndReturn(
+		func(_ context.Context, _ any, _ any, _ ...store.Option) error {
+			close(stored)
+			return nil
+		},
+	)
+
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		return key, []store.Option{}, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithSetterWorkers(2))
+
+	// When
+	_, err1 := cache.Get(ctx, "key-1")
+	<-started
+
+	_, err2 := cache.Get(ctx, "key-2")
+
+	// Then the second worker stores key-2 while the first one is blocked
+	<-stored
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+
+	close(release)
+	assert.Nil(t, cache.Close())
+}
+
+func TestLoadableGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockBatchCacheInterface.EXPECT().GetMany(ctx, []any{"key-1", "key-2", "key-3"}).Return(map[any]any{"key-1": "value-1"}, nil)
+	cache1.MockSetterCacheInterface.EXPECT().Set(context.Background(), "key-2", "value-2").Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		if key == "key-2" {
+			return "value-2", []store.Option{}, nil
+		}
+		return nil, nil, store.NotFoundWithCause(errors.New("not found in source"))
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key-1", "key-2", "key-3"})
+
+	// Closing waits for the loaded values to be stored into the cache
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1", "key-2": "value-2"}, values)
+}
+
 func TestLoadableDelete(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -199,6 +1277,182 @@ func TestLoadableDeleteWhenError(t *testing.T) {
 	assert.Equal(t, expectedErr, err)
 }
 
+func TestLoadableDeleteWhileLoading(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, store.NotFoundWithCause(nil)).Times(2)
+	cache1.EXPECT().Delete(ctx, "my-key").Return(nil)
+	// only the value loaded after the deletion is stored
+	cache1.EXPECT().Set(context.Background(), "my-key", "second-value").Return(nil)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var calls atomic.Int32
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		if calls.Add(1) == 2 {
+			return "second-value", []store.Option{}, nil
+		}
+		close(started)
+		<-release
+		return "first-value", []store.Option{}, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+
+	go func() {
+		<-started
+		assert.Nil(t, cache.Delete(ctx, "my-key"))
+		close(release)
+	}()
+
+	// When
+	firstValue, firstErr := cache.Get(ctx, "my-key")
+	secondValue, secondErr := cache.Get(ctx, "my-key")
+
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.Nil(t, firstErr)
+	assert.Equal(t, "first-value", firstValue)
+	assert.Nil(t, secondErr)
+	assert.Equal(t, "second-value", secondValue)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestLoadableDeleteWhileStoring(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	storing := make(chan struct{})
+	release := make(chan struct{})
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, store.NotFoundWithCause(nil))
+	cache1.EXPECT().Set(context.Background(), "my-key", "my-value").DoAndReturn(func(_ context.Context, _ any, _ any, _ ...store.Option) error {
+		close(storing)
+		<-release
+		return nil
+	})
+	// the deletion, then the value stored in the meantime being deleted again
+	cache1.EXPECT().Delete(ctx, "my-key").Return(nil).Times(2)
+
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		return "my-value", []store.Option{}, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+
+	value, err := cache.Get(ctx, "my-key")
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+
+	// When
+	<-storing
+	// the deletion does not wait for the value to be stored
+	assert.Nil(t, cache.Delete(ctx, "my-key"))
+	close(release)
+
+	// Then
+	assert.Nil(t, cache.Close())
+}
+
+func TestLoadableSetWhileStoringAnotherKey(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	storing := make(chan struct{})
+	release := make(chan struct{})
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, store.NotFoundWithCause(nil))
+	cache1.EXPECT().Set(context.Background(), "my-key", "my-value").DoAndReturn(func(_ context.Context, _ any, _ any, _ ...store.Option) error {
+		close(storing)
+		<-release
+		return nil
+	})
+	cache1.EXPECT().Set(ctx, "other-key", "other-value").Return(nil)
+
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		return "my-value", []store.Option{}, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+
+	_, err := cache.Get(ctx, "my-key")
+	assert.Nil(t, err)
+
+	// When
+	<-storing
+	err = cache.Set(ctx, "other-key", "other-value")
+	close(release)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Nil(t, cache.Close())
+}
+
+func TestLoadableKeys(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockScannerCacheInterface.EXPECT().Keys(ctx, gomock.Any()).Return(keysSeq("key-1", "key-2"))
+
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		return "a value", []store.Option{}, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+	defer cache.Close()
+
+	// When
+	keys := []any{}
+	for key, err := range cache.Keys(ctx, store.WithScanMatch("key-*")) {
+		assert.Nil(t, err)
+		keys = append(keys, key)
+	}
+
+	// Then
+	assert.Equal(t, []any{"key-1", "key-2"}, keys)
+}
+
+func TestLoadableKeysWithProbabilisticEarlyExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockScannerCacheInterface.EXPECT().Keys(ctx, gomock.Any()).Return(keysSeq("key-1", "gocache_metadata_key-1", "key-2"))
+
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		return "a value", []store.Option{}, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1, WithProbabilisticEarlyExpiration(1))
+	defer cache.Close()
+
+	// When
+	keys := []any{}
+	for key, err := range cache.Keys(ctx) {
+		assert.Nil(t, err)
+		keys = append(keys, key)
+	}
+
+	// Then
+	assert.Equal(t, []any{"key-1", "key-2"}, keys)
+}
+
 func TestLoadableInvalidate(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -295,6 +1549,45 @@ func TestLoadableClearWhenError(t *testing.T) {
 	assert.Equal(t, expectedErr, err)
 }
 
+func TestLoadableClearWhileLoading(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, store.NotFoundWithCause(nil))
+	cache1.EXPECT().Clear(ctx).Return(nil)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		close(started)
+		<-release
+		return "my-value", []store.Option{}, nil
+	}
+
+	cache := NewLoadable[any](loadFunc, cache1)
+
+	go func() {
+		<-started
+		assert.Nil(t, cache.Clear(ctx))
+		close(release)
+	}()
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	assert.Nil(t, cache.Close())
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+
+	_, pending := cache.setCache.Load("my-key")
+	assert.False(t, pending)
+}
+
 func TestLoadableGetType(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
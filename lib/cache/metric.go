@@ -2,6 +2,8 @@ package cache
 
 import (
 	"context"
+	"iter"
+	"time"
 
 	"github.com/eko/gocache/lib/v4/metrics"
 	"github.com/eko/gocache/lib/v4/store"
@@ -39,16 +41,84 @@ func (c *MetricCache[T]) Get(ctx context.Context, key any) (T, error) {
 	return result, err
 }
 
+// GetWithTTL obtains a value and its remaining TTL from cache and also records metrics
+func (c *MetricCache[T]) GetWithTTL(ctx context.Context, key any) (T, time.Duration, error) {
+	result, ttl, err := getWithTTLFunc(c.cache)(ctx, key)
+
+	c.updateMetrics(c.cache)
+
+	return result, ttl, err
+}
+
+// GetMany obtains several values from cache and also records metrics
+func (c *MetricCache[T]) GetMany(ctx context.Context, keys []any) (map[any]T, error) {
+	result, err := getMany(ctx, c.cache, keys)
+
+	c.updateMetrics(c.cache)
+
+	return result, err
+}
+
 // Set sets a value from the cache
 func (c *MetricCache[T]) Set(ctx context.Context, key any, object T, options ...store.Option) error {
 	return c.cache.Set(ctx, key, object, options...)
 }
 
+// SetMany sets several values at once in the cache
+func (c *MetricCache[T]) SetMany(ctx context.Context, items map[any]T, options ...store.Option) error {
+	return setMany(ctx, c.cache, items, options...)
+}
+
 // Delete removes a value from the cache
 func (c *MetricCache[T]) Delete(ctx context.Context, key any) error {
 	return c.cache.Delete(ctx, key)
 }
 
+// DeleteMany removes several values from the cache
+func (c *MetricCache[T]) DeleteMany(ctx context.Context, keys []any) error {
+	return deleteMany(ctx, c.cache, keys)
+}
+
+// Keys iterates over the keys held by the underlying cache
+func (c *MetricCache[T]) Keys(ctx context.Context, options ...store.ScanOption) iter.Seq2[any, error] {
+	return scanKeys(ctx, c.cache, options...)
+}
+
+// Increment atomically adds the given delta to the counter held by the underlying cache
+func (c *MetricCache[T]) Increment(ctx context.Context, key any, delta int64, options ...store.Option) (T, error) {
+	return increment(ctx, c.cache, key, delta, options...)
+}
+
+// Decrement atomically subtracts the given delta from the counter held by the underlying cache
+func (c *MetricCache[T]) Decrement(ctx context.Context, key any, delta int64, options ...store.Option) (T, error) {
+	return decrement(ctx, c.cache, key, delta, options...)
+}
+
+// SetIfNotExists sets a value in the underlying cache only if it does not exist yet
+func (c *MetricCache[T]) SetIfNotExists(ctx context.Context, key any, object T, options ...store.Option) error {
+	return setIfNotExists(ctx, c.cache, key, object, options...)
+}
+
+// GetWithVersion returns a value from the underlying cache along with its version
+func (c *MetricCache[T]) GetWithVersion(ctx context.Context, key any) (T, store.Version, error) {
+	return getWithVersion(ctx, c.cache, key)
+}
+
+// CompareAndSwap sets a value in the underlying cache only if it still matches the given version
+func (c *MetricCache[T]) CompareAndSwap(ctx context.Context, key any, object T, version store.Version, options ...store.Option) error {
+	return compareAndSwap(ctx, c.cache, key, object, version, options...)
+}
+
+// Touch changes the expiration of the given key in the underlying cache
+func (c *MetricCache[T]) Touch(ctx context.Context, key any, ttl time.Duration) error {
+	return touch(ctx, c.cache, key, ttl)
+}
+
+// SlidingExpiration returns the sliding expiration given to the key in the underlying cache
+func (c *MetricCache[T]) SlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	return slidingExpiration(ctx, c.cache, key)
+}
+
 // Invalidate invalidates cache item from given options
 func (c *MetricCache[T]) Invalidate(ctx context.Context, options ...store.InvalidateOption) error {
 	return c.cache.Invalidate(ctx, options...)
@@ -67,6 +137,12 @@ func (c *MetricCache[T]) updateMetrics(cache CacheInterface[T]) {
 			c.updateMetrics(cache)
 		}
 
+	case *LoadableCache[T]:
+		if loaderMetrics, ok := c.metrics.(metrics.LoaderMetricsInterface); ok {
+			loaderMetrics.RecordLoaderStats(current.GetName(), current.GetStats())
+		}
+		c.updateMetrics(current.cache)
+
 	case SetterCacheInterface[T]:
 		c.metrics.RecordFromCodec(current.GetCodec())
 	}
@@ -10,6 +10,8 @@ import (
 	mockcodec "github.com/eko/gocache/lib/v4/internal/mocks/codec"
 	mockmetrics "github.com/eko/gocache/lib/v4/internal/mocks/metrics"
 	mockstore "github.com/eko/gocache/lib/v4/internal/mocks/store"
+	"github.com/eko/gocache/lib/v4/metrics"
+	"github.com/eko/gocache/lib/v4/store"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -102,6 +104,119 @@ func TestMetricGetWhenChainCache(t *testing.T) {
 	assert.Equal(t, cacheValue, value)
 }
 
+func TestMetricGetWhenLoadableCache(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	codec1 := mockcodec.NewMockCodecInterface(ctrl)
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return(nil, errors.New("store unavailable"))
+	cache1.EXPECT().GetCodec().Return(codec1).MinTimes(1)
+
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		return nil, nil, errors.New("database unavailable")
+	}
+
+	loadableCache := NewLoadable[any](loadFunc, cache1)
+	defer loadableCache.Close()
+
+	loaderMetrics := mockmetrics.NewMockLoaderMetricsInterface(ctrl)
+	loaderMetrics.EXPECT().RecordFromCodec(codec1).MinTimes(1)
+	loaderMetrics.EXPECT().RecordLoaderStats(LoadableType, metrics.LoaderStats{})
+	loaderMetrics.EXPECT().RecordLoaderStats(LoadableType, metrics.LoaderStats{Loads: 1, LoadErrors: 1})
+
+	cache := NewMetric[any](loaderMetrics, loadableCache)
+
+	// When
+	_, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.EqualError(t, err, "database unavailable")
+}
+
+func TestMetricGetWhenLoadableCacheWithName(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	codec1 := mockcodec.NewMockCodecInterface(ctrl)
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().Get(ctx, "my-key").Return("my-value", nil)
+	cache1.EXPECT().GetCodec().Return(codec1).MinTimes(1)
+
+	loadFunc := func(_ context.Context, key any) (any, []store.Option, error) {
+		return nil, nil, errors.New("database unavailable")
+	}
+
+	loadableCache := NewLoadable[any](loadFunc, cache1, WithLoaderName("books-loader"))
+	defer loadableCache.Close()
+
+	loaderMetrics := mockmetrics.NewMockLoaderMetricsInterface(ctrl)
+	loaderMetrics.EXPECT().RecordFromCodec(codec1).MinTimes(1)
+	loaderMetrics.EXPECT().RecordLoaderStats("books-loader", metrics.LoaderStats{}).Times(2)
+
+	cache := NewMetric[any](loaderMetrics, loadableCache)
+
+	// When
+	value, err := cache.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestMetricGetWithTTL(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	codec1 := mockcodec.NewMockCodecInterface(ctrl)
+	cache1 := mockcache.NewMockSetterCacheInterface[any](ctrl)
+	cache1.EXPECT().GetWithTTL(ctx, "my-key").Return("my-value", 10*time.Second, nil)
+	cache1.EXPECT().GetCodec().Return(codec1).Times(2)
+
+	metrics := mockmetrics.NewMockMetricsInterface(ctrl)
+	metrics.EXPECT().RecordFromCodec(codec1).Times(2)
+
+	cache := NewMetric[any](metrics, cache1)
+
+	// When
+	value, ttl, err := cache.GetWithTTL(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, 10*time.Second, ttl)
+}
+
+func TestMetricGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	codec1 := mockcodec.NewMockCodecInterface(ctrl)
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockBatchCacheInterface.EXPECT().GetMany(ctx, []any{"key-1", "key-2"}).Return(map[any]any{"key-1": "value-1"}, nil)
+	cache1.MockSetterCacheInterface.EXPECT().GetCodec().Return(codec1).MinTimes(1)
+
+	metrics := mockmetrics.NewMockMetricsInterface(ctrl)
+	metrics.EXPECT().RecordFromCodec(codec1).MinTimes(1)
+
+	cache := NewMetric[any](metrics, cache1)
+
+	// When
+	values, err := cache.GetMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1"}, values)
+}
+
 func TestMetricSet(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -179,6 +294,52 @@ func TestMetricDeleteWhenError(t *testing.T) {
 	assert.Equal(t, expectedErr, err)
 }
 
+func TestMetricKeysWhenCacheDoesNotSupportScan(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cache1 := mockcache.NewMockCacheInterface[any](ctrl)
+
+	metrics := mockmetrics.NewMockMetricsInterface(ctrl)
+
+	cache := NewMetric[any](metrics, cache1)
+
+	// When
+	var errs []error
+	for _, err := range cache.Keys(ctx) {
+		errs = append(errs, err)
+	}
+
+	// Then
+	assert.Equal(t, []error{store.ErrUnsupported}, errs)
+}
+
+func TestMetricIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	codec1 := mockcodec.NewMockCodecInterface(ctrl)
+	cache1 := newOptionalCache[any](ctrl)
+	cache1.MockCounterCacheInterface.EXPECT().Increment(ctx, "my-counter", int64(1)).Return(int64(1), nil)
+	cache1.MockSetterCacheInterface.EXPECT().GetCodec().Return(codec1).Times(1)
+
+	metrics := mockmetrics.NewMockMetricsInterface(ctrl)
+	metrics.EXPECT().RecordFromCodec(codec1).Times(1)
+
+	cache := NewMetric[any](metrics, cache1)
+
+	// When
+	value, err := cache.Increment(ctx, "my-counter", 1)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), value)
+}
+
 func TestMetricInvalidate(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -0,0 +1,28 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/eko/gocache/lib/v4/store"
+)
+
+// touch changes the expiration of the key using the cache implementation
+// when available or returns store.ErrUnsupported otherwise
+func touch[T any](ctx context.Context, cache CacheInterface[T], key any, ttl time.Duration) error {
+	if touchCache, ok := cache.(TouchCacheInterface); ok {
+		return touchCache.Touch(ctx, key, ttl)
+	}
+
+	return store.ErrUnsupported
+}
+
+// slidingExpiration returns the sliding expiration of the key using the cache
+// implementation when available or returns store.ErrUnsupported otherwise
+func slidingExpiration[T any](ctx context.Context, cache CacheInterface[T], key any) (time.Duration, error) {
+	if slidingCache, ok := cache.(SlidingCacheInterface); ok {
+		return slidingCache.SlidingExpiration(ctx, key)
+	}
+
+	return 0, store.ErrUnsupported
+}
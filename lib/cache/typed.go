@@ -0,0 +1,263 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/eko/gocache/lib/v4/codec"
+	"github.com/eko/gocache/lib/v4/store"
+)
+
+// KeyEncoder converts a typed key into the key given to the cache store
+type KeyEncoder[K comparable] func(key K) any
+
+// StringKey is a KeyEncoder converting keys into strings, to be used with remote stores.
+// Strings and integers are converted into their value, keys implementing CacheKeyGenerator
+// into their generated key and other keys into a checksum of their structure.
+func StringKey[K comparable](key K) any {
+	switch v := any(key).(type) {
+	case string:
+		return v
+	case CacheKeyGenerator:
+		return v.GetCacheKey()
+	}
+
+	switch value := reflect.ValueOf(key); value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(value.Uint(), 10)
+	default:
+		return checksum(key)
+	}
+}
+
+// NativeKey is a KeyEncoder giving keys as is to the cache store, to be used with
+// in-memory stores supporting typed keys such as Ristretto or Freecache (with int64 keys).
+// Other stores only accept string keys: NewTyped returns an error when the store does
+// not report K in the KeyTypes of its capabilities.
+func NativeKey[K comparable](key K) any {
+	return key
+}
+
+// TypedCache is a cache whose keys are of a given type, checked at compile time.
+// Keys are converted using a KeyEncoder before being given to the cache store.
+//
+// As its methods take keys of type K instead of any, TypedCache does not implement
+// CacheInterface and cannot be wrapped by chain, loadable or metric caches.
+type TypedCache[K comparable, T any] struct {
+	cache *Cache[T]
+}
+
+// NewTyped instantiates a new cache with typed keys, encoded using the given
+// encoder or StringKey if none is given. An error wrapping store.ErrInvalidKeyType
+// is returned if the keys given by the encoder are not accepted by the store.
+func NewTyped[K comparable, T any](store store.StoreInterface, encoder KeyEncoder[K]) (*TypedCache[K, T], error) {
+	if encoder == nil {
+		encoder = StringKey[K]
+	}
+
+	if err := checkKeyEncoder(store, encoder); err != nil {
+		return nil, err
+	}
+
+	return &TypedCache[K, T]{
+		cache: &Cache[T]{
+			codec: codec.New(store),
+			keyEncoder: func(key any) any {
+				return encoder(key.(K))
+			},
+		},
+	}, nil
+}
+
+// checkKeyEncoder returns an error if the type of the keys given by the encoder, as
+// for the zero key, is not one of the key types accepted by the store
+func checkKeyEncoder[K comparable](s store.StoreInterface, encoder KeyEncoder[K]) error {
+	switch reflect.TypeFor[K]().Kind() {
+	case reflect.Pointer, reflect.Interface:
+		// the zero key is nil and may not be encoded
+		return nil
+	}
+
+	keyType := reflect.TypeOf(encoder(*new(K)))
+	if !store.CapabilitiesOf(s).AcceptsKeyType(keyType) {
+		return fmt.Errorf("%w: the %s store does not accept keys of type %s", store.ErrInvalidKeyType, s.GetType(), keyType)
+	}
+
+	return nil
+}
+
+// Get returns the object stored in cache if it exists
+func (c *TypedCache[K, T]) Get(ctx context.Context, key K) (T, error) {
+	return c.cache.Get(ctx, key)
+}
+
+// GetWithTTL returns the object stored in cache and its corresponding TTL
+func (c *TypedCache[K, T]) GetWithTTL(ctx context.Context, key K) (T, time.Duration, error) {
+	return c.cache.GetWithTTL(ctx, key)
+}
+
+// Set populates the cache item using the given key
+func (c *TypedCache[K, T]) Set(ctx context.Context, key K, object T, options ...store.Option) error {
+	return c.cache.Set(ctx, key, object, options...)
+}
+
+// Delete removes the cache item using the given key
+func (c *TypedCache[K, T]) Delete(ctx context.Context, key K) error {
+	return c.cache.Delete(ctx, key)
+}
+
+// GetMany returns the objects stored in cache for the given keys.
+// Keys that are not found are omitted from the returned map.
+func (c *TypedCache[K, T]) GetMany(ctx context.Context, keys []K) (map[K]T, error) {
+	values, err := c.cache.GetMany(ctx, anyKeys(keys))
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make(map[K]T, len(values))
+	for key, object := range values {
+		objects[key.(K)] = object
+	}
+
+	return objects, nil
+}
+
+// SetMany populates several cache items at once using the same options
+func (c *TypedCache[K, T]) SetMany(ctx context.Context, items map[K]T, options ...store.Option) error {
+	objects := make(map[any]T, len(items))
+	for key, object := range items {
+		objects[key] = object
+	}
+
+	return c.cache.SetMany(ctx, objects, options...)
+}
+
+// DeleteMany removes the cache items using the given keys
+func (c *TypedCache[K, T]) DeleteMany(ctx context.Context, keys []K) error {
+	return c.cache.DeleteMany(ctx, anyKeys(keys))
+}
+
+// Keys iterates over the keys held by the cache store, converted back into typed keys.
+// Keys given as is to the store, or converted by StringKey from a string or integer
+// kind, can be converted back: an error wrapping store.ErrInvalidKeyType is yielded
+// for the other ones, such as checksums, and iterating goes on.
+func (c *TypedCache[K, T]) Keys(ctx context.Context, options ...store.ScanOption) iter.Seq2[K, error] {
+	return func(yield func(K, error) bool) {
+		for key, err := range c.cache.Keys(ctx, options...) {
+			if err != nil {
+				yield(*new(K), err)
+				return
+			}
+			if !yield(decodeKey[K](key)) {
+				return
+			}
+		}
+	}
+}
+
+// Increment atomically adds the given delta to the counter stored for the given key
+func (c *TypedCache[K, T]) Increment(ctx context.Context, key K, delta int64, options ...store.Option) (T, error) {
+	return c.cache.Increment(ctx, key, delta, options...)
+}
+
+// Decrement atomically subtracts the given delta from the counter stored for the given key
+func (c *TypedCache[K, T]) Decrement(ctx context.Context, key K, delta int64, options ...store.Option) (T, error) {
+	return c.cache.Decrement(ctx, key, delta, options...)
+}
+
+// SetIfNotExists populates the cache item only if the key does not exist yet
+func (c *TypedCache[K, T]) SetIfNotExists(ctx context.Context, key K, object T, options ...store.Option) error {
+	return c.cache.SetIfNotExists(ctx, key, object, options...)
+}
+
+// GetWithVersion returns the object stored in cache along with its version
+func (c *TypedCache[K, T]) GetWithVersion(ctx context.Context, key K) (T, store.Version, error) {
+	return c.cache.GetWithVersion(ctx, key)
+}
+
+// CompareAndSwap populates the cache item only if the stored one still matches the given version
+func (c *TypedCache[K, T]) CompareAndSwap(ctx context.Context, key K, object T, version store.Version, options ...store.Option) error {
+	return c.cache.CompareAndSwap(ctx, key, object, version, options...)
+}
+
+// Touch changes the expiration of the given key without rewriting its value
+func (c *TypedCache[K, T]) Touch(ctx context.Context, key K, ttl time.Duration) error {
+	return c.cache.Touch(ctx, key, ttl)
+}
+
+// SlidingExpiration returns the sliding expiration given to the key, if any
+func (c *TypedCache[K, T]) SlidingExpiration(ctx context.Context, key K) (time.Duration, error) {
+	return c.cache.SlidingExpiration(ctx, key)
+}
+
+// Invalidate invalidates cache item from given options
+func (c *TypedCache[K, T]) Invalidate(ctx context.Context, options ...store.InvalidateOption) error {
+	return c.cache.Invalidate(ctx, options...)
+}
+
+// Clear resets all cache data
+func (c *TypedCache[K, T]) Clear(ctx context.Context) error {
+	return c.cache.Clear(ctx)
+}
+
+// Capabilities returns the features supported by the cache store
+func (c *TypedCache[K, T]) Capabilities() store.Capabilities {
+	return c.cache.Capabilities()
+}
+
+// GetCodec returns the current codec
+func (c *TypedCache[K, T]) GetCodec() codec.CodecInterface {
+	return c.cache.GetCodec()
+}
+
+// GetType returns the cache type
+func (c *TypedCache[K, T]) GetType() string {
+	return c.cache.GetType()
+}
+
+// decodeKey converts a key held by the cache store back into a typed key
+func decodeKey[K comparable](key any) (K, error) {
+	if typed, ok := key.(K); ok {
+		return typed, nil
+	}
+
+	var typed K
+	if encoded, ok := key.(string); ok {
+		value := reflect.ValueOf(&typed).Elem()
+		switch value.Kind() {
+		case reflect.String:
+			value.SetString(encoded)
+			return typed, nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if i, err := strconv.ParseInt(encoded, 10, value.Type().Bits()); err == nil {
+				value.SetInt(i)
+				return typed, nil
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if u, err := strconv.ParseUint(encoded, 10, value.Type().Bits()); err == nil {
+				value.SetUint(u)
+				return typed, nil
+			}
+		}
+	}
+
+	return typed, fmt.Errorf("%w: key %v cannot be converted into a %T", store.ErrInvalidKeyType, key, typed)
+}
+
+// anyKeys converts typed keys into the keys expected by Cache
+func anyKeys[K comparable](keys []K) []any {
+	result := make([]any, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, key)
+	}
+
+	return result
+}
@@ -0,0 +1,217 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	mockstore "github.com/eko/gocache/lib/v4/internal/mocks/store"
+	libstore "github.com/eko/gocache/lib/v4/store"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type userID int64
+
+func TestStringKey(t *testing.T) {
+	type structKey struct {
+		Hello string
+	}
+
+	assert.Equal(t, "my-key", StringKey("my-key"))
+	assert.Equal(t, "42", StringKey(userID(42)))
+	assert.Equal(t, "-1", StringKey(-1))
+	assert.Equal(t, "7", StringKey(uint8(7)))
+	assert.Equal(t, "my-generated-key", StringKey(&StructWithGenerator{}))
+	assert.Equal(t, checksum(structKey{Hello: "world"}), StringKey(structKey{Hello: "world"}))
+}
+
+func TestTypedCacheWithStringKeys(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+	store.EXPECT().Set(ctx, "42", "john", libstore.OptionsMatcher{Expiration: time.Minute}).Return(nil)
+	store.EXPECT().Get(ctx, "42").Return("john", nil)
+
+	cache, err := NewTyped[userID, string](store, nil)
+	assert.Nil(t, err)
+
+	// When
+	err = cache.Set(ctx, 42, "john", libstore.WithExpiration(time.Minute))
+	assert.Nil(t, err)
+
+	value, err := cache.Get(ctx, 42)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "john", value)
+}
+
+// nativeKeysStore is a store accepting int64 keys as is
+type nativeKeysStore struct {
+	*mockstore.MockStoreInterface
+	*mockstore.MockScannerStoreInterface
+	*mockstore.MockCapabilitiesStoreInterface
+}
+
+func newNativeKeysStore(ctrl *gomock.Controller) *nativeKeysStore {
+	store := &nativeKeysStore{
+		MockStoreInterface:             mockstore.NewMockStoreInterface(ctrl),
+		MockScannerStoreInterface:      mockstore.NewMockScannerStoreInterface(ctrl),
+		MockCapabilitiesStoreInterface: mockstore.NewMockCapabilitiesStoreInterface(ctrl),
+	}
+	store.MockCapabilitiesStoreInterface.EXPECT().Capabilities().Return(libstore.Capabilities{
+		KeyTypes: []reflect.Type{reflect.TypeFor[int64]()},
+	}).AnyTimes()
+
+	return store
+}
+
+func TestTypedCacheWithNativeKeys(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := newNativeKeysStore(ctrl)
+	store.MockStoreInterface.EXPECT().Delete(ctx, int64(42)).Return(nil)
+
+	cache, err := NewTyped[int64, string](store, NativeKey[int64])
+	assert.Nil(t, err)
+
+	// When
+	err = cache.Delete(ctx, 42)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestNewTypedWhenStoreDoesNotAcceptNativeKeys(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+	store.EXPECT().GetType().Return("redis")
+
+	// When
+	cache, err := NewTyped[int64, string](store, NativeKey[int64])
+
+	// Then
+	assert.Nil(t, cache)
+	assert.ErrorIs(t, err, libstore.ErrInvalidKeyType)
+	assert.EqualError(t, err, "key type not supported by store: the redis store does not accept keys of type int64")
+}
+
+func TestTypedCacheGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+	store.EXPECT().Get(ctx, "1").Return("john", nil)
+	store.EXPECT().Get(ctx, "2").Return(nil, libstore.NotFoundWithCause(errors.New("not found")))
+
+	cache, err := NewTyped[userID, string](store, StringKey[userID])
+	assert.Nil(t, err)
+
+	// When
+	values, err := cache.GetMany(ctx, []userID{1, 2})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[userID]string{1: "john"}, values)
+}
+
+func TestTypedCacheSetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+	store.EXPECT().Set(ctx, "1", "john").Return(nil)
+	store.EXPECT().Set(ctx, "2", "jane").Return(nil)
+
+	cache, err := NewTyped[userID, string](store, nil)
+	assert.Nil(t, err)
+
+	// When
+	err = cache.SetMany(ctx, map[userID]string{1: "john", 2: "jane"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+type scannerStore struct {
+	*mockstore.MockStoreInterface
+	*mockstore.MockScannerStoreInterface
+}
+
+func TestTypedCacheKeys(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := &scannerStore{
+		MockStoreInterface:        mockstore.NewMockStoreInterface(ctrl),
+		MockScannerStoreInterface: mockstore.NewMockScannerStoreInterface(ctrl),
+	}
+	store.MockScannerStoreInterface.EXPECT().Keys(ctx, gomock.Any()).Return(func(yield func(any, error) bool) {
+		for _, key := range []any{"1", "my-key", "2"} {
+			if !yield(key, nil) {
+				return
+			}
+		}
+	})
+
+	cache, err := NewTyped[userID, string](store, nil)
+	assert.Nil(t, err)
+
+	// When
+	keys := []userID{}
+	errs := []error{}
+	for key, err := range cache.Keys(ctx) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	// Then
+	assert.Equal(t, []userID{1, 2}, keys)
+	assert.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], libstore.ErrInvalidKeyType)
+}
+
+func TestTypedCacheKeysWithNativeKeys(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := newNativeKeysStore(ctrl)
+	store.MockScannerStoreInterface.EXPECT().Keys(ctx, gomock.Any()).Return(func(yield func(any, error) bool) {
+		yield(int64(42), nil)
+	})
+
+	cache, err := NewTyped[int64, string](store, NativeKey[int64])
+	assert.Nil(t, err)
+
+	// When
+	keys := []int64{}
+	for key, err := range cache.Keys(ctx) {
+		assert.Nil(t, err)
+		keys = append(keys, key)
+	}
+
+	// Then
+	assert.Equal(t, []int64{42}, keys)
+}
@@ -2,6 +2,8 @@ package codec
 
 import (
 	"context"
+	"errors"
+	"iter"
 	"sync"
 	"time"
 
@@ -128,6 +130,199 @@ func (c *Codec) Clear(ctx context.Context) error {
 	return err
 }
 
+// GetMany allows to retrieve the values of several key identifiers at once.
+// Keys that are not found are omitted from the returned map and counted as misses.
+func (c *Codec) GetMany(ctx context.Context, keys []any) (map[any]any, error) {
+	values, err := store.GetMany(ctx, c.store, keys)
+
+	c.statsMtx.Lock()
+	defer c.statsMtx.Unlock()
+	if err == nil {
+		c.stats.Hits += len(values)
+		c.stats.Miss += len(keys) - len(values)
+	} else {
+		c.stats.Miss += len(keys)
+	}
+
+	return values, err
+}
+
+// SetMany allows to set several values at once using the same options
+func (c *Codec) SetMany(ctx context.Context, items map[any]any, options ...store.Option) error {
+	err := store.SetMany(ctx, c.store, items, options...)
+
+	c.statsMtx.Lock()
+	defer c.statsMtx.Unlock()
+	if err == nil {
+		c.stats.SetSuccess += len(items)
+	} else {
+		c.stats.SetError += len(items)
+	}
+
+	return err
+}
+
+// DeleteMany allows to remove the values of several key identifiers at once
+func (c *Codec) DeleteMany(ctx context.Context, keys []any) error {
+	err := store.DeleteMany(ctx, c.store, keys)
+
+	c.statsMtx.Lock()
+	defer c.statsMtx.Unlock()
+	if err == nil {
+		c.stats.DeleteSuccess += len(keys)
+	} else {
+		c.stats.DeleteError += len(keys)
+	}
+
+	return err
+}
+
+// Keys allows to iterate over the keys held by the store.
+// A single store.ErrUnsupported error is yielded if the store cannot list its keys.
+func (c *Codec) Keys(ctx context.Context, options ...store.ScanOption) iter.Seq2[any, error] {
+	scanner, ok := c.store.(store.ScannerStoreInterface)
+	if !ok {
+		return func(yield func(any, error) bool) {
+			yield(nil, store.ErrUnsupported)
+		}
+	}
+
+	return scanner.Keys(ctx, options...)
+}
+
+// Increment allows to atomically add the given delta to the counter stored
+// for the given key identifier and returns its new value.
+// store.ErrUnsupported is returned if the store does not support counters.
+func (c *Codec) Increment(ctx context.Context, key any, delta int64, options ...store.Option) (int64, error) {
+	counter, ok := c.store.(store.CounterStoreInterface)
+	if !ok {
+		return 0, store.ErrUnsupported
+	}
+
+	value, err := counter.Increment(ctx, key, delta, options...)
+	c.updateCounterStats(err)
+
+	return value, err
+}
+
+// Decrement allows to atomically subtract the given delta from the counter stored
+// for the given key identifier and returns its new value.
+// store.ErrUnsupported is returned if the store does not support counters.
+func (c *Codec) Decrement(ctx context.Context, key any, delta int64, options ...store.Option) (int64, error) {
+	counter, ok := c.store.(store.CounterStoreInterface)
+	if !ok {
+		return 0, store.ErrUnsupported
+	}
+
+	value, err := counter.Decrement(ctx, key, delta, options...)
+	c.updateCounterStats(err)
+
+	return value, err
+}
+
+func (c *Codec) updateCounterStats(err error) {
+	c.statsMtx.Lock()
+	defer c.statsMtx.Unlock()
+	if err == nil {
+		c.stats.SetSuccess++
+	} else {
+		c.stats.SetError++
+	}
+}
+
+// SetIfNotExists allows to set a value for a given key identifier only if it does not exist yet.
+// A store.ConditionFailed error is returned if the key already exists and
+// store.ErrUnsupported if the store does not support conditional writes.
+func (c *Codec) SetIfNotExists(ctx context.Context, key any, value any, options ...store.Option) error {
+	conditional, ok := c.store.(store.ConditionalStoreInterface)
+	if !ok {
+		return store.ErrUnsupported
+	}
+
+	err := conditional.SetIfNotExists(ctx, key, value, options...)
+	c.updateConditionalStats(err)
+
+	return err
+}
+
+// GetWithVersion allows to retrieve the value from a given key identifier along with
+// its version, to be used with CompareAndSwap
+func (c *Codec) GetWithVersion(ctx context.Context, key any) (any, store.Version, error) {
+	conditional, ok := c.store.(store.ConditionalStoreInterface)
+	if !ok {
+		return nil, store.Version{}, store.ErrUnsupported
+	}
+
+	val, version, err := conditional.GetWithVersion(ctx, key)
+
+	c.statsMtx.Lock()
+	defer c.statsMtx.Unlock()
+	if err == nil {
+		c.stats.Hits++
+	} else {
+		c.stats.Miss++
+	}
+
+	return val, version, err
+}
+
+// CompareAndSwap allows to set a value for a given key identifier only if the stored
+// value still matches the given version.
+// A store.ConditionFailed error is returned if the value has changed in the meantime and
+// store.ErrUnsupported if the store does not support conditional writes.
+func (c *Codec) CompareAndSwap(ctx context.Context, key any, value any, version store.Version, options ...store.Option) error {
+	conditional, ok := c.store.(store.ConditionalStoreInterface)
+	if !ok {
+		return store.ErrUnsupported
+	}
+
+	err := conditional.CompareAndSwap(ctx, key, value, version, options...)
+	c.updateConditionalStats(err)
+
+	return err
+}
+
+// updateConditionalStats records a conditional write, a write whose
+// condition is not met being neither a success nor an error
+func (c *Codec) updateConditionalStats(err error) {
+	c.statsMtx.Lock()
+	defer c.statsMtx.Unlock()
+	if err == nil {
+		c.stats.SetSuccess++
+	} else if !errors.Is(err, store.ConditionFailed{}) {
+		c.stats.SetError++
+	}
+}
+
+// Touch allows to change the expiration of a given key identifier without rewriting its value.
+// A store.NotFound error is returned if the key does not exist and store.ErrUnsupported
+// if the store is not able to change expirations.
+func (c *Codec) Touch(ctx context.Context, key any, ttl time.Duration) error {
+	touchable, ok := c.store.(store.TouchStoreInterface)
+	if !ok {
+		return store.ErrUnsupported
+	}
+
+	return touchable.Touch(ctx, key, ttl)
+}
+
+// SlidingExpiration returns the sliding expiration given to a key identifier when it
+// has been set, 0 if it has none and store.ErrUnsupported if the store is not able
+// to renew expirations on reads.
+func (c *Codec) SlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	sliding, ok := c.store.(store.SlidingStoreInterface)
+	if !ok {
+		return 0, store.ErrUnsupported
+	}
+
+	return sliding.SlidingExpiration(ctx, key)
+}
+
+// Capabilities returns the features supported by the store, see store.CapabilitiesOf
+func (c *Codec) Capabilities() store.Capabilities {
+	return store.CapabilitiesOf(c.store)
+}
+
 // GetStore returns the store associated to this codec
 func (c *Codec) GetStore() store.StoreInterface {
 	return c.store
@@ -447,3 +447,436 @@ func TestGetStats(t *testing.T) {
 	expectedStats := &Stats{}
 	assert.Equal(t, expectedStats, codec.GetStats())
 }
+
+type scannerStore struct {
+	*mockstore.MockStoreInterface
+	*mockstore.MockScannerStoreInterface
+}
+
+type counterStore struct {
+	*mockstore.MockStoreInterface
+	*mockstore.MockCounterStoreInterface
+}
+
+type conditionalStore struct {
+	*mockstore.MockStoreInterface
+	*mockstore.MockConditionalStoreInterface
+}
+
+type capabilitiesStore struct {
+	*mockstore.MockStoreInterface
+	*mockstore.MockCapabilitiesStoreInterface
+}
+
+type touchStore struct {
+	*mockstore.MockStoreInterface
+	*mockstore.MockTouchStoreInterface
+}
+
+type batchStore struct {
+	*mockstore.MockStoreInterface
+	*mockstore.MockBatchStoreInterface
+}
+
+func TestGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := &batchStore{
+		MockStoreInterface:      mockstore.NewMockStoreInterface(ctrl),
+		MockBatchStoreInterface: mockstore.NewMockBatchStoreInterface(ctrl),
+	}
+	store.MockBatchStoreInterface.EXPECT().GetMany(ctx, []any{"key-1", "key-2", "key-3"}).
+		Return(map[any]any{"key-1": "value-1", "key-2": "value-2"}, nil)
+
+	codec := New(store)
+
+	// When
+	values, err := codec.GetMany(ctx, []any{"key-1", "key-2", "key-3"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1", "key-2": "value-2"}, values)
+
+	assert.Equal(t, 2, codec.GetStats().Hits)
+	assert.Equal(t, 1, codec.GetStats().Miss)
+}
+
+func TestGetManyWhenStoreDoesNotSupportBatch(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+	store.EXPECT().Get(ctx, "key-1").Return("value-1", nil)
+	store.EXPECT().Get(ctx, "key-2").Return(nil, libstore.NotFoundWithCause(errors.New("not found")))
+
+	codec := New(store)
+
+	// When
+	values, err := codec.GetMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1"}, values)
+
+	assert.Equal(t, 1, codec.GetStats().Hits)
+	assert.Equal(t, 1, codec.GetStats().Miss)
+}
+
+func TestGetManyWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("unable to reach store")
+
+	store := &batchStore{
+		MockStoreInterface:      mockstore.NewMockStoreInterface(ctrl),
+		MockBatchStoreInterface: mockstore.NewMockBatchStoreInterface(ctrl),
+	}
+	store.MockBatchStoreInterface.EXPECT().GetMany(ctx, []any{"key-1", "key-2"}).Return(nil, expectedErr)
+
+	codec := New(store)
+
+	// When
+	values, err := codec.GetMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Equal(t, expectedErr, err)
+	assert.Nil(t, values)
+
+	assert.Equal(t, 0, codec.GetStats().Hits)
+	assert.Equal(t, 2, codec.GetStats().Miss)
+}
+
+func TestSetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	items := map[any]any{"key-1": "value-1", "key-2": "value-2"}
+
+	store := &batchStore{
+		MockStoreInterface:      mockstore.NewMockStoreInterface(ctrl),
+		MockBatchStoreInterface: mockstore.NewMockBatchStoreInterface(ctrl),
+	}
+	store.MockBatchStoreInterface.EXPECT().SetMany(ctx, items, libstore.OptionsMatcher{
+		Expiration: 5 * time.Second,
+	}).Return(nil)
+
+	codec := New(store)
+
+	// When
+	err := codec.SetMany(ctx, items, libstore.WithExpiration(5*time.Second))
+
+	// Then
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, codec.GetStats().SetSuccess)
+	assert.Equal(t, 0, codec.GetStats().SetError)
+}
+
+func TestDeleteMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := &batchStore{
+		MockStoreInterface:      mockstore.NewMockStoreInterface(ctrl),
+		MockBatchStoreInterface: mockstore.NewMockBatchStoreInterface(ctrl),
+	}
+	store.MockBatchStoreInterface.EXPECT().DeleteMany(ctx, []any{"key-1", "key-2"}).Return(nil)
+
+	codec := New(store)
+
+	// When
+	err := codec.DeleteMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+
+	assert.Equal(t, 2, codec.GetStats().DeleteSuccess)
+	assert.Equal(t, 0, codec.GetStats().DeleteError)
+}
+
+func TestKeys(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := &scannerStore{
+		MockStoreInterface:        mockstore.NewMockStoreInterface(ctrl),
+		MockScannerStoreInterface: mockstore.NewMockScannerStoreInterface(ctrl),
+	}
+	store.MockScannerStoreInterface.EXPECT().Keys(ctx, gomock.Any()).Return(func(yield func(any, error) bool) {
+		for _, key := range []any{"key-1", "key-2"} {
+			if !yield(key, nil) {
+				return
+			}
+		}
+	})
+
+	codec := New(store)
+
+	// When
+	keys := []any{}
+	for key, err := range codec.Keys(ctx, libstore.WithScanMatch("key-*")) {
+		assert.Nil(t, err)
+		keys = append(keys, key)
+	}
+
+	// Then
+	assert.Equal(t, []any{"key-1", "key-2"}, keys)
+}
+
+func TestKeysWhenStoreDoesNotSupportScan(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+
+	codec := New(store)
+
+	// When
+	var errs []error
+	for _, err := range codec.Keys(ctx) {
+		errs = append(errs, err)
+	}
+
+	// Then
+	assert.Equal(t, []error{libstore.ErrUnsupported}, errs)
+}
+
+func TestIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := &counterStore{
+		MockStoreInterface:        mockstore.NewMockStoreInterface(ctrl),
+		MockCounterStoreInterface: mockstore.NewMockCounterStoreInterface(ctrl),
+	}
+	store.MockCounterStoreInterface.EXPECT().Increment(ctx, "my-key", int64(2), gomock.Any()).Return(int64(5), nil)
+
+	codec := New(store)
+
+	// When
+	value, err := codec.Increment(ctx, "my-key", 2, libstore.WithExpiration(5*time.Second))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), value)
+
+	assert.Equal(t, 1, codec.GetStats().SetSuccess)
+	assert.Equal(t, 0, codec.GetStats().SetError)
+}
+
+func TestDecrementWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("unable to decrement counter")
+
+	store := &counterStore{
+		MockStoreInterface:        mockstore.NewMockStoreInterface(ctrl),
+		MockCounterStoreInterface: mockstore.NewMockCounterStoreInterface(ctrl),
+	}
+	store.MockCounterStoreInterface.EXPECT().Decrement(ctx, "my-key", int64(2)).Return(int64(0), expectedErr)
+
+	codec := New(store)
+
+	// When
+	value, err := codec.Decrement(ctx, "my-key", 2)
+
+	// Then
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, int64(0), value)
+
+	assert.Equal(t, 0, codec.GetStats().SetSuccess)
+	assert.Equal(t, 1, codec.GetStats().SetError)
+}
+
+func TestIncrementWhenStoreDoesNotSupportCounters(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+
+	codec := New(store)
+
+	// When
+	value, err := codec.Increment(ctx, "my-key", 1)
+
+	// Then
+	assert.ErrorIs(t, err, libstore.ErrUnsupported)
+	assert.Equal(t, int64(0), value)
+}
+
+func TestSetIfNotExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := &conditionalStore{
+		MockStoreInterface:            mockstore.NewMockStoreInterface(ctrl),
+		MockConditionalStoreInterface: mockstore.NewMockConditionalStoreInterface(ctrl),
+	}
+	store.MockConditionalStoreInterface.EXPECT().SetIfNotExists(ctx, "my-key", "my-value").Return(nil)
+	store.MockConditionalStoreInterface.EXPECT().SetIfNotExists(ctx, "my-other-key", "my-value").Return(libstore.ConditionFailedWithCause(nil))
+
+	codec := New(store)
+
+	// When
+	err := codec.SetIfNotExists(ctx, "my-key", "my-value")
+	otherErr := codec.SetIfNotExists(ctx, "my-other-key", "my-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.ErrorIs(t, otherErr, libstore.ConditionFailed{})
+
+	assert.Equal(t, 1, codec.GetStats().SetSuccess)
+	assert.Equal(t, 0, codec.GetStats().SetError)
+}
+
+func TestGetWithVersion(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := &conditionalStore{
+		MockStoreInterface:            mockstore.NewMockStoreInterface(ctrl),
+		MockConditionalStoreInterface: mockstore.NewMockConditionalStoreInterface(ctrl),
+	}
+	store.MockConditionalStoreInterface.EXPECT().GetWithVersion(ctx, "my-key").Return("my-value", libstore.NewVersion(42), nil)
+
+	codec := New(store)
+
+	// When
+	value, version, err := codec.GetWithVersion(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, libstore.NewVersion(42), version)
+
+	assert.Equal(t, 1, codec.GetStats().Hits)
+}
+
+func TestCompareAndSwapWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("unable to swap value")
+
+	store := &conditionalStore{
+		MockStoreInterface:            mockstore.NewMockStoreInterface(ctrl),
+		MockConditionalStoreInterface: mockstore.NewMockConditionalStoreInterface(ctrl),
+	}
+	store.MockConditionalStoreInterface.EXPECT().CompareAndSwap(ctx, "my-key", "my-value", libstore.NewVersion(42)).Return(expectedErr)
+
+	codec := New(store)
+
+	// When
+	err := codec.CompareAndSwap(ctx, "my-key", "my-value", libstore.NewVersion(42))
+
+	// Then
+	assert.Equal(t, expectedErr, err)
+
+	assert.Equal(t, 0, codec.GetStats().SetSuccess)
+	assert.Equal(t, 1, codec.GetStats().SetError)
+}
+
+func TestCompareAndSwapWhenStoreDoesNotSupportConditionalWrites(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+
+	codec := New(store)
+
+	// When
+	err := codec.CompareAndSwap(ctx, "my-key", "my-value", libstore.NewVersion(42))
+
+	// Then
+	assert.ErrorIs(t, err, libstore.ErrUnsupported)
+}
+
+func TestTouch(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := &touchStore{
+		MockStoreInterface:      mockstore.NewMockStoreInterface(ctrl),
+		MockTouchStoreInterface: mockstore.NewMockTouchStoreInterface(ctrl),
+	}
+	store.MockTouchStoreInterface.EXPECT().Touch(ctx, "my-key", time.Minute).Return(nil)
+
+	codec := New(store)
+
+	// When
+	err := codec.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestTouchWhenStoreDoesNotSupportTouch(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	store := mockstore.NewMockStoreInterface(ctrl)
+
+	codec := New(store)
+
+	// When
+	err := codec.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.ErrorIs(t, err, libstore.ErrUnsupported)
+}
+
+func TestCapabilities(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	expected := libstore.Capabilities{TTLPrecision: time.Millisecond, Tags: true}
+
+	store := &capabilitiesStore{
+		MockStoreInterface:             mockstore.NewMockStoreInterface(ctrl),
+		MockCapabilitiesStoreInterface: mockstore.NewMockCapabilitiesStoreInterface(ctrl),
+	}
+	store.MockCapabilitiesStoreInterface.EXPECT().Capabilities().Return(expected)
+
+	codec := New(store)
+
+	// When
+	capabilities := codec.Capabilities()
+
+	// Then
+	assert.Equal(t, expected, capabilities)
+}
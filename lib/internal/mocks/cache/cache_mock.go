@@ -11,6 +11,7 @@ package cache
 
 import (
 	context "context"
+	iter "iter"
 	reflect "reflect"
 	time "time"
 
@@ -138,6 +139,378 @@ func (mr *MockCacheInterfaceMockRecorder[T]) Set(ctx, key, object any, options .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCacheInterface[T])(nil).Set), varargs...)
 }
 
+// MockBatchCacheInterface is a mock of BatchCacheInterface interface.
+type MockBatchCacheInterface[T any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchCacheInterfaceMockRecorder[T]
+	isgomock struct{}
+}
+
+// MockBatchCacheInterfaceMockRecorder is the mock recorder for MockBatchCacheInterface.
+type MockBatchCacheInterfaceMockRecorder[T any] struct {
+	mock *MockBatchCacheInterface[T]
+}
+
+// NewMockBatchCacheInterface creates a new mock instance.
+func NewMockBatchCacheInterface[T any](ctrl *gomock.Controller) *MockBatchCacheInterface[T] {
+	mock := &MockBatchCacheInterface[T]{ctrl: ctrl}
+	mock.recorder = &MockBatchCacheInterfaceMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchCacheInterface[T]) EXPECT() *MockBatchCacheInterfaceMockRecorder[T] {
+	return m.recorder
+}
+
+// DeleteMany mocks base method.
+func (m *MockBatchCacheInterface[T]) DeleteMany(ctx context.Context, keys []any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMany", ctx, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockBatchCacheInterfaceMockRecorder[T]) DeleteMany(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockBatchCacheInterface[T])(nil).DeleteMany), ctx, keys)
+}
+
+// GetMany mocks base method.
+func (m *MockBatchCacheInterface[T]) GetMany(ctx context.Context, keys []any) (map[any]T, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", ctx, keys)
+	ret0, _ := ret[0].(map[any]T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany.
+func (mr *MockBatchCacheInterfaceMockRecorder[T]) GetMany(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockBatchCacheInterface[T])(nil).GetMany), ctx, keys)
+}
+
+// SetMany mocks base method.
+func (m *MockBatchCacheInterface[T]) SetMany(ctx context.Context, items map[any]T, options ...store.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, items}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetMany", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMany indicates an expected call of SetMany.
+func (mr *MockBatchCacheInterfaceMockRecorder[T]) SetMany(ctx, items any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, items}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMany", reflect.TypeOf((*MockBatchCacheInterface[T])(nil).SetMany), varargs...)
+}
+
+// MockScannerCacheInterface is a mock of ScannerCacheInterface interface.
+type MockScannerCacheInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockScannerCacheInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockScannerCacheInterfaceMockRecorder is the mock recorder for MockScannerCacheInterface.
+type MockScannerCacheInterfaceMockRecorder struct {
+	mock *MockScannerCacheInterface
+}
+
+// NewMockScannerCacheInterface creates a new mock instance.
+func NewMockScannerCacheInterface(ctrl *gomock.Controller) *MockScannerCacheInterface {
+	mock := &MockScannerCacheInterface{ctrl: ctrl}
+	mock.recorder = &MockScannerCacheInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScannerCacheInterface) EXPECT() *MockScannerCacheInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Keys mocks base method.
+func (m *MockScannerCacheInterface) Keys(ctx context.Context, options ...store.ScanOption) iter.Seq2[any, error] {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Keys", varargs...)
+	ret0, _ := ret[0].(iter.Seq2[any, error])
+	return ret0
+}
+
+// Keys indicates an expected call of Keys.
+func (mr *MockScannerCacheInterfaceMockRecorder) Keys(ctx any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keys", reflect.TypeOf((*MockScannerCacheInterface)(nil).Keys), varargs...)
+}
+
+// MockCounterCacheInterface is a mock of CounterCacheInterface interface.
+type MockCounterCacheInterface[T any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockCounterCacheInterfaceMockRecorder[T]
+	isgomock struct{}
+}
+
+// MockCounterCacheInterfaceMockRecorder is the mock recorder for MockCounterCacheInterface.
+type MockCounterCacheInterfaceMockRecorder[T any] struct {
+	mock *MockCounterCacheInterface[T]
+}
+
+// NewMockCounterCacheInterface creates a new mock instance.
+func NewMockCounterCacheInterface[T any](ctrl *gomock.Controller) *MockCounterCacheInterface[T] {
+	mock := &MockCounterCacheInterface[T]{ctrl: ctrl}
+	mock.recorder = &MockCounterCacheInterfaceMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCounterCacheInterface[T]) EXPECT() *MockCounterCacheInterfaceMockRecorder[T] {
+	return m.recorder
+}
+
+// Decrement mocks base method.
+func (m *MockCounterCacheInterface[T]) Decrement(ctx context.Context, key any, delta int64, options ...store.Option) (T, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key, delta}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Decrement", varargs...)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decrement indicates an expected call of Decrement.
+func (mr *MockCounterCacheInterfaceMockRecorder[T]) Decrement(ctx, key, delta any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key, delta}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrement", reflect.TypeOf((*MockCounterCacheInterface[T])(nil).Decrement), varargs...)
+}
+
+// Increment mocks base method.
+func (m *MockCounterCacheInterface[T]) Increment(ctx context.Context, key any, delta int64, options ...store.Option) (T, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key, delta}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Increment", varargs...)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockCounterCacheInterfaceMockRecorder[T]) Increment(ctx, key, delta any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key, delta}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockCounterCacheInterface[T])(nil).Increment), varargs...)
+}
+
+// MockConditionalCacheInterface is a mock of ConditionalCacheInterface interface.
+type MockConditionalCacheInterface[T any] struct {
+	ctrl     *gomock.Controller
+	recorder *MockConditionalCacheInterfaceMockRecorder[T]
+	isgomock struct{}
+}
+
+// MockConditionalCacheInterfaceMockRecorder is the mock recorder for MockConditionalCacheInterface.
+type MockConditionalCacheInterfaceMockRecorder[T any] struct {
+	mock *MockConditionalCacheInterface[T]
+}
+
+// NewMockConditionalCacheInterface creates a new mock instance.
+func NewMockConditionalCacheInterface[T any](ctrl *gomock.Controller) *MockConditionalCacheInterface[T] {
+	mock := &MockConditionalCacheInterface[T]{ctrl: ctrl}
+	mock.recorder = &MockConditionalCacheInterfaceMockRecorder[T]{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConditionalCacheInterface[T]) EXPECT() *MockConditionalCacheInterfaceMockRecorder[T] {
+	return m.recorder
+}
+
+// CompareAndSwap mocks base method.
+func (m *MockConditionalCacheInterface[T]) CompareAndSwap(ctx context.Context, key any, object T, version store.Version, options ...store.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key, object, version}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CompareAndSwap", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompareAndSwap indicates an expected call of CompareAndSwap.
+func (mr *MockConditionalCacheInterfaceMockRecorder[T]) CompareAndSwap(ctx, key, object, version any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key, object, version}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndSwap", reflect.TypeOf((*MockConditionalCacheInterface[T])(nil).CompareAndSwap), varargs...)
+}
+
+// GetWithVersion mocks base method.
+func (m *MockConditionalCacheInterface[T]) GetWithVersion(ctx context.Context, key any) (T, store.Version, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithVersion", ctx, key)
+	ret0, _ := ret[0].(T)
+	ret1, _ := ret[1].(store.Version)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWithVersion indicates an expected call of GetWithVersion.
+func (mr *MockConditionalCacheInterfaceMockRecorder[T]) GetWithVersion(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithVersion", reflect.TypeOf((*MockConditionalCacheInterface[T])(nil).GetWithVersion), ctx, key)
+}
+
+// SetIfNotExists mocks base method.
+func (m *MockConditionalCacheInterface[T]) SetIfNotExists(ctx context.Context, key any, object T, options ...store.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key, object}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetIfNotExists", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetIfNotExists indicates an expected call of SetIfNotExists.
+func (mr *MockConditionalCacheInterfaceMockRecorder[T]) SetIfNotExists(ctx, key, object any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key, object}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIfNotExists", reflect.TypeOf((*MockConditionalCacheInterface[T])(nil).SetIfNotExists), varargs...)
+}
+
+// MockTouchCacheInterface is a mock of TouchCacheInterface interface.
+type MockTouchCacheInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTouchCacheInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTouchCacheInterfaceMockRecorder is the mock recorder for MockTouchCacheInterface.
+type MockTouchCacheInterfaceMockRecorder struct {
+	mock *MockTouchCacheInterface
+}
+
+// NewMockTouchCacheInterface creates a new mock instance.
+func NewMockTouchCacheInterface(ctrl *gomock.Controller) *MockTouchCacheInterface {
+	mock := &MockTouchCacheInterface{ctrl: ctrl}
+	mock.recorder = &MockTouchCacheInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTouchCacheInterface) EXPECT() *MockTouchCacheInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Touch mocks base method.
+func (m *MockTouchCacheInterface) Touch(ctx context.Context, key any, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, key, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockTouchCacheInterfaceMockRecorder) Touch(ctx, key, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockTouchCacheInterface)(nil).Touch), ctx, key, ttl)
+}
+
+// MockSlidingCacheInterface is a mock of SlidingCacheInterface interface.
+type MockSlidingCacheInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSlidingCacheInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSlidingCacheInterfaceMockRecorder is the mock recorder for MockSlidingCacheInterface.
+type MockSlidingCacheInterfaceMockRecorder struct {
+	mock *MockSlidingCacheInterface
+}
+
+// NewMockSlidingCacheInterface creates a new mock instance.
+func NewMockSlidingCacheInterface(ctrl *gomock.Controller) *MockSlidingCacheInterface {
+	mock := &MockSlidingCacheInterface{ctrl: ctrl}
+	mock.recorder = &MockSlidingCacheInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSlidingCacheInterface) EXPECT() *MockSlidingCacheInterfaceMockRecorder {
+	return m.recorder
+}
+
+// SlidingExpiration mocks base method.
+func (m *MockSlidingCacheInterface) SlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SlidingExpiration", ctx, key)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SlidingExpiration indicates an expected call of SlidingExpiration.
+func (mr *MockSlidingCacheInterfaceMockRecorder) SlidingExpiration(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SlidingExpiration", reflect.TypeOf((*MockSlidingCacheInterface)(nil).SlidingExpiration), ctx, key)
+}
+
+// MockCapabilitiesCacheInterface is a mock of CapabilitiesCacheInterface interface.
+type MockCapabilitiesCacheInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCapabilitiesCacheInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCapabilitiesCacheInterfaceMockRecorder is the mock recorder for MockCapabilitiesCacheInterface.
+type MockCapabilitiesCacheInterfaceMockRecorder struct {
+	mock *MockCapabilitiesCacheInterface
+}
+
+// NewMockCapabilitiesCacheInterface creates a new mock instance.
+func NewMockCapabilitiesCacheInterface(ctrl *gomock.Controller) *MockCapabilitiesCacheInterface {
+	mock := &MockCapabilitiesCacheInterface{ctrl: ctrl}
+	mock.recorder = &MockCapabilitiesCacheInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCapabilitiesCacheInterface) EXPECT() *MockCapabilitiesCacheInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Capabilities mocks base method.
+func (m *MockCapabilitiesCacheInterface) Capabilities() store.Capabilities {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capabilities")
+	ret0, _ := ret[0].(store.Capabilities)
+	return ret0
+}
+
+// Capabilities indicates an expected call of Capabilities.
+func (mr *MockCapabilitiesCacheInterfaceMockRecorder) Capabilities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capabilities", reflect.TypeOf((*MockCapabilitiesCacheInterface)(nil).Capabilities))
+}
+
 // MockCacheKeyGenerator is a mock of CacheKeyGenerator interface.
 type MockCacheKeyGenerator struct {
 	ctrl     *gomock.Controller
@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 
 	codec "github.com/eko/gocache/lib/v4/codec"
+	metrics "github.com/eko/gocache/lib/v4/metrics"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -51,3 +52,51 @@ func (mr *MockMetricsInterfaceMockRecorder) RecordFromCodec(arg0 any) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFromCodec", reflect.TypeOf((*MockMetricsInterface)(nil).RecordFromCodec), arg0)
 }
+
+// MockLoaderMetricsInterface is a mock of LoaderMetricsInterface interface.
+type MockLoaderMetricsInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoaderMetricsInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockLoaderMetricsInterfaceMockRecorder is the mock recorder for MockLoaderMetricsInterface.
+type MockLoaderMetricsInterfaceMockRecorder struct {
+	mock *MockLoaderMetricsInterface
+}
+
+// NewMockLoaderMetricsInterface creates a new mock instance.
+func NewMockLoaderMetricsInterface(ctrl *gomock.Controller) *MockLoaderMetricsInterface {
+	mock := &MockLoaderMetricsInterface{ctrl: ctrl}
+	mock.recorder = &MockLoaderMetricsInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoaderMetricsInterface) EXPECT() *MockLoaderMetricsInterfaceMockRecorder {
+	return m.recorder
+}
+
+// RecordFromCodec mocks base method.
+func (m *MockLoaderMetricsInterface) RecordFromCodec(arg0 codec.CodecInterface) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordFromCodec", arg0)
+}
+
+// RecordFromCodec indicates an expected call of RecordFromCodec.
+func (mr *MockLoaderMetricsInterfaceMockRecorder) RecordFromCodec(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFromCodec", reflect.TypeOf((*MockLoaderMetricsInterface)(nil).RecordFromCodec), arg0)
+}
+
+// RecordLoaderStats mocks base method.
+func (m *MockLoaderMetricsInterface) RecordLoaderStats(name string, stats metrics.LoaderStats) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordLoaderStats", name, stats)
+}
+
+// RecordLoaderStats indicates an expected call of RecordLoaderStats.
+func (mr *MockLoaderMetricsInterfaceMockRecorder) RecordLoaderStats(name, stats any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLoaderStats", reflect.TypeOf((*MockLoaderMetricsInterface)(nil).RecordLoaderStats), name, stats)
+}
@@ -11,6 +11,7 @@ package store
 
 import (
 	context "context"
+	iter "iter"
 	reflect "reflect"
 	time "time"
 
@@ -152,3 +153,375 @@ func (mr *MockStoreInterfaceMockRecorder) Set(ctx, key, value any, options ...an
 	varargs := append([]any{ctx, key, value}, options...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockStoreInterface)(nil).Set), varargs...)
 }
+
+// MockBatchStoreInterface is a mock of BatchStoreInterface interface.
+type MockBatchStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockBatchStoreInterfaceMockRecorder is the mock recorder for MockBatchStoreInterface.
+type MockBatchStoreInterfaceMockRecorder struct {
+	mock *MockBatchStoreInterface
+}
+
+// NewMockBatchStoreInterface creates a new mock instance.
+func NewMockBatchStoreInterface(ctrl *gomock.Controller) *MockBatchStoreInterface {
+	mock := &MockBatchStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockBatchStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBatchStoreInterface) EXPECT() *MockBatchStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeleteMany mocks base method.
+func (m *MockBatchStoreInterface) DeleteMany(ctx context.Context, keys []any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMany", ctx, keys)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockBatchStoreInterfaceMockRecorder) DeleteMany(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockBatchStoreInterface)(nil).DeleteMany), ctx, keys)
+}
+
+// GetMany mocks base method.
+func (m *MockBatchStoreInterface) GetMany(ctx context.Context, keys []any) (map[any]any, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMany", ctx, keys)
+	ret0, _ := ret[0].(map[any]any)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMany indicates an expected call of GetMany.
+func (mr *MockBatchStoreInterfaceMockRecorder) GetMany(ctx, keys any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMany", reflect.TypeOf((*MockBatchStoreInterface)(nil).GetMany), ctx, keys)
+}
+
+// SetMany mocks base method.
+func (m *MockBatchStoreInterface) SetMany(ctx context.Context, items map[any]any, options ...store.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, items}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetMany", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMany indicates an expected call of SetMany.
+func (mr *MockBatchStoreInterfaceMockRecorder) SetMany(ctx, items any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, items}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMany", reflect.TypeOf((*MockBatchStoreInterface)(nil).SetMany), varargs...)
+}
+
+// MockScannerStoreInterface is a mock of ScannerStoreInterface interface.
+type MockScannerStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockScannerStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockScannerStoreInterfaceMockRecorder is the mock recorder for MockScannerStoreInterface.
+type MockScannerStoreInterfaceMockRecorder struct {
+	mock *MockScannerStoreInterface
+}
+
+// NewMockScannerStoreInterface creates a new mock instance.
+func NewMockScannerStoreInterface(ctrl *gomock.Controller) *MockScannerStoreInterface {
+	mock := &MockScannerStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockScannerStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScannerStoreInterface) EXPECT() *MockScannerStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Keys mocks base method.
+func (m *MockScannerStoreInterface) Keys(ctx context.Context, options ...store.ScanOption) iter.Seq2[any, error] {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Keys", varargs...)
+	ret0, _ := ret[0].(iter.Seq2[any, error])
+	return ret0
+}
+
+// Keys indicates an expected call of Keys.
+func (mr *MockScannerStoreInterfaceMockRecorder) Keys(ctx any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keys", reflect.TypeOf((*MockScannerStoreInterface)(nil).Keys), varargs...)
+}
+
+// MockCounterStoreInterface is a mock of CounterStoreInterface interface.
+type MockCounterStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCounterStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCounterStoreInterfaceMockRecorder is the mock recorder for MockCounterStoreInterface.
+type MockCounterStoreInterfaceMockRecorder struct {
+	mock *MockCounterStoreInterface
+}
+
+// NewMockCounterStoreInterface creates a new mock instance.
+func NewMockCounterStoreInterface(ctrl *gomock.Controller) *MockCounterStoreInterface {
+	mock := &MockCounterStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockCounterStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCounterStoreInterface) EXPECT() *MockCounterStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Decrement mocks base method.
+func (m *MockCounterStoreInterface) Decrement(ctx context.Context, key any, delta int64, options ...store.Option) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key, delta}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Decrement", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Decrement indicates an expected call of Decrement.
+func (mr *MockCounterStoreInterfaceMockRecorder) Decrement(ctx, key, delta any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key, delta}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decrement", reflect.TypeOf((*MockCounterStoreInterface)(nil).Decrement), varargs...)
+}
+
+// Increment mocks base method.
+func (m *MockCounterStoreInterface) Increment(ctx context.Context, key any, delta int64, options ...store.Option) (int64, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key, delta}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Increment", varargs...)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Increment indicates an expected call of Increment.
+func (mr *MockCounterStoreInterfaceMockRecorder) Increment(ctx, key, delta any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key, delta}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Increment", reflect.TypeOf((*MockCounterStoreInterface)(nil).Increment), varargs...)
+}
+
+// MockConditionalStoreInterface is a mock of ConditionalStoreInterface interface.
+type MockConditionalStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockConditionalStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockConditionalStoreInterfaceMockRecorder is the mock recorder for MockConditionalStoreInterface.
+type MockConditionalStoreInterfaceMockRecorder struct {
+	mock *MockConditionalStoreInterface
+}
+
+// NewMockConditionalStoreInterface creates a new mock instance.
+func NewMockConditionalStoreInterface(ctrl *gomock.Controller) *MockConditionalStoreInterface {
+	mock := &MockConditionalStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockConditionalStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConditionalStoreInterface) EXPECT() *MockConditionalStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CompareAndSwap mocks base method.
+func (m *MockConditionalStoreInterface) CompareAndSwap(ctx context.Context, key, value any, version store.Version, options ...store.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key, value, version}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CompareAndSwap", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompareAndSwap indicates an expected call of CompareAndSwap.
+func (mr *MockConditionalStoreInterfaceMockRecorder) CompareAndSwap(ctx, key, value, version any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key, value, version}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareAndSwap", reflect.TypeOf((*MockConditionalStoreInterface)(nil).CompareAndSwap), varargs...)
+}
+
+// GetWithVersion mocks base method.
+func (m *MockConditionalStoreInterface) GetWithVersion(ctx context.Context, key any) (any, store.Version, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWithVersion", ctx, key)
+	ret0, _ := ret[0].(any)
+	ret1, _ := ret[1].(store.Version)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWithVersion indicates an expected call of GetWithVersion.
+func (mr *MockConditionalStoreInterfaceMockRecorder) GetWithVersion(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithVersion", reflect.TypeOf((*MockConditionalStoreInterface)(nil).GetWithVersion), ctx, key)
+}
+
+// SetIfNotExists mocks base method.
+func (m *MockConditionalStoreInterface) SetIfNotExists(ctx context.Context, key, value any, options ...store.Option) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key, value}
+	for _, a := range options {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetIfNotExists", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetIfNotExists indicates an expected call of SetIfNotExists.
+func (mr *MockConditionalStoreInterfaceMockRecorder) SetIfNotExists(ctx, key, value any, options ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key, value}, options...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIfNotExists", reflect.TypeOf((*MockConditionalStoreInterface)(nil).SetIfNotExists), varargs...)
+}
+
+// MockTouchStoreInterface is a mock of TouchStoreInterface interface.
+type MockTouchStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTouchStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTouchStoreInterfaceMockRecorder is the mock recorder for MockTouchStoreInterface.
+type MockTouchStoreInterfaceMockRecorder struct {
+	mock *MockTouchStoreInterface
+}
+
+// NewMockTouchStoreInterface creates a new mock instance.
+func NewMockTouchStoreInterface(ctrl *gomock.Controller) *MockTouchStoreInterface {
+	mock := &MockTouchStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockTouchStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTouchStoreInterface) EXPECT() *MockTouchStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Touch mocks base method.
+func (m *MockTouchStoreInterface) Touch(ctx context.Context, key any, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, key, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockTouchStoreInterfaceMockRecorder) Touch(ctx, key, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockTouchStoreInterface)(nil).Touch), ctx, key, ttl)
+}
+
+// MockSlidingStoreInterface is a mock of SlidingStoreInterface interface.
+type MockSlidingStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSlidingStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSlidingStoreInterfaceMockRecorder is the mock recorder for MockSlidingStoreInterface.
+type MockSlidingStoreInterfaceMockRecorder struct {
+	mock *MockSlidingStoreInterface
+}
+
+// NewMockSlidingStoreInterface creates a new mock instance.
+func NewMockSlidingStoreInterface(ctrl *gomock.Controller) *MockSlidingStoreInterface {
+	mock := &MockSlidingStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockSlidingStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSlidingStoreInterface) EXPECT() *MockSlidingStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// SlidingExpiration mocks base method.
+func (m *MockSlidingStoreInterface) SlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SlidingExpiration", ctx, key)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SlidingExpiration indicates an expected call of SlidingExpiration.
+func (mr *MockSlidingStoreInterfaceMockRecorder) SlidingExpiration(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SlidingExpiration", reflect.TypeOf((*MockSlidingStoreInterface)(nil).SlidingExpiration), ctx, key)
+}
+
+// MockCapabilitiesStoreInterface is a mock of CapabilitiesStoreInterface interface.
+type MockCapabilitiesStoreInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCapabilitiesStoreInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCapabilitiesStoreInterfaceMockRecorder is the mock recorder for MockCapabilitiesStoreInterface.
+type MockCapabilitiesStoreInterfaceMockRecorder struct {
+	mock *MockCapabilitiesStoreInterface
+}
+
+// NewMockCapabilitiesStoreInterface creates a new mock instance.
+func NewMockCapabilitiesStoreInterface(ctrl *gomock.Controller) *MockCapabilitiesStoreInterface {
+	mock := &MockCapabilitiesStoreInterface{ctrl: ctrl}
+	mock.recorder = &MockCapabilitiesStoreInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCapabilitiesStoreInterface) EXPECT() *MockCapabilitiesStoreInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Capabilities mocks base method.
+func (m *MockCapabilitiesStoreInterface) Capabilities() store.Capabilities {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capabilities")
+	ret0, _ := ret[0].(store.Capabilities)
+	return ret0
+}
+
+// Capabilities indicates an expected call of Capabilities.
+func (mr *MockCapabilitiesStoreInterfaceMockRecorder) Capabilities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capabilities", reflect.TypeOf((*MockCapabilitiesStoreInterface)(nil).Capabilities))
+}
@@ -12,6 +12,7 @@ import (
 
 // ErrUnsupportedValueType is returned by Get when the cached value cannot be
 // unmarshaled because the store returned a type the marshaler does not handle.
+// As other unmarshaling errors, it is wrapped into a store.ErrInvalidValue error.
 var ErrUnsupportedValueType = errors.New("unsupported cached value type")
 
 // Marshaler is the struct that marshal and unmarshal cache values
@@ -39,11 +40,12 @@ func (c *Marshaler) Get(ctx context.Context, key any, returnObj any) (any, error
 	case string:
 		err = msgpack.Unmarshal([]byte(v), returnObj)
 	default:
-		return nil, fmt.Errorf("%w: got %T, expected []byte or string", ErrUnsupportedValueType, result)
+		return nil, store.InvalidValueWithCause(
+			fmt.Errorf("%w: got %T, expected []byte or string", ErrUnsupportedValueType, result))
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, store.InvalidValueWithCause(err)
 	}
 
 	return returnObj, nil
@@ -53,7 +55,7 @@ func (c *Marshaler) Get(ctx context.Context, key any, returnObj any) (any, error
 func (c *Marshaler) Set(ctx context.Context, key, object any, options ...store.Option) error {
 	bytes, err := msgpack.Marshal(object)
 	if err != nil {
-		return err
+		return store.InvalidValueWithCause(err)
 	}
 
 	return c.cache.Set(ctx, key, bytes, options...)
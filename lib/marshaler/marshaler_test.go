@@ -102,7 +102,7 @@ func TestGetWhenUnmarshalingError(t *testing.T) {
 	value, err := marshaler.Get(ctx, "my-key", new(testCacheValue))
 
 	// Then
-	assert.NotNil(t, err)
+	assert.ErrorIs(t, err, store.ErrInvalidValue)
 	assert.Nil(t, value)
 }
 
@@ -6,3 +6,22 @@ import "github.com/eko/gocache/lib/v4/codec"
 type MetricsInterface interface {
 	RecordFromCodec(codec codec.CodecInterface)
 }
+
+// LoaderStats represents the statistics of the loads made by a loadable cache
+type LoaderStats struct {
+	Loads            int
+	LoadErrors       int
+	LoadsBackedOff   int
+	Refreshes        int
+	RefreshErrors    int
+	RefreshesSkipped int
+	SetQueueDepth    int
+	SetsDropped      int
+}
+
+// LoaderMetricsInterface represents the providers also able to record the statistics
+// of loadable caches
+type LoaderMetricsInterface interface {
+	MetricsInterface
+	RecordLoaderStats(name string, stats LoaderStats)
+}
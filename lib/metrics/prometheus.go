@@ -113,6 +113,21 @@ func (m *Prometheus) recorder() {
 	}
 }
 
+// RecordLoaderStats records the statistics of a loadable cache in prometheus, under
+// the given name as store label
+func (m *Prometheus) RecordLoaderStats(name string, stats LoaderStats) {
+	m.record(name, "load_count", float64(stats.Loads))
+	m.record(name, "load_error", float64(stats.LoadErrors))
+	m.record(name, "load_backed_off", float64(stats.LoadsBackedOff))
+
+	m.record(name, "refresh_count", float64(stats.Refreshes))
+	m.record(name, "refresh_error", float64(stats.RefreshErrors))
+	m.record(name, "refresh_skipped", float64(stats.RefreshesSkipped))
+
+	m.record(name, "set_queue_depth", float64(stats.SetQueueDepth))
+	m.record(name, "set_dropped", float64(stats.SetsDropped))
+}
+
 // RecordFromCodec sends the given codec into the codec channel to be read from recorder
 func (m *Prometheus) RecordFromCodec(codec codec.CodecInterface) {
 	m.codecChannel <- codec
@@ -194,3 +194,76 @@ func TestRecordFromCodec(t *testing.T) {
 		assert.Equal(t, tc.expected, v)
 	}
 }
+
+func TestRecordLoaderStats(t *testing.T) {
+	// Given
+	stats := LoaderStats{
+		Loads:            10,
+		LoadErrors:       4,
+		LoadsBackedOff:   7,
+		Refreshes:        5,
+		RefreshErrors:    2,
+		RefreshesSkipped: 1,
+		SetQueueDepth:    12,
+		SetsDropped:      3,
+	}
+
+	customRegistry := prometheus.NewRegistry()
+
+	metrics := NewPrometheus(
+		"my-test-service-name",
+		WithRegisterer(customRegistry),
+	)
+
+	// When
+	metrics.RecordLoaderStats("books-loader", stats)
+
+	// Then
+	testCases := []struct {
+		metricName string
+		expected   float64
+	}{
+		{
+			metricName: "load_count",
+			expected:   float64(stats.Loads),
+		},
+		{
+			metricName: "load_error",
+			expected:   float64(stats.LoadErrors),
+		},
+		{
+			metricName: "load_backed_off",
+			expected:   float64(stats.LoadsBackedOff),
+		},
+		{
+			metricName: "refresh_count",
+			expected:   float64(stats.Refreshes),
+		},
+		{
+			metricName: "refresh_error",
+			expected:   float64(stats.RefreshErrors),
+		},
+		{
+			metricName: "refresh_skipped",
+			expected:   float64(stats.RefreshesSkipped),
+		},
+		{
+			metricName: "set_queue_depth",
+			expected:   float64(stats.SetQueueDepth),
+		},
+		{
+			metricName: "set_dropped",
+			expected:   float64(stats.SetsDropped),
+		},
+	}
+
+	for _, tc := range testCases {
+		metric, err := metrics.collector.GetMetricWithLabelValues("my-test-service-name", "books-loader", tc.metricName)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		v := testutil.ToFloat64(metric)
+
+		assert.Equal(t, tc.expected, v)
+	}
+}
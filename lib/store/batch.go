@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"errors"
+)
+
+// GetMany returns the values stored for the given keys.
+// Keys that are not found in the store are omitted from the returned map.
+// It uses the store batch implementation when available and falls back
+// to one Get call per key otherwise.
+func GetMany(ctx context.Context, store StoreInterface, keys []any) (map[any]any, error) {
+	if batchStore, ok := store.(BatchStoreInterface); ok {
+		return batchStore.GetMany(ctx, keys)
+	}
+
+	return getEach(ctx, store.Get, keys)
+}
+
+// getEach returns the values of the given keys using one call of the given function per key
+func getEach(ctx context.Context, get GetFunc, keys []any) (map[any]any, error) {
+	values := make(map[any]any, len(keys))
+	for _, key := range keys {
+		value, err := get(ctx, key)
+		if errors.Is(err, NotFound{}) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+
+	return values, nil
+}
+
+// SetMany stores all the given items using the same options.
+// It uses the store batch implementation when available and falls back
+// to one Set call per item otherwise.
+func SetMany(ctx context.Context, store StoreInterface, items map[any]any, options ...Option) error {
+	if batchStore, ok := store.(BatchStoreInterface); ok {
+		return batchStore.SetMany(ctx, items, options...)
+	}
+
+	return setEach(ctx, store.Set, items, options...)
+}
+
+// setEach stores the given items using one call of the given function per item
+func setEach(ctx context.Context, set SetFunc, items map[any]any, options ...Option) error {
+	errs := []error{}
+	for key, value := range items {
+		if err := set(ctx, key, value, options...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// DeleteMany removes all the given keys from the store.
+// It uses the store batch implementation when available and falls back
+// to one Delete call per key otherwise.
+func DeleteMany(ctx context.Context, store StoreInterface, keys []any) error {
+	if batchStore, ok := store.(BatchStoreInterface); ok {
+		return batchStore.DeleteMany(ctx, keys)
+	}
+
+	return deleteEach(ctx, store.Delete, keys)
+}
+
+// deleteEach removes the given keys using one call of the given function per key
+func deleteEach(ctx context.Context, del DeleteFunc, keys []any) error {
+	errs := []error{}
+	for _, key := range keys {
+		if err := del(ctx, key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
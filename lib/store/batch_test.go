@@ -0,0 +1,110 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryStore is a minimal map based store used to test the batch fallbacks
+type memoryStore struct {
+	values    map[any]any
+	deleteErr error
+}
+
+func (s *memoryStore) Get(_ context.Context, key any) (any, error) {
+	if value, ok := s.values[key]; ok {
+		return value, nil
+	}
+	return nil, NotFoundWithCause(errors.New("value not found in memory store"))
+}
+
+func (s *memoryStore) GetWithTTL(ctx context.Context, key any) (any, time.Duration, error) {
+	value, err := s.Get(ctx, key)
+	return value, 0, err
+}
+
+func (s *memoryStore) Set(_ context.Context, key any, value any, _ ...Option) error {
+	s.values[key] = value
+	return nil
+}
+
+func (s *memoryStore) Delete(_ context.Context, key any) error {
+	if s.deleteErr != nil {
+		return s.deleteErr
+	}
+	delete(s.values, key)
+	return nil
+}
+
+func (s *memoryStore) Invalidate(_ context.Context, _ ...InvalidateOption) error {
+	return nil
+}
+
+func (s *memoryStore) Clear(_ context.Context) error {
+	s.values = map[any]any{}
+	return nil
+}
+
+func (s *memoryStore) GetType() string {
+	return "memory"
+}
+
+func TestGetManyFallback(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	store := &memoryStore{values: map[any]any{"key-1": "value-1", "key-2": "value-2"}}
+
+	// When
+	values, err := GetMany(ctx, store, []any{"key-1", "key-2", "key-3"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1", "key-2": "value-2"}, values)
+}
+
+func TestSetManyFallback(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	store := &memoryStore{values: map[any]any{}}
+
+	// When
+	err := SetMany(ctx, store, map[any]any{"key-1": "value-1", "key-2": "value-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1", "key-2": "value-2"}, store.values)
+}
+
+func TestDeleteManyFallback(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	store := &memoryStore{values: map[any]any{"key-1": "value-1", "key-2": "value-2"}}
+
+	// When
+	err := DeleteMany(ctx, store, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Empty(t, store.values)
+}
+
+func TestDeleteManyFallbackWhenError(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	expectedErr := errors.New("unable to delete")
+	store := &memoryStore{values: map[any]any{"key-1": "value-1"}, deleteErr: expectedErr}
+
+	// When
+	err := DeleteMany(ctx, store, []any{"key-1"})
+
+	// Then
+	assert.ErrorIs(t, err, expectedErr)
+}
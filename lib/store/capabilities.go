@@ -0,0 +1,143 @@
+package store
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+)
+
+// Capabilities describes the features supported by a store, or by a cache
+// as the aggregate of the stores it relies on
+type Capabilities struct {
+	// TTLPrecision is the smallest expiration unit honoured, 0 if expirations are not supported
+	TTLPrecision time.Duration
+	// Tags reports whether values can be tagged and invalidated using their tags
+	Tags bool
+	// Batch reports whether several keys are handled in a single operation
+	Batch bool
+	// Scan reports whether the keys can be iterated over
+	Scan bool
+	// AtomicCounters reports whether counters can be atomically incremented and decremented
+	AtomicCounters bool
+	// ConditionalWrites reports whether values can be atomically written only when a condition is met
+	ConditionalWrites bool
+	// MaxValueSize is the maximum size of a value in bytes, 0 if unlimited or unknown
+	MaxValueSize int
+	// Cost reports whether the WithCost option is honoured
+	Cost bool
+	// SynchronousSet reports whether the WithSynchronousSet option is honoured
+	SynchronousSet bool
+	// ClientSideCaching reports whether the WithClientSideCaching option is honoured
+	ClientSideCaching bool
+	// SlidingExpiration reports whether the WithSlidingExpiration option is honoured
+	SlidingExpiration bool
+	// KeyTypes lists the types of the keys accepted by the store, nil meaning strings only
+	KeyTypes []reflect.Type
+}
+
+// CapabilitiesOf returns the capabilities of the given store. Stores which do not
+// implement CapabilitiesStoreInterface are assumed to support expirations with a
+// one second precision and tags, other capabilities being deduced from the
+// optional interfaces they implement.
+func CapabilitiesOf(store StoreInterface) Capabilities {
+	if describer, ok := store.(CapabilitiesStoreInterface); ok {
+		return describer.Capabilities()
+	}
+
+	_, batch := store.(BatchStoreInterface)
+	_, scan := store.(ScannerStoreInterface)
+	_, counters := store.(CounterStoreInterface)
+	_, conditional := store.(ConditionalStoreInterface)
+
+	return Capabilities{
+		TTLPrecision:      time.Second,
+		Tags:              true,
+		Batch:             batch,
+		Scan:              scan,
+		AtomicCounters:    counters,
+		ConditionalWrites: conditional,
+	}
+}
+
+// Intersect returns the capabilities supported by both c and other, as when
+// values are written to both stores
+func (c Capabilities) Intersect(other Capabilities) Capabilities {
+	result := Capabilities{
+		TTLPrecision:      max(c.TTLPrecision, other.TTLPrecision),
+		Tags:              c.Tags && other.Tags,
+		Batch:             c.Batch && other.Batch,
+		Scan:              c.Scan && other.Scan,
+		AtomicCounters:    c.AtomicCounters && other.AtomicCounters,
+		ConditionalWrites: c.ConditionalWrites && other.ConditionalWrites,
+		MaxValueSize:      max(c.MaxValueSize, other.MaxValueSize),
+		Cost:              c.Cost && other.Cost,
+		SynchronousSet:    c.SynchronousSet && other.SynchronousSet,
+		ClientSideCaching: c.ClientSideCaching && other.ClientSideCaching,
+		SlidingExpiration: c.SlidingExpiration && other.SlidingExpiration,
+	}
+
+	if c.TTLPrecision == 0 || other.TTLPrecision == 0 {
+		result.TTLPrecision = 0
+	}
+	if c.MaxValueSize > 0 && other.MaxValueSize > 0 {
+		result.MaxValueSize = min(c.MaxValueSize, other.MaxValueSize)
+	}
+	if c.KeyTypes != nil || other.KeyTypes != nil {
+		result.KeyTypes = []reflect.Type{}
+		for _, keyType := range c.keyTypes() {
+			if other.AcceptsKeyType(keyType) {
+				result.KeyTypes = append(result.KeyTypes, keyType)
+			}
+		}
+	}
+
+	return result
+}
+
+// AcceptsKeyType reports whether keys of the given type are accepted by the store
+func (c Capabilities) AcceptsKeyType(keyType reflect.Type) bool {
+	return slices.Contains(c.keyTypes(), keyType)
+}
+
+func (c Capabilities) keyTypes() []reflect.Type {
+	if c.KeyTypes == nil {
+		return []reflect.Type{reflect.TypeFor[string]()}
+	}
+
+	return c.KeyTypes
+}
+
+// CheckOptions returns an error wrapping ErrUnsupported when strict mode is enabled
+// and the given options cannot be honoured, for instance an expiration which is
+// not a multiple of the TTL precision
+func (c Capabilities) CheckOptions(opts *Options) error {
+	if !opts.Strict {
+		return nil
+	}
+
+	switch {
+	case opts.Expiration > 0 && (c.TTLPrecision == 0 || opts.Expiration%c.TTLPrecision != 0):
+		return unsupportedOption("WithExpiration")
+	case !opts.ExpireAt.IsZero() && c.TTLPrecision == 0:
+		return unsupportedOption("WithExpireAt")
+	case len(opts.Tags) > 0 && !c.Tags:
+		return unsupportedOption("WithTags")
+	case opts.TagsTTL > 0 && !c.Tags:
+		return unsupportedOption("WithTagsTTL")
+	case opts.Cost != 0 && !c.Cost:
+		return unsupportedOption("WithCost")
+	case opts.SynchronousSet && !c.SynchronousSet:
+		return unsupportedOption("WithSynchronousSet")
+	case opts.ClientSideCacheExpiration > 0 && !c.ClientSideCaching:
+		return unsupportedOption("WithClientSideCaching")
+	case opts.SlidingExpiration > 0 && !c.SlidingExpiration:
+		return unsupportedOption("WithSlidingExpiration")
+	}
+
+	return nil
+}
+
+func unsupportedOption(option string) error {
+	return fmt.Errorf("%w: %s option cannot be honoured", ErrUnsupported, option)
+}
@@ -0,0 +1,136 @@
+package store
+
+import (
+	"context"
+	"iter"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// scannerStore is a store implementing the ScannerStoreInterface only
+type scannerStore struct {
+	StoreInterface
+}
+
+func (s *scannerStore) Keys(_ context.Context, _ ...ScanOption) iter.Seq2[any, error] {
+	return func(yield func(any, error) bool) {}
+}
+
+func TestCapabilitiesOfWhenNotDescribed(t *testing.T) {
+	// When
+	capabilities := CapabilitiesOf(&scannerStore{})
+
+	// Then
+	assert.Equal(t, Capabilities{
+		TTLPrecision: time.Second,
+		Tags:         true,
+		Scan:         true,
+	}, capabilities)
+}
+
+func TestCapabilitiesIntersect(t *testing.T) {
+	// Given
+	memory := Capabilities{
+		TTLPrecision:   time.Millisecond,
+		Tags:           true,
+		Scan:           true,
+		AtomicCounters: true,
+		Cost:           true,
+	}
+	remote := Capabilities{
+		TTLPrecision:      time.Second,
+		Tags:              true,
+		Batch:             true,
+		AtomicCounters:    true,
+		ConditionalWrites: true,
+		MaxValueSize:      1024,
+	}
+
+	// When
+	capabilities := memory.Intersect(remote)
+
+	// Then
+	assert.Equal(t, Capabilities{
+		TTLPrecision:   time.Second,
+		Tags:           true,
+		AtomicCounters: true,
+		MaxValueSize:   1024,
+	}, capabilities)
+	assert.Equal(t, time.Duration(0), capabilities.Intersect(Capabilities{}).TTLPrecision)
+}
+
+func TestCapabilitiesIntersectKeyTypes(t *testing.T) {
+	// Given
+	stringType, int64Type := reflect.TypeFor[string](), reflect.TypeFor[int64]()
+
+	freecache := Capabilities{KeyTypes: []reflect.Type{stringType, int64Type}}
+	ristretto := Capabilities{KeyTypes: []reflect.Type{int64Type}}
+	redis := Capabilities{}
+
+	// When - Then
+	assert.Equal(t, []reflect.Type{stringType}, freecache.Intersect(redis).KeyTypes)
+	assert.Equal(t, []reflect.Type{int64Type}, freecache.Intersect(ristretto).KeyTypes)
+	assert.Equal(t, []reflect.Type{}, ristretto.Intersect(redis).KeyTypes)
+	assert.Nil(t, redis.Intersect(redis).KeyTypes)
+}
+
+func TestCapabilitiesAcceptsKeyType(t *testing.T) {
+	// Given
+	stringType, int64Type := reflect.TypeFor[string](), reflect.TypeFor[int64]()
+
+	// When - Then
+	assert.True(t, Capabilities{}.AcceptsKeyType(stringType))
+	assert.False(t, Capabilities{}.AcceptsKeyType(int64Type))
+	assert.True(t, Capabilities{KeyTypes: []reflect.Type{int64Type}}.AcceptsKeyType(int64Type))
+	assert.False(t, Capabilities{KeyTypes: []reflect.Type{int64Type}}.AcceptsKeyType(stringType))
+	assert.False(t, Capabilities{KeyTypes: []reflect.Type{}}.AcceptsKeyType(stringType))
+}
+
+func TestCapabilitiesCheckOptions(t *testing.T) {
+	capabilities := Capabilities{
+		TTLPrecision: time.Second,
+		Tags:         true,
+	}
+
+	testCases := []struct {
+		name    string
+		options []Option
+		err     string
+	}{
+		{name: "not strict", options: []Option{WithCost(1)}},
+		{name: "supported", options: []Option{WithStrictOptions(), WithExpiration(time.Minute), WithTags([]string{"tag"})}},
+		{name: "cost", options: []Option{WithStrictOptions(), WithCost(1)}, err: "WithCost"},
+		{name: "synchronous set", options: []Option{WithStrictOptions(), WithSynchronousSet()}, err: "WithSynchronousSet"},
+		{name: "client side caching", options: []Option{WithStrictOptions(), WithClientSideCaching(time.Second)}, err: "WithClientSideCaching"},
+		{name: "sliding expiration", options: []Option{WithStrictOptions(), WithSlidingExpiration(time.Minute)}, err: "WithSlidingExpiration"},
+		{name: "imprecise expiration", options: []Option{WithStrictOptions(), WithExpiration(1500 * time.Millisecond)}, err: "WithExpiration"},
+		{name: "deadline", options: []Option{WithStrictOptions(), WithExpireAt(time.Now().Add(1500 * time.Millisecond))}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// When
+			err := capabilities.CheckOptions(ApplyOptions(tc.options...))
+
+			// Then
+			if tc.err == "" {
+				assert.Nil(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrUnsupported)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestCapabilitiesCheckOptionsWhenExpirationsAreNotSupported(t *testing.T) {
+	// When
+	err := Capabilities{}.CheckOptions(ApplyOptions(WithStrictOptions(), WithExpireAt(time.Now().Add(time.Hour))))
+
+	// Then
+	assert.ErrorIs(t, err, ErrUnsupported)
+	assert.ErrorContains(t, err, "WithExpireAt")
+}
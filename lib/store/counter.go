@@ -0,0 +1,49 @@
+package store
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// CounterValue converts a value read from a store to the int64 value of a counter.
+// It is meant to be used by stores that do not provide native atomic counters
+// and accepts integers as well as their decimal representation as string or []byte.
+func CounterValue(value any) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case string:
+		return parseCounter(v)
+	case []byte:
+		return parseCounter(string(v))
+	}
+
+	return 0, fmt.Errorf("%w: value of type %T is not an integer", ErrInvalidValue, value)
+}
+
+func parseCounter(value string) (int64, error) {
+	counter, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, InvalidValueWithCause(fmt.Errorf("value %q is not an integer: %w", value, err))
+	}
+
+	return counter, nil
+}
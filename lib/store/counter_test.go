@@ -0,0 +1,41 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCounterValue(t *testing.T) {
+	testCases := []struct {
+		value    any
+		expected int64
+	}{
+		{value: 42, expected: 42},
+		{value: int32(-3), expected: -3},
+		{value: int64(10), expected: 10},
+		{value: uint8(7), expected: 7},
+		{value: "-15", expected: -15},
+		{value: []byte("1234"), expected: 1234},
+	}
+
+	for _, tc := range testCases {
+		// When
+		counter, err := CounterValue(tc.value)
+
+		// Then
+		assert.Nil(t, err)
+		assert.Equal(t, tc.expected, counter)
+	}
+}
+
+func TestCounterValueWhenNotAnInteger(t *testing.T) {
+	for _, value := range []any{"my-value", []byte("1.5"), 1.5, struct{}{}} {
+		// When
+		counter, err := CounterValue(value)
+
+		// Then
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), counter)
+	}
+}
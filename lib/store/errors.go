@@ -1,5 +1,14 @@
 package store
 
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"syscall"
+)
+
 const NOT_FOUND_ERR string = "value not found in store"
 
 type NotFound struct {
@@ -25,3 +34,138 @@ func (e NotFound) Error() string {
 	return NOT_FOUND_ERR
 }
 func (e NotFound) Unwrap() error { return e.cause }
+
+const CONDITION_FAILED_ERR string = "store condition failed"
+
+// ConditionFailed is returned by conditional writes when their condition is not met,
+// for instance when the key already exists or when its value has changed.
+type ConditionFailed struct {
+	cause error
+}
+
+func ConditionFailedWithCause(e error) error {
+	err := ConditionFailed{
+		cause: e,
+	}
+	return &err
+}
+
+func (e ConditionFailed) Cause() error {
+	return e.cause
+}
+
+func (e ConditionFailed) Is(err error) bool {
+	return err.Error() == CONDITION_FAILED_ERR
+}
+
+func (e ConditionFailed) Error() string {
+	return CONDITION_FAILED_ERR
+}
+func (e ConditionFailed) Unwrap() error { return e.cause }
+
+var (
+	// ErrUnsupported is returned when an operation is not supported by the store
+	ErrUnsupported = errors.New("operation not supported by store")
+	// ErrUnavailable is returned when the store backend cannot be reached
+	ErrUnavailable = errors.New("store unavailable")
+	// ErrTimeout is returned when the store backend did not answer in time
+	ErrTimeout = errors.New("store operation timed out")
+	// ErrInvalidValue is returned when a value cannot be stored or read back,
+	// because of its type, its size or its serialization
+	ErrInvalidValue = errors.New("invalid value for store")
+	// ErrInvalidKeyType is returned when the type of a key is not supported by the store
+	ErrInvalidKeyType = errors.New("key type not supported by store")
+)
+
+// Error is an error of one of the store error kinds (ErrUnavailable, ErrTimeout,
+// ErrInvalidValue or ErrInvalidKeyType) wrapping the error returned by the store client.
+// Both the kind and the cause can be checked using errors.Is.
+type Error struct {
+	kind  error
+	cause error
+}
+
+func newError(kind error, cause error) error {
+	return &Error{
+		kind:  kind,
+		cause: cause,
+	}
+}
+
+// UnavailableWithCause returns an ErrUnavailable error wrapping the given cause
+func UnavailableWithCause(e error) error {
+	return newError(ErrUnavailable, e)
+}
+
+// TimeoutWithCause returns an ErrTimeout error wrapping the given cause
+func TimeoutWithCause(e error) error {
+	return newError(ErrTimeout, e)
+}
+
+// InvalidValueWithCause returns an ErrInvalidValue error wrapping the given cause
+func InvalidValueWithCause(e error) error {
+	return newError(ErrInvalidValue, e)
+}
+
+// InvalidKeyTypeWithCause returns an ErrInvalidKeyType error wrapping the given cause
+func InvalidKeyTypeWithCause(e error) error {
+	return newError(ErrInvalidKeyType, e)
+}
+
+func (e *Error) Kind() error {
+	return e.kind
+}
+
+func (e *Error) Cause() error {
+	return e.cause
+}
+
+func (e *Error) Error() string {
+	if e.cause == nil {
+		return e.kind.Error()
+	}
+	return e.kind.Error() + ": " + e.cause.Error()
+}
+
+func (e *Error) Unwrap() []error {
+	return []error{e.kind, e.cause}
+}
+
+// ClassifyError maps the network and deadline errors returned by store clients onto
+// ErrTimeout and ErrUnavailable. Errors which are already classified and other
+// errors are returned as is. Stores first map the errors specific to their client.
+func ClassifyError(err error) error {
+	if err == nil || isClassified(err) {
+		return err
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded),
+		errors.As(err, &netErr) && netErr.Timeout():
+		return TimeoutWithCause(err)
+
+	case errors.As(err, new(*net.OpError)), errors.As(err, new(*net.DNSError)),
+		errors.Is(err, net.ErrClosed), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET), errors.Is(err, syscall.EPIPE):
+		return UnavailableWithCause(err)
+	}
+
+	return err
+}
+
+func isClassified(err error) bool {
+	for _, kind := range []error{ErrUnsupported, ErrUnavailable, ErrTimeout, ErrInvalidValue, ErrInvalidKeyType} {
+		if errors.Is(err, kind) {
+			return true
+		}
+	}
+
+	return errors.Is(err, NotFound{}) || errors.Is(err, ConditionFailed{})
+}
+
+// IsTransient reports whether the error is due to the store backend being unavailable
+// or too slow, in which case the operation may succeed later or using another store
+func IsTransient(err error) bool {
+	return errors.Is(err, ErrUnavailable) || errors.Is(err, ErrTimeout)
+}
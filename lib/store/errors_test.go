@@ -1,7 +1,13 @@
 package store
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,3 +26,63 @@ func TestNotFoundIs(t *testing.T) {
 
 	assert.True(t, err.Error() == NotFound{}.Error())
 }
+
+func TestConditionFailedIs(t *testing.T) {
+	expectedErr := errors.New("this is an expected error cause")
+	err := ConditionFailedWithCause(expectedErr)
+	assert.True(t, errors.Is(err, ConditionFailed{}))
+	assert.True(t, errors.Is(err, expectedErr))
+	assert.False(t, errors.Is(err, NotFound{}))
+
+	_, ok := err.(*ConditionFailed)
+	assert.True(t, ok)
+
+	assert.True(t, err.Error() == ConditionFailed{}.Error())
+}
+
+func TestErrorIs(t *testing.T) {
+	cause := errors.New("this is an expected error cause")
+	err := UnavailableWithCause(cause)
+	assert.True(t, errors.Is(err, ErrUnavailable))
+	assert.True(t, errors.Is(err, cause))
+	assert.False(t, errors.Is(err, ErrTimeout))
+	assert.False(t, errors.Is(err, NotFound{}))
+
+	storeErr, ok := err.(*Error)
+	assert.True(t, ok)
+	assert.Equal(t, ErrUnavailable, storeErr.Kind())
+	assert.Equal(t, cause, storeErr.Cause())
+
+	assert.Equal(t, "store unavailable: this is an expected error cause", err.Error())
+}
+
+func TestClassifyError(t *testing.T) {
+	cause := errors.New("this is an expected error cause")
+	notFound := NotFoundWithCause(cause)
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{name: "deadline exceeded", err: context.DeadlineExceeded, expected: ErrTimeout},
+		{name: "network timeout", err: &net.OpError{Op: "read", Err: os.ErrDeadlineExceeded}, expected: ErrTimeout},
+		{name: "connection refused", err: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, expected: ErrUnavailable},
+		{name: "connection closed", err: fmt.Errorf("read: %w", io.EOF), expected: ErrUnavailable},
+		{name: "already classified", err: InvalidValueWithCause(cause), expected: ErrInvalidValue},
+		{name: "not found", err: notFound, expected: notFound},
+		{name: "other error", err: cause, expected: cause},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ClassifyError(tc.err)
+			assert.ErrorIs(t, err, tc.expected)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	assert.Nil(t, ClassifyError(nil))
+	assert.True(t, IsTransient(ClassifyError(context.DeadlineExceeded)))
+	assert.False(t, IsTransient(cause))
+}
@@ -0,0 +1,379 @@
+package store
+
+import (
+	"context"
+	"iter"
+	"time"
+)
+
+// GetFunc is the signature of the Get operation of a store
+type GetFunc func(ctx context.Context, key any) (any, error)
+
+// GetWithTTLFunc is the signature of the GetWithTTL operation of a store
+type GetWithTTLFunc func(ctx context.Context, key any) (any, time.Duration, error)
+
+// SetFunc is the signature of the Set operation of a store
+type SetFunc func(ctx context.Context, key any, value any, options ...Option) error
+
+// DeleteFunc is the signature of the Delete operation of a store
+type DeleteFunc func(ctx context.Context, key any) error
+
+// GetManyFunc is the signature of the GetMany operation of a store
+type GetManyFunc func(ctx context.Context, keys []any) (map[any]any, error)
+
+// SetManyFunc is the signature of the SetMany operation of a store
+type SetManyFunc func(ctx context.Context, items map[any]any, options ...Option) error
+
+// DeleteManyFunc is the signature of the DeleteMany operation of a store
+type DeleteManyFunc func(ctx context.Context, keys []any) error
+
+// InvalidateFunc is the signature of the Invalidate operation of a store
+type InvalidateFunc func(ctx context.Context, options ...InvalidateOption) error
+
+// ClearFunc is the signature of the Clear operation of a store
+type ClearFunc func(ctx context.Context) error
+
+// KeysFunc is the signature of the Keys operation of a store
+type KeysFunc func(ctx context.Context, options ...ScanOption) iter.Seq2[any, error]
+
+// CounterFunc is the signature of the Increment and Decrement operations of a store
+type CounterFunc func(ctx context.Context, key any, delta int64, options ...Option) (int64, error)
+
+// GetWithVersionFunc is the signature of the GetWithVersion operation of a store
+type GetWithVersionFunc func(ctx context.Context, key any) (any, Version, error)
+
+// CompareAndSwapFunc is the signature of the CompareAndSwap operation of a store
+type CompareAndSwapFunc func(ctx context.Context, key any, value any, version Version, options ...Option) error
+
+// TouchFunc is the signature of the Touch operation of a store
+type TouchFunc func(ctx context.Context, key any, ttl time.Duration) error
+
+// SlidingExpirationFunc is the signature of the SlidingExpiration operation of a store
+type SlidingExpirationFunc func(ctx context.Context, key any) (time.Duration, error)
+
+// Interceptor wraps some operations of a store. Each function receives the next
+// step of the chain and returns the function to call instead, which can act before
+// and after calling next, change its arguments or not call it at all.
+// Operations whose function is nil are not intercepted.
+type Interceptor struct {
+	Get               func(next GetFunc) GetFunc
+	GetWithTTL        func(next GetWithTTLFunc) GetWithTTLFunc
+	Set               func(next SetFunc) SetFunc
+	Delete            func(next DeleteFunc) DeleteFunc
+	GetMany           func(next GetManyFunc) GetManyFunc
+	SetMany           func(next SetManyFunc) SetManyFunc
+	DeleteMany        func(next DeleteManyFunc) DeleteManyFunc
+	Invalidate        func(next InvalidateFunc) InvalidateFunc
+	Clear             func(next ClearFunc) ClearFunc
+	Keys              func(next KeysFunc) KeysFunc
+	Increment         func(next CounterFunc) CounterFunc
+	Decrement         func(next CounterFunc) CounterFunc
+	SetIfNotExists    func(next SetFunc) SetFunc
+	GetWithVersion    func(next GetWithVersionFunc) GetWithVersionFunc
+	CompareAndSwap    func(next CompareAndSwapFunc) CompareAndSwapFunc
+	Touch             func(next TouchFunc) TouchFunc
+	SlidingExpiration func(next SlidingExpirationFunc) SlidingExpirationFunc
+}
+
+// InterceptedStore is a store whose operations go through a chain of interceptors.
+// Batch operations go through the GetMany, SetMany and DeleteMany interceptors once
+// per batch. At the end of the chain, they use the batch operations of the wrapped
+// store when available, and fall back to intercepted Get, Set and Delete calls otherwise.
+// The optional operations the wrapped store does not support return ErrUnsupported
+// at the end of the chain.
+type InterceptedStore struct {
+	store             StoreInterface
+	get               GetFunc
+	getWithTTL        GetWithTTLFunc
+	set               SetFunc
+	delete            DeleteFunc
+	getMany           GetManyFunc
+	setMany           SetManyFunc
+	deleteMany        DeleteManyFunc
+	invalidate        InvalidateFunc
+	clear             ClearFunc
+	keys              KeysFunc
+	increment         CounterFunc
+	decrement         CounterFunc
+	setIfNotExists    SetFunc
+	getWithVersion    GetWithVersionFunc
+	compareAndSwap    CompareAndSwapFunc
+	touch             TouchFunc
+	slidingExpiration SlidingExpirationFunc
+}
+
+// NewIntercepted creates a new store calling the given interceptors before the
+// wrapped store. The first interceptor is the outermost one, called first.
+func NewIntercepted(store StoreInterface, interceptors ...Interceptor) *InterceptedStore {
+	s := &InterceptedStore{
+		store:      store,
+		get:        store.Get,
+		getWithTTL: store.GetWithTTL,
+		set:        store.Set,
+		delete:     store.Delete,
+		invalidate: store.Invalidate,
+		clear:      store.Clear,
+	}
+	s.getMany = s.storeGetMany
+	s.setMany = s.storeSetMany
+	s.deleteMany = s.storeDeleteMany
+	s.keys = s.storeKeys
+	s.increment = s.storeIncrement
+	s.decrement = s.storeDecrement
+	s.setIfNotExists = s.storeSetIfNotExists
+	s.getWithVersion = s.storeGetWithVersion
+	s.compareAndSwap = s.storeCompareAndSwap
+	s.touch = s.storeTouch
+	s.slidingExpiration = s.storeSlidingExpiration
+
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		s.get = intercept(s.get, interceptors[i].Get)
+		s.getWithTTL = intercept(s.getWithTTL, interceptors[i].GetWithTTL)
+		s.set = intercept(s.set, interceptors[i].Set)
+		s.delete = intercept(s.delete, interceptors[i].Delete)
+		s.getMany = intercept(s.getMany, interceptors[i].GetMany)
+		s.setMany = intercept(s.setMany, interceptors[i].SetMany)
+		s.deleteMany = intercept(s.deleteMany, interceptors[i].DeleteMany)
+		s.invalidate = intercept(s.invalidate, interceptors[i].Invalidate)
+		s.clear = intercept(s.clear, interceptors[i].Clear)
+		s.keys = intercept(s.keys, interceptors[i].Keys)
+		s.increment = intercept(s.increment, interceptors[i].Increment)
+		s.decrement = intercept(s.decrement, interceptors[i].Decrement)
+		s.setIfNotExists = intercept(s.setIfNotExists, interceptors[i].SetIfNotExists)
+		s.getWithVersion = intercept(s.getWithVersion, interceptors[i].GetWithVersion)
+		s.compareAndSwap = intercept(s.compareAndSwap, interceptors[i].CompareAndSwap)
+		s.touch = intercept(s.touch, interceptors[i].Touch)
+		s.slidingExpiration = intercept(s.slidingExpiration, interceptors[i].SlidingExpiration)
+	}
+
+	return s
+}
+
+// intercept wraps next with the given interceptor function, if any
+func intercept[F any](next F, interceptor func(next F) F) F {
+	if interceptor == nil {
+		return next
+	}
+
+	return interceptor(next)
+}
+
+// Get returns data stored from a given key through the interceptors
+func (s *InterceptedStore) Get(ctx context.Context, key any) (any, error) {
+	return s.get(ctx, key)
+}
+
+// GetWithTTL returns data stored from a given key and its corresponding TTL through the interceptors
+func (s *InterceptedStore) GetWithTTL(ctx context.Context, key any) (any, time.Duration, error) {
+	return s.getWithTTL(ctx, key)
+}
+
+// Set defines data in the store for given key identifier through the interceptors
+func (s *InterceptedStore) Set(ctx context.Context, key any, value any, options ...Option) error {
+	return s.set(ctx, key, value, options...)
+}
+
+// Delete removes data from the store for given key identifier through the interceptors
+func (s *InterceptedStore) Delete(ctx context.Context, key any) error {
+	return s.delete(ctx, key)
+}
+
+// GetMany returns the values stored for the given keys through the interceptors
+func (s *InterceptedStore) GetMany(ctx context.Context, keys []any) (map[any]any, error) {
+	return s.getMany(ctx, keys)
+}
+
+// SetMany stores all the given items using the same options through the interceptors
+func (s *InterceptedStore) SetMany(ctx context.Context, items map[any]any, options ...Option) error {
+	return s.setMany(ctx, items, options...)
+}
+
+// DeleteMany removes all the given keys from the store through the interceptors
+func (s *InterceptedStore) DeleteMany(ctx context.Context, keys []any) error {
+	return s.deleteMany(ctx, keys)
+}
+
+// Invalidate invalidates some data of the store for given options through the interceptors
+func (s *InterceptedStore) Invalidate(ctx context.Context, options ...InvalidateOption) error {
+	return s.invalidate(ctx, options...)
+}
+
+// Clear resets all data in the store through the interceptors
+func (s *InterceptedStore) Clear(ctx context.Context) error {
+	return s.clear(ctx)
+}
+
+// Keys iterates over the keys of the store through the interceptors
+func (s *InterceptedStore) Keys(ctx context.Context, options ...ScanOption) iter.Seq2[any, error] {
+	return s.keys(ctx, options...)
+}
+
+// Increment adds the given delta to the counter stored in the store through the interceptors
+func (s *InterceptedStore) Increment(ctx context.Context, key any, delta int64, options ...Option) (int64, error) {
+	return s.increment(ctx, key, delta, options...)
+}
+
+// Decrement subtracts the given delta from the counter stored in the store through the interceptors
+func (s *InterceptedStore) Decrement(ctx context.Context, key any, delta int64, options ...Option) (int64, error) {
+	return s.decrement(ctx, key, delta, options...)
+}
+
+// SetIfNotExists sets the value only if the key does not exist yet through the interceptors
+func (s *InterceptedStore) SetIfNotExists(ctx context.Context, key any, value any, options ...Option) error {
+	return s.setIfNotExists(ctx, key, value, options...)
+}
+
+// GetWithVersion returns the value stored along with its current version through the interceptors
+func (s *InterceptedStore) GetWithVersion(ctx context.Context, key any) (any, Version, error) {
+	return s.getWithVersion(ctx, key)
+}
+
+// CompareAndSwap sets the value only if it still matches the given version through the interceptors
+func (s *InterceptedStore) CompareAndSwap(ctx context.Context, key any, value any, version Version, options ...Option) error {
+	return s.compareAndSwap(ctx, key, value, version, options...)
+}
+
+// Touch changes the expiration of the given key through the interceptors
+func (s *InterceptedStore) Touch(ctx context.Context, key any, ttl time.Duration) error {
+	return s.touch(ctx, key, ttl)
+}
+
+// SlidingExpiration returns the sliding expiration of the given key through the interceptors
+func (s *InterceptedStore) SlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	return s.slidingExpiration(ctx, key)
+}
+
+// storeGetMany returns the values stored in the wrapped store for the given keys,
+// using intercepted Get calls if the wrapped store does not support batches
+func (s *InterceptedStore) storeGetMany(ctx context.Context, keys []any) (map[any]any, error) {
+	if batch, ok := s.store.(BatchStoreInterface); ok {
+		return batch.GetMany(ctx, keys)
+	}
+
+	return getEach(ctx, s.get, keys)
+}
+
+// storeSetMany stores the given items in the wrapped store,
+// using intercepted Set calls if the wrapped store does not support batches
+func (s *InterceptedStore) storeSetMany(ctx context.Context, items map[any]any, options ...Option) error {
+	if batch, ok := s.store.(BatchStoreInterface); ok {
+		return batch.SetMany(ctx, items, options...)
+	}
+
+	return setEach(ctx, s.set, items, options...)
+}
+
+// storeDeleteMany removes the given keys from the wrapped store,
+// using intercepted Delete calls if the wrapped store does not support batches
+func (s *InterceptedStore) storeDeleteMany(ctx context.Context, keys []any) error {
+	if batch, ok := s.store.(BatchStoreInterface); ok {
+		return batch.DeleteMany(ctx, keys)
+	}
+
+	return deleteEach(ctx, s.delete, keys)
+}
+
+// storeKeys iterates over the keys of the wrapped store.
+// A single ErrUnsupported error is yielded if the wrapped store cannot list its keys.
+func (s *InterceptedStore) storeKeys(ctx context.Context, options ...ScanOption) iter.Seq2[any, error] {
+	scanner, ok := s.store.(ScannerStoreInterface)
+	if !ok {
+		return func(yield func(any, error) bool) {
+			yield(nil, ErrUnsupported)
+		}
+	}
+
+	return scanner.Keys(ctx, options...)
+}
+
+// storeIncrement adds the given delta to the counter stored in the wrapped store.
+// ErrUnsupported is returned if the wrapped store does not support counters.
+func (s *InterceptedStore) storeIncrement(ctx context.Context, key any, delta int64, options ...Option) (int64, error) {
+	counter, ok := s.store.(CounterStoreInterface)
+	if !ok {
+		return 0, ErrUnsupported
+	}
+
+	return counter.Increment(ctx, key, delta, options...)
+}
+
+// storeDecrement subtracts the given delta from the counter stored in the wrapped store.
+// ErrUnsupported is returned if the wrapped store does not support counters.
+func (s *InterceptedStore) storeDecrement(ctx context.Context, key any, delta int64, options ...Option) (int64, error) {
+	counter, ok := s.store.(CounterStoreInterface)
+	if !ok {
+		return 0, ErrUnsupported
+	}
+
+	return counter.Decrement(ctx, key, delta, options...)
+}
+
+// storeSetIfNotExists sets the value in the wrapped store only if the key does not exist yet.
+// ErrUnsupported is returned if the wrapped store does not support conditional writes.
+func (s *InterceptedStore) storeSetIfNotExists(ctx context.Context, key any, value any, options ...Option) error {
+	conditional, ok := s.store.(ConditionalStoreInterface)
+	if !ok {
+		return ErrUnsupported
+	}
+
+	return conditional.SetIfNotExists(ctx, key, value, options...)
+}
+
+// storeGetWithVersion returns the value stored in the wrapped store along with its current version.
+// ErrUnsupported is returned if the wrapped store does not support conditional writes.
+func (s *InterceptedStore) storeGetWithVersion(ctx context.Context, key any) (any, Version, error) {
+	conditional, ok := s.store.(ConditionalStoreInterface)
+	if !ok {
+		return nil, Version{}, ErrUnsupported
+	}
+
+	return conditional.GetWithVersion(ctx, key)
+}
+
+// storeCompareAndSwap sets the value in the wrapped store only if it still matches the given version.
+// ErrUnsupported is returned if the wrapped store does not support conditional writes.
+func (s *InterceptedStore) storeCompareAndSwap(ctx context.Context, key any, value any, version Version, options ...Option) error {
+	conditional, ok := s.store.(ConditionalStoreInterface)
+	if !ok {
+		return ErrUnsupported
+	}
+
+	return conditional.CompareAndSwap(ctx, key, value, version, options...)
+}
+
+// storeTouch changes the expiration of the given key in the wrapped store.
+// ErrUnsupported is returned if the wrapped store is not able to change expirations.
+func (s *InterceptedStore) storeTouch(ctx context.Context, key any, ttl time.Duration) error {
+	touchable, ok := s.store.(TouchStoreInterface)
+	if !ok {
+		return ErrUnsupported
+	}
+
+	return touchable.Touch(ctx, key, ttl)
+}
+
+// storeSlidingExpiration returns the sliding expiration of the given key in the wrapped store.
+// ErrUnsupported is returned if the wrapped store does not record sliding expirations.
+func (s *InterceptedStore) storeSlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	sliding, ok := s.store.(SlidingStoreInterface)
+	if !ok {
+		return 0, ErrUnsupported
+	}
+
+	return sliding.SlidingExpiration(ctx, key)
+}
+
+// Capabilities returns the features supported by the wrapped store
+func (s *InterceptedStore) Capabilities() Capabilities {
+	return CapabilitiesOf(s.store)
+}
+
+// GetStore returns the wrapped store
+func (s *InterceptedStore) GetStore() StoreInterface {
+	return s.store
+}
+
+// GetType returns the type of the wrapped store
+func (s *InterceptedStore) GetType() string {
+	return s.store.GetType()
+}
@@ -0,0 +1,260 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterceptedStoreOrder(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	calls := []string{}
+	logger := func(name string) Interceptor {
+		return Interceptor{
+			Set: func(next SetFunc) SetFunc {
+				return func(ctx context.Context, key any, value any, options ...Option) error {
+					calls = append(calls, name+" before")
+					err := next(ctx, key, value, options...)
+					calls = append(calls, name+" after")
+					return err
+				}
+			},
+		}
+	}
+
+	memory := &memoryStore{values: map[any]any{}}
+	store := NewIntercepted(memory, logger("first"), logger("second"))
+
+	// When
+	err := store.Set(ctx, "my-key", "my-value")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"first before", "second before", "second after", "first after"}, calls)
+	assert.Equal(t, "my-value", memory.values["my-key"])
+}
+
+func TestInterceptedStoreKeyRewriting(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	rewrite := Interceptor{
+		Get: func(next GetFunc) GetFunc {
+			return func(ctx context.Context, key any) (any, error) {
+				return next(ctx, "v2:"+key.(string))
+			}
+		},
+	}
+
+	memory := &memoryStore{values: map[any]any{"v2:key-1": "value-1"}}
+	store := NewIntercepted(memory, rewrite)
+
+	// When
+	values, err := GetMany(ctx, store, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1"}, values)
+}
+
+func TestInterceptedStoreFaultInjection(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	expectedErr := errors.New("injected failure")
+	failure := Interceptor{
+		Delete: func(next DeleteFunc) DeleteFunc {
+			return func(ctx context.Context, key any) error {
+				return expectedErr
+			}
+		},
+	}
+
+	memory := &memoryStore{values: map[any]any{"my-key": "my-value"}}
+	store := NewIntercepted(memory, failure)
+
+	// When
+	err := store.Delete(ctx, "my-key")
+	value, getErr := store.Get(ctx, "my-key")
+
+	// Then
+	assert.Equal(t, expectedErr, err)
+	assert.Nil(t, getErr)
+	assert.Equal(t, "my-value", value)
+}
+
+func TestInterceptedStoreWhenOperationNotSupported(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	store := NewIntercepted(&memoryStore{values: map[any]any{}})
+
+	// When
+	_, err := store.Increment(ctx, "my-key", 1)
+
+	// Then
+	assert.ErrorIs(t, err, ErrUnsupported)
+	assert.False(t, store.Capabilities().AtomicCounters)
+	assert.Equal(t, "memory", store.GetType())
+}
+
+func TestInterceptedStoreKeys(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	fake := Interceptor{
+		Keys: func(next KeysFunc) KeysFunc {
+			return func(ctx context.Context, options ...ScanOption) iter.Seq2[any, error] {
+				return func(yield func(any, error) bool) {
+					_ = yield("key-1", nil) && yield("key-2", nil)
+				}
+			}
+		},
+	}
+
+	store := NewIntercepted(&memoryStore{values: map[any]any{}}, fake)
+
+	// When
+	keys := []any{}
+	for key, err := range store.Keys(ctx) {
+		assert.Nil(t, err)
+		keys = append(keys, key)
+	}
+
+	// Then
+	assert.Equal(t, []any{"key-1", "key-2"}, keys)
+}
+
+func TestInterceptedStoreSetIfNotExists(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	calls := []string{}
+	logging := Interceptor{
+		SetIfNotExists: func(next SetFunc) SetFunc {
+			return func(ctx context.Context, key any, value any, options ...Option) error {
+				calls = append(calls, key.(string))
+				return next(ctx, key, value, options...)
+			}
+		},
+	}
+
+	store := NewIntercepted(&memoryStore{values: map[any]any{}}, logging)
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-key", "my-value")
+
+	// Then
+	assert.ErrorIs(t, err, ErrUnsupported)
+	assert.Equal(t, []string{"my-key"}, calls)
+}
+
+// memoryBatchStore is a memoryStore counting the calls to its batch operations
+type memoryBatchStore struct {
+	*memoryStore
+	batches int
+}
+
+func (s *memoryBatchStore) GetMany(ctx context.Context, keys []any) (map[any]any, error) {
+	s.batches++
+	return getEach(ctx, s.Get, keys)
+}
+
+func (s *memoryBatchStore) SetMany(ctx context.Context, items map[any]any, options ...Option) error {
+	s.batches++
+	return setEach(ctx, s.Set, items, options...)
+}
+
+func (s *memoryBatchStore) DeleteMany(ctx context.Context, keys []any) error {
+	s.batches++
+	return deleteEach(ctx, s.Delete, keys)
+}
+
+func TestInterceptedStoreBatch(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	calls := []string{}
+	logger := Interceptor{
+		Get: func(next GetFunc) GetFunc {
+			return func(ctx context.Context, key any) (any, error) {
+				calls = append(calls, "get")
+				return next(ctx, key)
+			}
+		},
+		GetMany: func(next GetManyFunc) GetManyFunc {
+			return func(ctx context.Context, keys []any) (map[any]any, error) {
+				calls = append(calls, "get many")
+				return next(ctx, keys)
+			}
+		},
+		SetMany: func(next SetManyFunc) SetManyFunc {
+			return func(ctx context.Context, items map[any]any, options ...Option) error {
+				calls = append(calls, "set many")
+				return next(ctx, items, options...)
+			}
+		},
+		DeleteMany: func(next DeleteManyFunc) DeleteManyFunc {
+			return func(ctx context.Context, keys []any) error {
+				calls = append(calls, "delete many")
+				return next(ctx, keys)
+			}
+		},
+	}
+
+	memory := &memoryBatchStore{memoryStore: &memoryStore{values: map[any]any{}}}
+	store := NewIntercepted(memory, logger)
+
+	// When
+	setErr := SetMany(ctx, store, map[any]any{"key-1": "value-1", "key-2": "value-2"})
+	values, getErr := GetMany(ctx, store, []any{"key-1", "key-2", "key-3"})
+	deleteErr := DeleteMany(ctx, store, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, setErr)
+	assert.Nil(t, getErr)
+	assert.Nil(t, deleteErr)
+	assert.Equal(t, map[any]any{"key-1": "value-1", "key-2": "value-2"}, values)
+	assert.Empty(t, memory.values)
+	assert.Equal(t, []string{"set many", "get many", "delete many"}, calls)
+	assert.Equal(t, 3, memory.batches)
+	assert.True(t, store.Capabilities().Batch)
+}
+
+func TestInterceptedStoreBatchWhenStoreDoesNotSupportBatches(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	calls := []string{}
+	logger := Interceptor{
+		Delete: func(next DeleteFunc) DeleteFunc {
+			return func(ctx context.Context, key any) error {
+				calls = append(calls, "delete "+key.(string))
+				return next(ctx, key)
+			}
+		},
+		DeleteMany: func(next DeleteManyFunc) DeleteManyFunc {
+			return func(ctx context.Context, keys []any) error {
+				calls = append(calls, "delete many")
+				return next(ctx, keys)
+			}
+		},
+	}
+
+	memory := &memoryStore{values: map[any]any{"key-1": "value-1", "key-2": "value-2"}}
+	store := NewIntercepted(memory, logger)
+
+	// When
+	err := store.DeleteMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Empty(t, memory.values)
+	assert.Equal(t, []string{"delete many", "delete key-1", "delete key-2"}, calls)
+	assert.False(t, store.Capabilities().Batch)
+}
@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"iter"
 	"time"
 )
 
@@ -15,3 +16,59 @@ type StoreInterface interface {
 	Clear(ctx context.Context) error
 	GetType() string
 }
+
+// BatchStoreInterface is the interface for stores that are able to handle
+// several keys in a single operation (for instance using MGET or pipelines)
+type BatchStoreInterface interface {
+	GetMany(ctx context.Context, keys []any) (map[any]any, error)
+	SetMany(ctx context.Context, items map[any]any, options ...Option) error
+	DeleteMany(ctx context.Context, keys []any) error
+}
+
+// ScannerStoreInterface is the interface for stores that are able to iterate
+// over the keys they hold
+type ScannerStoreInterface interface {
+	Keys(ctx context.Context, options ...ScanOption) iter.Seq2[any, error]
+}
+
+// CounterStoreInterface is the interface for stores that are able to
+// atomically increment or decrement integer values.
+// Missing keys are initialized to 0 before applying the delta and the
+// expiration option is only applied when the counter is created.
+type CounterStoreInterface interface {
+	Increment(ctx context.Context, key any, delta int64, options ...Option) (int64, error)
+	Decrement(ctx context.Context, key any, delta int64, options ...Option) (int64, error)
+}
+
+// ConditionalStoreInterface is the interface for stores that are able to
+// write a value only when a condition is met.
+// A ConditionFailed error is returned when the condition is not met.
+type ConditionalStoreInterface interface {
+	// SetIfNotExists sets the value only if the key does not exist yet
+	SetIfNotExists(ctx context.Context, key any, value any, options ...Option) error
+	// GetWithVersion returns the value stored for the key along with its current version
+	GetWithVersion(ctx context.Context, key any) (any, Version, error)
+	// CompareAndSwap sets the value only if the stored one still matches the given version
+	CompareAndSwap(ctx context.Context, key any, value any, version Version, options ...Option) error
+}
+
+// TouchStoreInterface is the interface for stores that are able to change
+// the expiration of a key without rewriting its value.
+// The ttl has the same meaning as the expiration option given to Set and
+// a NotFound error is returned if the key does not exist.
+type TouchStoreInterface interface {
+	Touch(ctx context.Context, key any, ttl time.Duration) error
+}
+
+// SlidingStoreInterface is the interface for stores that are able to tell the
+// sliding expiration given to a key using the WithSlidingExpiration option.
+// A zero duration is returned if the key has no sliding expiration.
+type SlidingStoreInterface interface {
+	SlidingExpiration(ctx context.Context, key any) (time.Duration, error)
+}
+
+// CapabilitiesStoreInterface is the interface for stores describing the features
+// they support, see CapabilitiesOf
+type CapabilitiesStoreInterface interface {
+	Capabilities() Capabilities
+}
@@ -1,14 +1,39 @@
 package store
 
+import "strings"
+
 // InvalidateOption represents a cache invalidation function.
 type InvalidateOption func(o *InvalidateOptions)
 
 type InvalidateOptions struct {
-	Tags []string
+	Tags    []string
+	Prefix  string
+	Pattern string
 }
 
 func (o *InvalidateOptions) isEmpty() bool {
-	return len(o.Tags) == 0
+	return len(o.Tags) == 0 && !o.HasKeyFilter()
+}
+
+// HasKeyFilter returns true if keys have to be invalidated given their prefix or a pattern
+func (o *InvalidateOptions) HasKeyFilter() bool {
+	return o.Prefix != "" || o.Pattern != ""
+}
+
+// KeyPattern returns a glob-style pattern matching the keys to invalidate, to be used
+// to scan the store keys. Keys it returns still have to be checked using MatchKey
+// when both a prefix and a pattern are given.
+func (o *InvalidateOptions) KeyPattern() string {
+	if o.Pattern != "" {
+		return o.Pattern
+	}
+
+	return EscapePattern(o.Prefix) + "*"
+}
+
+// MatchKey reports whether the given key has to be invalidated given the prefix and pattern
+func (o *InvalidateOptions) MatchKey(key string) bool {
+	return strings.HasPrefix(key, o.Prefix) && MatchPattern(o.Pattern, key)
 }
 
 func ApplyInvalidateOptionsWithDefault(defaultOptions *InvalidateOptions, opts ...InvalidateOption) *InvalidateOptions {
@@ -37,3 +62,18 @@ func WithInvalidateTags(tags []string) InvalidateOption {
 		o.Tags = tags
 	}
 }
+
+// WithInvalidatePrefix allows invalidating all the keys starting with the given prefix.
+func WithInvalidatePrefix(prefix string) InvalidateOption {
+	return func(o *InvalidateOptions) {
+		o.Prefix = prefix
+	}
+}
+
+// WithInvalidatePattern allows invalidating all the keys matching the given glob-style
+// pattern, following the rules of MatchPattern.
+func WithInvalidatePattern(pattern string) InvalidateOption {
+	return func(o *InvalidateOptions) {
+		o.Pattern = pattern
+	}
+}
@@ -15,3 +15,28 @@ func TestInvalidateOptionsTagsValue(t *testing.T) {
 	// When - Then
 	assert.Equal(t, []string{"tag1", "tag2", "tag3"}, options.Tags)
 }
+
+func TestInvalidateOptionsKeyPattern(t *testing.T) {
+	// Given
+	prefixOptions := ApplyInvalidateOptions(WithInvalidatePrefix("tenant:[42]:"))
+	patternOptions := ApplyInvalidateOptions(WithInvalidatePattern("tenant:*:users"))
+
+	// When - Then
+	assert.True(t, prefixOptions.HasKeyFilter())
+	assert.Equal(t, `tenant:\[42\]:*`, prefixOptions.KeyPattern())
+	assert.Equal(t, "tenant:*:users", patternOptions.KeyPattern())
+	assert.False(t, ApplyInvalidateOptions(WithInvalidateTags([]string{"tag1"})).HasKeyFilter())
+}
+
+func TestInvalidateOptionsMatchKey(t *testing.T) {
+	// Given
+	options := ApplyInvalidateOptions(
+		WithInvalidatePrefix("tenant:42:"),
+		WithInvalidatePattern("*:users:*"),
+	)
+
+	// When - Then
+	assert.True(t, options.MatchKey("tenant:42:users:1"))
+	assert.False(t, options.MatchKey("tenant:42:orders:1"))
+	assert.False(t, options.MatchKey("tenant:43:users:1"))
+}
@@ -0,0 +1,31 @@
+package store
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// KeyAs returns the given key as a key of type K, the type of keys handled by a store.
+// An ErrInvalidKeyType error is returned when the key has another type.
+func KeyAs[K any](key any) (K, error) {
+	typed, ok := key.(K)
+	if !ok {
+		return typed, InvalidKeyTypeWithCause(fmt.Errorf("key %v of type %T is not a %s", key, key, reflect.TypeFor[K]()))
+	}
+
+	return typed, nil
+}
+
+// KeysAs returns the given keys as keys of type K using KeyAs
+func KeysAs[K any](keys []any) ([]K, error) {
+	typed := make([]K, 0, len(keys))
+	for _, key := range keys {
+		typedKey, err := KeyAs[K](key)
+		if err != nil {
+			return nil, err
+		}
+		typed = append(typed, typedKey)
+	}
+
+	return typed, nil
+}
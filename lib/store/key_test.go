@@ -0,0 +1,37 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyAs(t *testing.T) {
+	// When
+	key, err := KeyAs[string]("my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-key", key)
+}
+
+func TestKeyAsWhenKeyHasAnotherType(t *testing.T) {
+	// When
+	key, err := KeyAs[string](42)
+
+	// Then
+	assert.ErrorIs(t, err, ErrInvalidKeyType)
+	assert.EqualError(t, err, "key type not supported by store: key 42 of type int is not a string")
+	assert.Empty(t, key)
+}
+
+func TestKeysAs(t *testing.T) {
+	// When
+	keys, err := KeysAs[string]([]any{"key-1", "key-2"})
+	_, invalidErr := KeysAs[string]([]any{"key-1", 2})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"key-1", "key-2"}, keys)
+	assert.ErrorIs(t, invalidErr, ErrInvalidKeyType)
+}
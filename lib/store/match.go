@@ -0,0 +1,120 @@
+package store
+
+import "strings"
+
+// MatchPattern reports whether the given key matches the given glob-style pattern,
+// using the same rules as the Redis MATCH option: * matches any sequence of characters,
+// ? matches a single character, [abc], [^abc] and [a-z] match a set of characters
+// and \ escapes the following character. An empty pattern matches every key.
+func MatchPattern(pattern, key string) bool {
+	if pattern == "" {
+		return true
+	}
+
+	return matchPattern([]rune(pattern), []rune(key))
+}
+
+// EscapePattern escapes the special characters of the given string so that it
+// only matches itself when used as a pattern
+func EscapePattern(value string) string {
+	var builder strings.Builder
+	for _, char := range value {
+		switch char {
+		case '*', '?', '[', ']', '\\':
+			builder.WriteRune('\\')
+		}
+		builder.WriteRune(char)
+	}
+
+	return builder.String()
+}
+
+func matchPattern(pattern, key []rune) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for len(pattern) > 1 && pattern[1] == '*' {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(key); i++ {
+				if matchPattern(pattern[1:], key[i:]) {
+					return true
+				}
+			}
+			return false
+
+		case '?':
+			if len(key) == 0 {
+				return false
+			}
+
+		case '[':
+			if len(key) == 0 {
+				return false
+			}
+			matched, rest := matchClass(pattern[1:], key[0])
+			if !matched {
+				return false
+			}
+			pattern = rest
+			key = key[1:]
+			continue
+
+		case '\\':
+			if len(pattern) > 1 {
+				pattern = pattern[1:]
+			}
+			fallthrough
+
+		default:
+			if len(key) == 0 || pattern[0] != key[0] {
+				return false
+			}
+		}
+
+		pattern = pattern[1:]
+		key = key[1:]
+	}
+
+	return len(key) == 0
+}
+
+// matchClass reports whether the given character matches the character class
+// at the start of the given pattern (just after the opening bracket) and returns
+// the remaining pattern after the closing bracket
+func matchClass(pattern []rune, char rune) (bool, []rune) {
+	negate := false
+	if len(pattern) > 0 && (pattern[0] == '^' || pattern[0] == '!') {
+		negate = true
+		pattern = pattern[1:]
+	}
+
+	matched := false
+	for len(pattern) > 0 && pattern[0] != ']' {
+		switch {
+		case pattern[0] == '\\' && len(pattern) > 1:
+			matched = matched || pattern[1] == char
+			pattern = pattern[2:]
+		case len(pattern) > 2 && pattern[1] == '-' && pattern[2] != ']':
+			low, high := pattern[0], pattern[2]
+			if low > high {
+				low, high = high, low
+			}
+			matched = matched || (char >= low && char <= high)
+			pattern = pattern[3:]
+		default:
+			matched = matched || pattern[0] == char
+			pattern = pattern[1:]
+		}
+	}
+
+	if len(pattern) > 0 {
+		// skip the closing bracket
+		pattern = pattern[1:]
+	}
+
+	return matched != negate, pattern
+}
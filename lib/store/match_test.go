@@ -0,0 +1,54 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		key      string
+		expected bool
+	}{
+		{pattern: "", key: "my-key", expected: true},
+		{pattern: "*", key: "my-key", expected: true},
+		{pattern: "*", key: "", expected: true},
+		{pattern: "my-*", key: "my-key", expected: true},
+		{pattern: "my-*", key: "your-key", expected: false},
+		{pattern: "*-key", key: "my-key", expected: true},
+		{pattern: "tenant:42:*", key: "tenant:42:users/1", expected: true},
+		{pattern: "tenant:42:*", key: "tenant:421:users/1", expected: false},
+		{pattern: "my-?ey", key: "my-key", expected: true},
+		{pattern: "my-?ey", key: "my-ey", expected: false},
+		{pattern: "h[ae]llo", key: "hello", expected: true},
+		{pattern: "h[ae]llo", key: "hillo", expected: false},
+		{pattern: "h[^e]llo", key: "hallo", expected: true},
+		{pattern: "h[^e]llo", key: "hello", expected: false},
+		{pattern: "h[a-c]llo", key: "hbllo", expected: true},
+		{pattern: "h[a-c]llo", key: "hdllo", expected: false},
+		{pattern: `my\*key`, key: "my*key", expected: true},
+		{pattern: `my\*key`, key: "my-key", expected: false},
+		{pattern: "my-key", key: "my-key-2", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MatchPattern(tt.pattern, tt.key))
+		})
+	}
+}
+
+func TestEscapePattern(t *testing.T) {
+	// Given
+	value := `key*?[1]\`
+
+	// When
+	pattern := EscapePattern(value)
+
+	// Then
+	assert.Equal(t, `key\*\?\[1\]\\`, pattern)
+	assert.True(t, MatchPattern(pattern, value))
+	assert.False(t, MatchPattern(pattern, "key-?[1]\\"))
+}
@@ -0,0 +1,518 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// NamespaceType represents the storage type as a string value
+	NamespaceType = "namespace"
+	// NamespaceSeparator separates the namespace from the keys and tags it prefixes
+	NamespaceSeparator = ":"
+)
+
+// errNamespaceKeyType is returned for keys which cannot be prefixed with the namespace
+var errNamespaceKeyType = InvalidKeyTypeWithCause(errors.New("namespace keys have to be strings"))
+
+// NamespaceOption represents a namespace store option function.
+type NamespaceOption func(s *NamespaceStore)
+
+// WithNamespaceGenerations makes the namespace store prefix keys with a generation
+// number which is incremented by Clear, instead of deleting the keys of the namespace.
+// Clear is then a single atomic increment and also works with stores that cannot
+// list their keys, but the wrapped store has to support counters and keys of previous
+// generations are only removed once they expire or are evicted. The generation is
+// read from the wrapped store before every operation, which costs an extra Get.
+func WithNamespaceGenerations() NamespaceOption {
+	return func(s *NamespaceStore) {
+		s.generations = true
+	}
+}
+
+// NamespaceStore wraps a store, transparently prefixing keys and tags with a
+// namespace so that several tenants can share the same store.
+// Clear and Invalidate only remove the keys of the namespace. Tagged values are also
+// tagged with the namespace itself, so that Clear removes their tag index entries.
+type NamespaceStore struct {
+	store       StoreInterface
+	namespace   string
+	generations bool
+}
+
+// NewNamespace creates a new store prefixing the keys given to the wrapped store
+// with the given namespace. It panics if the namespace contains the separator, as its keys
+// could then collide with the ones of another namespace.
+func NewNamespace(store StoreInterface, namespace string, options ...NamespaceOption) *NamespaceStore {
+	if strings.Contains(namespace, NamespaceSeparator) {
+		panic(fmt.Sprintf("store: the namespace %q cannot contain %q", namespace, NamespaceSeparator))
+	}
+
+	s := &NamespaceStore{
+		store:     store,
+		namespace: namespace,
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+// prefix returns the prefix of the keys and tags of the namespace, including the
+// current generation when generations are enabled
+func (s *NamespaceStore) prefix(ctx context.Context) (string, error) {
+	if !s.generations {
+		return s.namespace + NamespaceSeparator, nil
+	}
+
+	generation, err := s.generation(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return s.namespace + NamespaceSeparator + strconv.FormatInt(generation, 10) + NamespaceSeparator, nil
+}
+
+// generation returns the current generation of the namespace, 0 until the first Clear
+func (s *NamespaceStore) generation(ctx context.Context) (int64, error) {
+	value, err := s.store.Get(ctx, s.generationKey())
+	if errors.Is(err, NotFound{}) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return CounterValue(value)
+}
+
+// generationKey cannot collide with the keys of the namespace as those
+// start with the generation number
+func (s *NamespaceStore) generationKey() string {
+	return s.namespace + NamespaceSeparator + "generation"
+}
+
+// namespaceTag is given to every tagged value of the namespace. It cannot collide
+// with the other tags of the namespace as those contain the separator.
+func (s *NamespaceStore) namespaceTag() string {
+	return s.namespace
+}
+
+func (s *NamespaceStore) key(prefix string, key any) (string, error) {
+	k, ok := key.(string)
+	if !ok {
+		return "", errNamespaceKeyType
+	}
+
+	return prefix + k, nil
+}
+
+func (s *NamespaceStore) keys(prefix string, keys []any) ([]any, error) {
+	prefixed := make([]any, 0, len(keys))
+	for _, key := range keys {
+		k, err := s.key(prefix, key)
+		if err != nil {
+			return nil, err
+		}
+		prefixed = append(prefixed, k)
+	}
+
+	return prefixed, nil
+}
+
+// options prefixes the tags given in the options, if any, and adds the namespace tag
+func (s *NamespaceStore) options(prefix string, options []Option) []Option {
+	opts := ApplyOptions(options...)
+	if len(opts.Tags) == 0 {
+		return options
+	}
+
+	tags := make([]string, 0, len(opts.Tags)+1)
+	for _, tag := range opts.Tags {
+		tags = append(tags, prefix+tag)
+	}
+	tags = append(tags, s.namespaceTag())
+
+	return append(slices.Clip(options), WithTags(tags))
+}
+
+// Get returns data stored from a given key of the namespace
+func (s *NamespaceStore) Get(ctx context.Context, key any) (any, error) {
+	prefix, err := s.prefix(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := s.key(prefix, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.Get(ctx, k)
+}
+
+// GetWithTTL returns data stored from a given key of the namespace and its corresponding TTL
+func (s *NamespaceStore) GetWithTTL(ctx context.Context, key any) (any, time.Duration, error) {
+	prefix, err := s.prefix(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	k, err := s.key(prefix, key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return s.store.GetWithTTL(ctx, k)
+}
+
+// Set defines data in the namespace for given key identifier
+func (s *NamespaceStore) Set(ctx context.Context, key any, value any, options ...Option) error {
+	prefix, err := s.prefix(ctx)
+	if err != nil {
+		return err
+	}
+
+	k, err := s.key(prefix, key)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Set(ctx, k, value, s.options(prefix, options)...)
+}
+
+// Delete removes data from the namespace for given key identifier
+func (s *NamespaceStore) Delete(ctx context.Context, key any) error {
+	prefix, err := s.prefix(ctx)
+	if err != nil {
+		return err
+	}
+
+	k, err := s.key(prefix, key)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Delete(ctx, k)
+}
+
+// Invalidate invalidates some data of the namespace for given options.
+// Tags, prefixes and patterns are relative to the namespace.
+func (s *NamespaceStore) Invalidate(ctx context.Context, options ...InvalidateOption) error {
+	prefix, err := s.prefix(ctx)
+	if err != nil {
+		return err
+	}
+
+	opts := ApplyInvalidateOptions(options...)
+
+	invalidateOptions := []InvalidateOption{}
+	if len(opts.Tags) > 0 {
+		tags := make([]string, 0, len(opts.Tags))
+		for _, tag := range opts.Tags {
+			tags = append(tags, prefix+tag)
+		}
+		invalidateOptions = append(invalidateOptions, WithInvalidateTags(tags))
+	}
+	if opts.HasKeyFilter() {
+		// the prefix restricts the invalidation to the namespace when only a pattern is given
+		invalidateOptions = append(invalidateOptions, WithInvalidatePrefix(prefix+opts.Prefix))
+	}
+	if opts.Pattern != "" {
+		invalidateOptions = append(invalidateOptions, WithInvalidatePattern(EscapePattern(prefix)+opts.Pattern))
+	}
+
+	if len(invalidateOptions) == 0 {
+		return nil
+	}
+
+	return s.store.Invalidate(ctx, invalidateOptions...)
+}
+
+// Clear removes all the data of the namespace, leaving other keys of the wrapped store untouched.
+// Keys are invalidated using their prefix, or the generation is incremented when
+// generations are enabled. Tagged keys are also invalidated using the namespace tag,
+// so that the wrapped store removes them from its tag index.
+func (s *NamespaceStore) Clear(ctx context.Context) error {
+	invalidateOptions := []InvalidateOption{}
+	if CapabilitiesOf(s.store).Tags {
+		invalidateOptions = append(invalidateOptions, WithInvalidateTags([]string{s.namespaceTag()}))
+	}
+
+	if !s.generations {
+		invalidateOptions = append(invalidateOptions, WithInvalidatePrefix(s.namespace+NamespaceSeparator))
+		return s.store.Invalidate(ctx, invalidateOptions...)
+	}
+
+	counter, ok := s.store.(CounterStoreInterface)
+	if !ok {
+		return ErrUnsupported
+	}
+
+	if _, err := counter.Increment(ctx, s.generationKey(), 1); err != nil {
+		return err
+	}
+
+	if len(invalidateOptions) == 0 {
+		return nil
+	}
+
+	return s.store.Invalidate(ctx, invalidateOptions...)
+}
+
+// GetMany returns the values stored for the given keys of the namespace
+func (s *NamespaceStore) GetMany(ctx context.Context, keys []any) (map[any]any, error) {
+	prefix, err := s.prefix(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	prefixed, err := s.keys(prefix, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := GetMany(ctx, s.store, prefixed)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[any]any, len(values))
+	for key, value := range values {
+		namespacedKey, err := KeyAs[string](key)
+		if err != nil {
+			return nil, err
+		}
+		result[strings.TrimPrefix(namespacedKey, prefix)] = value
+	}
+
+	return result, nil
+}
+
+// SetMany stores all the given items in the namespace using the same options
+func (s *NamespaceStore) SetMany(ctx context.Context, items map[any]any, options ...Option) error {
+	prefix, err := s.prefix(ctx)
+	if err != nil {
+		return err
+	}
+
+	prefixed := make(map[any]any, len(items))
+	for key, value := range items {
+		k, err := s.key(prefix, key)
+		if err != nil {
+			return err
+		}
+		prefixed[k] = value
+	}
+
+	return SetMany(ctx, s.store, prefixed, s.options(prefix, options)...)
+}
+
+// DeleteMany removes all the given keys from the namespace
+func (s *NamespaceStore) DeleteMany(ctx context.Context, keys []any) error {
+	prefix, err := s.prefix(ctx)
+	if err != nil {
+		return err
+	}
+
+	prefixed, err := s.keys(prefix, keys)
+	if err != nil {
+		return err
+	}
+
+	return DeleteMany(ctx, s.store, prefixed)
+}
+
+// Keys iterates over the keys of the namespace, returned without their prefix.
+// A single ErrUnsupported error is yielded if the wrapped store cannot list its keys.
+func (s *NamespaceStore) Keys(ctx context.Context, options ...ScanOption) iter.Seq2[any, error] {
+	return func(yield func(any, error) bool) {
+		scanner, ok := s.store.(ScannerStoreInterface)
+		if !ok {
+			yield(nil, ErrUnsupported)
+			return
+		}
+
+		prefix, err := s.prefix(ctx)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+
+		opts := ApplyScanOptions(options...)
+		match := opts.Match
+		if match == "" {
+			match = "*"
+		}
+
+		for key, err := range scanner.Keys(ctx, WithScanMatch(EscapePattern(prefix)+match), WithScanCount(opts.Count)) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			namespacedKey, err := KeyAs[string](key)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(strings.TrimPrefix(namespacedKey, prefix), nil) {
+				return
+			}
+		}
+	}
+}
+
+// Increment adds the given delta to the counter stored for the given key of the namespace.
+// ErrUnsupported is returned if the wrapped store does not support counters.
+func (s *NamespaceStore) Increment(ctx context.Context, key any, delta int64, options ...Option) (int64, error) {
+	counter, ok := s.store.(CounterStoreInterface)
+	if !ok {
+		return 0, ErrUnsupported
+	}
+
+	prefix, err := s.prefix(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	k, err := s.key(prefix, key)
+	if err != nil {
+		return 0, err
+	}
+
+	return counter.Increment(ctx, k, delta, options...)
+}
+
+// Decrement subtracts the given delta from the counter stored for the given key of the namespace.
+// ErrUnsupported is returned if the wrapped store does not support counters.
+func (s *NamespaceStore) Decrement(ctx context.Context, key any, delta int64, options ...Option) (int64, error) {
+	return s.Increment(ctx, key, -delta, options...)
+}
+
+// SetIfNotExists sets the value only if the key does not exist yet in the namespace.
+// ErrUnsupported is returned if the wrapped store does not support conditional writes.
+func (s *NamespaceStore) SetIfNotExists(ctx context.Context, key any, value any, options ...Option) error {
+	conditional, ok := s.store.(ConditionalStoreInterface)
+	if !ok {
+		return ErrUnsupported
+	}
+
+	prefix, err := s.prefix(ctx)
+	if err != nil {
+		return err
+	}
+
+	k, err := s.key(prefix, key)
+	if err != nil {
+		return err
+	}
+
+	return conditional.SetIfNotExists(ctx, k, value, s.options(prefix, options)...)
+}
+
+// GetWithVersion returns the value stored for the key of the namespace along with its current version.
+// ErrUnsupported is returned if the wrapped store does not support conditional writes.
+func (s *NamespaceStore) GetWithVersion(ctx context.Context, key any) (any, Version, error) {
+	conditional, ok := s.store.(ConditionalStoreInterface)
+	if !ok {
+		return nil, Version{}, ErrUnsupported
+	}
+
+	prefix, err := s.prefix(ctx)
+	if err != nil {
+		return nil, Version{}, err
+	}
+
+	k, err := s.key(prefix, key)
+	if err != nil {
+		return nil, Version{}, err
+	}
+
+	return conditional.GetWithVersion(ctx, k)
+}
+
+// CompareAndSwap sets the value only if the one stored for the key of the namespace still matches the given version.
+// ErrUnsupported is returned if the wrapped store does not support conditional writes.
+func (s *NamespaceStore) CompareAndSwap(ctx context.Context, key any, value any, version Version, options ...Option) error {
+	conditional, ok := s.store.(ConditionalStoreInterface)
+	if !ok {
+		return ErrUnsupported
+	}
+
+	prefix, err := s.prefix(ctx)
+	if err != nil {
+		return err
+	}
+
+	k, err := s.key(prefix, key)
+	if err != nil {
+		return err
+	}
+
+	return conditional.CompareAndSwap(ctx, k, value, version, s.options(prefix, options)...)
+}
+
+// Touch changes the expiration of the given key of the namespace.
+// ErrUnsupported is returned if the wrapped store is not able to change expirations.
+func (s *NamespaceStore) Touch(ctx context.Context, key any, ttl time.Duration) error {
+	touchable, ok := s.store.(TouchStoreInterface)
+	if !ok {
+		return ErrUnsupported
+	}
+
+	prefix, err := s.prefix(ctx)
+	if err != nil {
+		return err
+	}
+
+	k, err := s.key(prefix, key)
+	if err != nil {
+		return err
+	}
+
+	return touchable.Touch(ctx, k, ttl)
+}
+
+// SlidingExpiration returns the sliding expiration of the given key of the namespace.
+// ErrUnsupported is returned if the wrapped store does not record sliding expirations.
+func (s *NamespaceStore) SlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	sliding, ok := s.store.(SlidingStoreInterface)
+	if !ok {
+		return 0, ErrUnsupported
+	}
+
+	prefix, err := s.prefix(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	k, err := s.key(prefix, key)
+	if err != nil {
+		return 0, err
+	}
+
+	return sliding.SlidingExpiration(ctx, k)
+}
+
+// Capabilities returns the features supported by the wrapped store
+func (s *NamespaceStore) Capabilities() Capabilities {
+	return CapabilitiesOf(s.store)
+}
+
+// GetStore returns the wrapped store
+func (s *NamespaceStore) GetStore() StoreInterface {
+	return s.store
+}
+
+// GetType returns the store type
+func (s *NamespaceStore) GetType() string {
+	return NamespaceType
+}
@@ -0,0 +1,218 @@
+package store
+
+import (
+	"context"
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sharedStore is a memory store able to scan, count and invalidate keys by prefix,
+// recording the tags of the last written value and the last invalidated tags
+type sharedStore struct {
+	*memoryStore
+	tags            []string
+	invalidatedTags []string
+}
+
+func newSharedStore(values map[any]any) *sharedStore {
+	return &sharedStore{memoryStore: &memoryStore{values: values}}
+}
+
+func (s *sharedStore) Set(ctx context.Context, key any, value any, options ...Option) error {
+	s.tags = ApplyOptions(options...).Tags
+	return s.memoryStore.Set(ctx, key, value, options...)
+}
+
+func (s *sharedStore) Invalidate(_ context.Context, options ...InvalidateOption) error {
+	opts := ApplyInvalidateOptions(options...)
+	s.invalidatedTags = opts.Tags
+	for key := range s.values {
+		if opts.HasKeyFilter() && opts.MatchKey(key.(string)) {
+			delete(s.values, key)
+		}
+	}
+	return nil
+}
+
+func (s *sharedStore) Keys(_ context.Context, options ...ScanOption) iter.Seq2[any, error] {
+	opts := ApplyScanOptions(options...)
+	return func(yield func(any, error) bool) {
+		for key := range s.values {
+			if MatchPattern(opts.Match, key.(string)) && !yield(key, nil) {
+				return
+			}
+		}
+	}
+}
+
+func (s *sharedStore) Increment(_ context.Context, key any, delta int64, _ ...Option) (int64, error) {
+	counter, _ := CounterValue(s.values[key])
+	s.values[key] = counter + delta
+	return counter + delta, nil
+}
+
+func (s *sharedStore) Decrement(ctx context.Context, key any, delta int64, options ...Option) (int64, error) {
+	return s.Increment(ctx, key, -delta, options...)
+}
+
+func TestNamespaceSetAndGet(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	shared := newSharedStore(map[any]any{"my-key": "other-value"})
+	store := NewNamespace(shared, "tenant-1")
+
+	// When
+	err := store.Set(ctx, "my-key", "my-value", WithTags([]string{"my-tag"}))
+	value, getErr := store.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Nil(t, getErr)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, "other-value", shared.values["my-key"])
+	assert.Equal(t, "my-value", shared.values["tenant-1:my-key"])
+	assert.Equal(t, []string{"tenant-1:my-tag", "tenant-1"}, shared.tags)
+}
+
+func TestNamespaceWhenNamespaceContainsSeparator(t *testing.T) {
+	// When - Then
+	assert.PanicsWithValue(t, `store: the namespace "tenant:1" cannot contain ":"`, func() {
+		NewNamespace(newSharedStore(map[any]any{}), "tenant:1")
+	})
+}
+
+func TestNamespaceWhenKeyIsNotAString(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	store := NewNamespace(newSharedStore(map[any]any{}), "tenant-1")
+
+	// When
+	err := store.Set(ctx, 42, "my-value")
+
+	// Then
+	assert.ErrorIs(t, err, errNamespaceKeyType)
+}
+
+func TestNamespaceClear(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	shared := newSharedStore(map[any]any{
+		"tenant-1:key-1": "value-1",
+		"tenant-1:key-2": "value-2",
+		"tenant-2:key-1": "value-1",
+		"key-1":          "value-1",
+	})
+	store := NewNamespace(shared, "tenant-1")
+
+	// When
+	err := store.Clear(ctx)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"tenant-2:key-1": "value-1", "key-1": "value-1"}, shared.values)
+	assert.Equal(t, []string{"tenant-1"}, shared.invalidatedTags)
+}
+
+func TestNamespaceClearWithGenerations(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	shared := newSharedStore(map[any]any{})
+	store := NewNamespace(shared, "tenant-1", WithNamespaceGenerations())
+
+	assert.Nil(t, store.Set(ctx, "my-key", "my-value"))
+
+	// When
+	err := store.Clear(ctx)
+	_, getErr := store.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.ErrorIs(t, getErr, NotFound{})
+	assert.Equal(t, "my-value", shared.values["tenant-1:0:my-key"])
+	assert.Equal(t, int64(1), shared.values["tenant-1:generation"])
+	assert.Equal(t, []string{"tenant-1"}, shared.invalidatedTags)
+
+	assert.Nil(t, store.Set(ctx, "my-key", "new-value"))
+	assert.Equal(t, "new-value", shared.values["tenant-1:1:my-key"])
+}
+
+func TestNamespaceClearWithGenerationsWhenCountersNotSupported(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	store := NewNamespace(&memoryStore{values: map[any]any{}}, "tenant-1", WithNamespaceGenerations())
+
+	// When
+	err := store.Clear(ctx)
+
+	// Then
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
+
+func TestNamespaceInvalidate(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	shared := newSharedStore(map[any]any{
+		"tenant-1:user:1:profile": "value-1",
+		"tenant-1:user:1:orders":  "value-2",
+		"user:1:profile":          "value-3",
+	})
+	store := NewNamespace(shared, "tenant-1")
+
+	// When
+	err := store.Invalidate(ctx, WithInvalidatePattern("user:*:profile"))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{
+		"tenant-1:user:1:orders": "value-2",
+		"user:1:profile":         "value-3",
+	}, shared.values)
+}
+
+func TestNamespaceKeys(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	shared := newSharedStore(map[any]any{
+		"tenant-1:key-1": "value-1",
+		"tenant-2:key-2": "value-2",
+		"key-3":          "value-3",
+	})
+	store := NewNamespace(shared, "tenant-1")
+
+	// When
+	keys := []any{}
+	for key, err := range store.Keys(ctx) {
+		assert.Nil(t, err)
+		keys = append(keys, key)
+	}
+
+	// Then
+	assert.Equal(t, []any{"key-1"}, keys)
+}
+
+func TestNamespaceGetMany(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	shared := newSharedStore(map[any]any{
+		"tenant-1:key-1": "value-1",
+		"key-2":          "value-2",
+	})
+	store := NewNamespace(shared, "tenant-1")
+
+	// When
+	values, err := store.GetMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1"}, values)
+}
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"math/rand/v2"
 	"time"
 )
 
@@ -14,10 +15,75 @@ type Options struct {
 	Tags                      []string
 	TagsTTL                   time.Duration
 	ClientSideCacheExpiration time.Duration
+	Strict                    bool
+	ExpirationJitter          float64
+	MaxExpirationJitter       time.Duration
+	SlidingExpiration         time.Duration
+	SlidingExpirationSupport  bool
+	ExpireAt                  time.Time
 }
 
+// minDeadlineExpiration is the expiration given to values whose deadline is less than
+// a second away or has already passed, as stores would otherwise truncate it to zero
+// which means no expiration
+const minDeadlineExpiration = time.Second
+
 func (o *Options) IsEmpty() bool {
-	return o.Cost == 0 && o.Expiration == 0 && len(o.Tags) == 0
+	return o.Cost == 0 && o.Expiration == 0 && o.ExpireAt.IsZero() && len(o.Tags) == 0
+}
+
+// EffectiveExpiration returns the expiration to give to the store when setting a value:
+// the expiration randomly shortened by the expiration jitter, if any, so that values
+// set together do not all expire at the same time. When the value has a deadline, the
+// expiration is the time remaining until the EffectiveExpireAt deadline when called.
+func (o *Options) EffectiveExpiration() time.Duration {
+	return o.EffectiveExpirationWithPrecision(1)
+}
+
+// EffectiveExpirationWithPrecision returns the EffectiveExpiration for stores only keeping
+// expirations to the given precision, such as whole seconds: the jitter never shortens the
+// expiration below the precision, as the store would otherwise truncate it to zero which
+// means no expiration.
+func (o *Options) EffectiveExpirationWithPrecision(precision time.Duration) time.Duration {
+	if !o.ExpireAt.IsZero() {
+		return max(time.Until(o.EffectiveExpireAt()), minDeadlineExpiration, precision)
+	}
+
+	return o.Expiration - o.jitter(o.Expiration, precision)
+}
+
+// EffectiveExpireAt returns the deadline to give to stores supporting absolute expirations
+// when setting a value, brought forward by the expiration jitter as for EffectiveExpiration.
+// The zero time is returned when the value has no deadline.
+func (o *Options) EffectiveExpireAt() time.Time {
+	if o.ExpireAt.IsZero() {
+		return o.ExpireAt
+	}
+
+	return o.ExpireAt.Add(-o.jitter(time.Until(o.ExpireAt), 1))
+}
+
+// jitter returns a random duration to subtract from the given expiration, leaving at least
+// the given precision
+func (o *Options) jitter(expiration, precision time.Duration) time.Duration {
+	if expiration <= 0 {
+		return 0
+	}
+
+	jitter := o.MaxExpirationJitter
+	if o.ExpirationJitter > 0 {
+		fractionJitter := time.Duration(o.ExpirationJitter * float64(expiration))
+		if jitter <= 0 || fractionJitter < jitter {
+			jitter = fractionJitter
+		}
+	}
+	// never shorten the expiration to zero, which would mean no expiration
+	jitter = min(jitter, expiration-precision)
+	if jitter <= 0 {
+		return 0
+	}
+
+	return rand.N(jitter + 1)
 }
 
 func ApplyOptionsWithDefault(defaultOptions *Options, opts ...Option) *Options {
@@ -58,9 +124,61 @@ func WithSynchronousSet() Option {
 }
 
 // WithExpiration allows to specify an expiration time when setting a value.
+// It replaces the deadline given with WithExpireAt, if any.
 func WithExpiration(expiration time.Duration) Option {
 	return func(o *Options) {
 		o.Expiration = expiration
+		o.ExpireAt = time.Time{}
+	}
+}
+
+// WithExpireAt makes the value expire at the given deadline, replacing the expiration
+// given with WithExpiration or WithSlidingExpiration, if any. The deadline is converted to an expiration by the
+// store when the value is written, so that it does not drift when the write is delayed.
+func WithExpireAt(deadline time.Time) Option {
+	return func(o *Options) {
+		o.Expiration = 0
+		o.SlidingExpiration = 0
+		o.ExpireAt = deadline
+	}
+}
+
+// WithSlidingExpiration makes the value expire after the given duration without being read:
+// its expiration is renewed each time it is returned by Get or GetWithTTL.
+// Stores record the sliding expiration of the value, natively or next to it, in which case
+// they must be created with WithSlidingExpirationSupport.
+func WithSlidingExpiration(expiration time.Duration) Option {
+	return func(o *Options) {
+		o.Expiration = expiration
+		o.ExpireAt = time.Time{}
+		o.SlidingExpiration = expiration
+	}
+}
+
+// WithSlidingExpirationSupport enables sliding expirations on the stores which are not able
+// to renew expirations natively and record them next to the values instead. As reads then
+// look the sliding expiration of each key up, and writes and deletions update it, such stores
+// only honour WithSlidingExpiration when given this option as a default option.
+func WithSlidingExpirationSupport() Option {
+	return func(o *Options) {
+		o.SlidingExpirationSupport = true
+	}
+}
+
+// WithExpirationJitter randomly shortens the expiration of each value by up to the given
+// fraction of it, between 0 and 1, so that values set with the same expiration do not all
+// expire at the same time. It is usually given as a default option of the store.
+func WithExpirationJitter(fraction float64) Option {
+	return func(o *Options) {
+		o.ExpirationJitter = fraction
+	}
+}
+
+// WithMaxExpirationJitter randomly shortens the expiration of each value by up to the given
+// duration. When combined with WithExpirationJitter, the smallest of both jitters is used.
+func WithMaxExpirationJitter(jitter time.Duration) Option {
+	return func(o *Options) {
+		o.MaxExpirationJitter = jitter
 	}
 }
 
@@ -85,3 +203,12 @@ func WithClientSideCaching(clientSideCacheExpiration time.Duration) Option {
 		o.ClientSideCacheExpiration = clientSideCacheExpiration
 	}
 }
+
+// WithStrictOptions makes Set return an error wrapping ErrUnsupported when it is given an
+// option the store cannot honour, instead of ignoring it. It can be given to Set or as a
+// default option of the store.
+func WithStrictOptions() Option {
+	return func(o *Options) {
+		o.Strict = true
+	}
+}
@@ -60,3 +60,95 @@ func Test_applyOptionsWithDefault(t *testing.T) {
 	assert.Equal(t, int64(7), options.Cost)
 	assert.Equal(t, 25*time.Second, options.Expiration)
 }
+
+func TestOptionsEffectiveExpiration(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options *Options
+		min     time.Duration
+		max     time.Duration
+	}{
+		{name: "no jitter", options: ApplyOptions(WithExpiration(time.Minute)), min: time.Minute, max: time.Minute},
+		{name: "no expiration", options: ApplyOptions(WithExpirationJitter(0.5)), min: 0, max: 0},
+		{name: "fraction", options: ApplyOptions(WithExpiration(time.Minute), WithExpirationJitter(0.1)), min: 54 * time.Second, max: time.Minute},
+		{name: "max duration", options: ApplyOptions(WithExpiration(time.Minute), WithMaxExpirationJitter(time.Second)), min: 59 * time.Second, max: time.Minute},
+		{name: "smallest jitter", options: ApplyOptions(WithExpiration(time.Minute), WithExpirationJitter(0.5), WithMaxExpirationJitter(time.Second)), min: 59 * time.Second, max: time.Minute},
+		{name: "whole expiration", options: ApplyOptions(WithExpiration(time.Minute), WithExpirationJitter(1)), min: 1, max: time.Minute},
+		{name: "deadline", options: ApplyOptions(WithExpireAt(time.Now().Add(time.Hour))), min: 59 * time.Minute, max: time.Hour},
+		{name: "deadline with jitter", options: ApplyOptions(WithExpireAt(time.Now().Add(time.Hour)), WithMaxExpirationJitter(time.Minute)), min: 58 * time.Minute, max: time.Hour},
+		{name: "passed deadline", options: ApplyOptions(WithExpireAt(time.Now().Add(-time.Hour))), min: time.Second, max: time.Second},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				expiration := tc.options.EffectiveExpiration()
+				assert.GreaterOrEqual(t, expiration, tc.min)
+				assert.LessOrEqual(t, expiration, tc.max)
+			}
+		})
+	}
+}
+
+func TestOptionsEffectiveExpirationWithPrecision(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options *Options
+		min     time.Duration
+		max     time.Duration
+	}{
+		{name: "no jitter", options: ApplyOptions(WithExpiration(time.Minute)), min: time.Minute, max: time.Minute},
+		{name: "whole expiration", options: ApplyOptions(WithExpiration(time.Minute), WithExpirationJitter(1)), min: time.Second, max: time.Minute},
+		{name: "expiration below precision", options: ApplyOptions(WithExpiration(time.Second), WithExpirationJitter(1)), min: time.Second, max: time.Second},
+		{name: "passed deadline", options: ApplyOptions(WithExpireAt(time.Now().Add(-time.Hour))), min: time.Second, max: time.Second},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				expiration := tc.options.EffectiveExpirationWithPrecision(time.Second)
+				assert.GreaterOrEqual(t, expiration, tc.min)
+				assert.LessOrEqual(t, expiration, tc.max)
+			}
+		})
+	}
+}
+
+func TestOptionsEffectiveExpirationIsSpread(t *testing.T) {
+	// Given
+	options := ApplyOptionsWithDefault(&Options{ExpirationJitter: 0.5}, WithExpiration(time.Hour))
+
+	// When
+	expirations := map[time.Duration]bool{}
+	for i := 0; i < 10; i++ {
+		expirations[options.EffectiveExpiration()] = true
+	}
+
+	// Then
+	assert.Greater(t, len(expirations), 1)
+}
+
+func TestWithExpireAt(t *testing.T) {
+	// Given
+	deadline := time.Now().Add(time.Hour)
+
+	// When
+	options := ApplyOptionsWithDefault(&Options{Expiration: time.Minute}, WithSlidingExpiration(time.Minute), WithExpireAt(deadline))
+
+	// Then
+	assert.Equal(t, time.Duration(0), options.Expiration)
+	assert.Equal(t, time.Duration(0), options.SlidingExpiration)
+	assert.Equal(t, deadline, options.ExpireAt)
+	assert.Equal(t, deadline, options.EffectiveExpireAt())
+	assert.False(t, options.IsEmpty())
+}
+
+func TestWithExpirationReplacesExpireAt(t *testing.T) {
+	// When
+	options := ApplyOptions(WithExpireAt(time.Now().Add(time.Hour)), WithExpiration(time.Minute))
+
+	// Then
+	assert.Equal(t, time.Minute, options.Expiration)
+	assert.True(t, options.ExpireAt.IsZero())
+	assert.True(t, options.EffectiveExpireAt().IsZero())
+}
@@ -0,0 +1,36 @@
+package store
+
+// ScanOption represents a keys scanning option function.
+type ScanOption func(o *ScanOptions)
+
+type ScanOptions struct {
+	Match string
+	Count int64
+}
+
+func ApplyScanOptions(opts ...ScanOption) *ScanOptions {
+	o := &ScanOptions{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithScanMatch allows to only iterate over the keys matching the given glob-style pattern.
+// Supported patterns are the ones of the Redis SCAN command: *, ?, [abc], [^a], [a-z]
+// and \ to escape special characters.
+func WithScanMatch(pattern string) ScanOption {
+	return func(o *ScanOptions) {
+		o.Match = pattern
+	}
+}
+
+// WithScanCount allows to give a hint of the number of keys to retrieve per round trip.
+// Actually it is only used by stores scanning a remote server.
+func WithScanCount(count int64) ScanOption {
+	return func(o *ScanOptions) {
+		o.Count = count
+	}
+}
@@ -0,0 +1,15 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanOptions(t *testing.T) {
+	// Given
+	options := ApplyScanOptions(WithScanMatch("my-*"), WithScanCount(50))
+
+	// When - Then
+	assert.Equal(t, &ScanOptions{Match: "my-*", Count: 50}, options)
+}
@@ -0,0 +1,59 @@
+package store
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// slidingExpirationKeyPrefix prefixes the keys holding the sliding expiration of the values
+// of stores that are not able to renew expirations natively
+const slidingExpirationKeyPrefix = "gocache_sliding_"
+
+// SlidingExpirationKey returns the key holding the sliding expiration of the given key,
+// for stores recording it next to the value
+func SlidingExpirationKey(key string) string {
+	return slidingExpirationKeyPrefix + key
+}
+
+// SlidingExpirationSlotKey returns the key holding the sliding expiration of the given key
+// for Redis stores. It belongs to the same cluster hash slot as the key, so that both keys
+// can be read and renewed at once by a script: it holds the hash tag of the key, or the whole
+// key as hash tag when it has none. False is returned for the keys without hash tag holding
+// a closing brace, for which no such key can be built.
+func SlidingExpirationSlotKey(key string) (string, bool) {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			// the hash tag of the key is kept by the prefix
+			return slidingExpirationKeyPrefix + key, true
+		}
+	}
+
+	if strings.ContainsRune(key, '}') {
+		return "", false
+	}
+
+	return slidingExpirationKeyPrefix + "{" + key + "}", true
+}
+
+// IsSlidingExpirationKey returns true if the given key holds the sliding expiration
+// of another key. Such keys must not be returned when iterating over keys.
+func IsSlidingExpirationKey(key string) bool {
+	return strings.HasPrefix(key, slidingExpirationKeyPrefix)
+}
+
+// SlidingExpirationValue returns the value to store under the sliding expiration key
+func SlidingExpirationValue(expiration time.Duration) string {
+	return strconv.FormatInt(int64(expiration), 10)
+}
+
+// ParseSlidingExpiration converts a value read under a sliding expiration key
+// to the sliding expiration it holds
+func ParseSlidingExpiration(value any) (time.Duration, error) {
+	nanoseconds, err := CounterValue(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(nanoseconds), nil
+}
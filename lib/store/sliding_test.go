@@ -0,0 +1,78 @@
+package store
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSlidingExpiration(t *testing.T) {
+	// When
+	options := ApplyOptions(WithSlidingExpiration(10 * time.Minute))
+
+	// Then
+	assert.Equal(t, 10*time.Minute, options.Expiration)
+	assert.Equal(t, 10*time.Minute, options.SlidingExpiration)
+}
+
+func TestSlidingExpirationKey(t *testing.T) {
+	// When
+	key := SlidingExpirationKey("my-key")
+
+	// Then
+	assert.Equal(t, "gocache_sliding_my-key", key)
+	assert.True(t, IsSlidingExpirationKey(key))
+	assert.False(t, IsSlidingExpirationKey("my-key"))
+}
+
+func TestWithSlidingExpirationSupport(t *testing.T) {
+	// When
+	options := ApplyOptions(WithSlidingExpirationSupport())
+
+	// Then
+	assert.True(t, options.SlidingExpirationSupport)
+}
+
+func TestSlidingExpirationSlotKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected string
+		ok       bool
+	}{
+		{key: "my-key", expected: "gocache_sliding_{my-key}", ok: true},
+		{key: "user:{42}:name", expected: "gocache_sliding_user:{42}:name", ok: true},
+		{key: "a{b", expected: "gocache_sliding_{a{b}", ok: true},
+		{key: "{}my-key", ok: false},
+		{key: "my}key", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			// When
+			key, ok := SlidingExpirationSlotKey(tt.key)
+
+			// Then
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, key)
+			if ok {
+				assert.True(t, IsSlidingExpirationKey(key))
+			}
+		})
+	}
+}
+
+func TestParseSlidingExpiration(t *testing.T) {
+	// Given
+	value := SlidingExpirationValue(90 * time.Second)
+
+	// When
+	expiration, err := ParseSlidingExpiration([]byte(value))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 90*time.Second, expiration)
+
+	_, err = ParseSlidingExpiration("1m30s")
+	assert.ErrorIs(t, err, ErrInvalidValue)
+}
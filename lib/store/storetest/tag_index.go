@@ -0,0 +1,129 @@
+// Package storetest provides tests shared by the store implementations,
+// so that every store behaves the same way.
+package storetest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/eko/gocache/lib/v4/store"
+)
+
+// TagIndexBackend runs the tests every store.TagIndexBackend has to pass against
+// the backends returned by the given function, each one being empty
+func TagIndexBackend(t *testing.T, newBackend func(t *testing.T) store.TagIndexBackend) {
+	ctx := context.Background()
+
+	t.Run("MembersWhenSetDoesNotExist", func(t *testing.T) {
+		backend := newBackend(t)
+
+		members, err := backend.Members(ctx, "gocache_tag_missing")
+
+		assert.Nil(t, err)
+		assert.Empty(t, members)
+	})
+
+	t.Run("AddMembersIgnoresDuplicates", func(t *testing.T) {
+		backend := newBackend(t)
+
+		assert.Nil(t, backend.AddMembers(ctx, "gocache_tag_book", []string{"key-1", "key-2"}, time.Minute))
+		assert.Nil(t, backend.AddMembers(ctx, "gocache_tag_book", []string{"key-2", "key-3"}, time.Minute))
+
+		members, err := backend.Members(ctx, "gocache_tag_book")
+
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []string{"key-1", "key-2", "key-3"}, members)
+	})
+
+	t.Run("MembersContainingCommas", func(t *testing.T) {
+		backend := newBackend(t)
+
+		assert.Nil(t, backend.AddMembers(ctx, "gocache_tag_book", []string{"key,1", `key\2`}, time.Minute))
+		assert.Nil(t, backend.RemoveMembers(ctx, "gocache_tag_book", []string{"key"}))
+
+		members, err := backend.Members(ctx, "gocache_tag_book")
+
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []string{"key,1", `key\2`}, members)
+	})
+
+	t.Run("AddMembersWithConcurrentWriters", func(t *testing.T) {
+		backend := newBackend(t)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				assert.Nil(t, backend.AddMembers(ctx, "gocache_tag_book", []string{fmt.Sprintf("key-%d", i)}, time.Minute))
+			}(i)
+		}
+		wg.Wait()
+
+		members, err := backend.Members(ctx, "gocache_tag_book")
+
+		assert.Nil(t, err)
+		assert.Len(t, members, 20)
+	})
+
+	t.Run("RemoveMembers", func(t *testing.T) {
+		backend := newBackend(t)
+
+		assert.Nil(t, backend.AddMembers(ctx, "gocache_tag_book", []string{"key-1", "key-2"}, time.Minute))
+		assert.Nil(t, backend.RemoveMembers(ctx, "gocache_tag_book", []string{"key-1", "key-3"}))
+		assert.Nil(t, backend.RemoveMembers(ctx, "gocache_tag_missing", []string{"key-1"}))
+
+		members, err := backend.Members(ctx, "gocache_tag_book")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"key-2"}, members)
+	})
+
+	t.Run("DeleteSet", func(t *testing.T) {
+		backend := newBackend(t)
+
+		assert.Nil(t, backend.AddMembers(ctx, "gocache_tag_book", []string{"key-1"}, time.Minute))
+		assert.Nil(t, backend.DeleteSet(ctx, "gocache_tag_book"))
+		assert.Nil(t, backend.DeleteSet(ctx, "gocache_tag_missing"))
+
+		members, err := backend.Members(ctx, "gocache_tag_book")
+
+		assert.Nil(t, err)
+		assert.Empty(t, members)
+	})
+
+	t.Run("TagIndex", func(t *testing.T) {
+		index := store.NewTagIndex(newBackend(t), "gocache_tag_%s")
+
+		assert.Nil(t, index.Add(ctx, "key-1", []string{"book", "author"}, time.Minute))
+		assert.Nil(t, index.Add(ctx, "key-2", []string{"book"}, 0))
+		assert.Nil(t, index.Add(ctx, "key-3", []string{"author"}, 0))
+
+		// key-3 is deleted so it has to be removed from the author tag
+		assert.Nil(t, index.Remove(ctx, "key-3"))
+
+		deleted := []string{}
+		err := index.Invalidate(ctx, []string{"author"}, func(_ context.Context, key string) error {
+			deleted = append(deleted, key)
+			return index.Remove(ctx, key)
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"key-1"}, deleted)
+
+		// key-1 has been removed from the book tag when deleted
+		deleted = []string{}
+		err = index.Invalidate(ctx, []string{"book"}, func(_ context.Context, key string) error {
+			deleted = append(deleted, key)
+			return nil
+		})
+
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"key-2"}, deleted)
+	})
+}
@@ -0,0 +1,37 @@
+package storetest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eko/gocache/lib/v4/store"
+)
+
+func TestValueTagIndexBackend(t *testing.T) {
+	TagIndexBackend(t, func(t *testing.T) store.TagIndexBackend {
+		var values, ttls sync.Map
+
+		return store.NewValueTagIndexBackend(
+			func(_ context.Context, key string) ([]byte, time.Duration, error) {
+				value, ok := values.Load(key)
+				if !ok {
+					return nil, 0, store.NotFoundWithCause(errors.New("value not found"))
+				}
+				ttl, _ := ttls.Load(key)
+				return value.([]byte), ttl.(time.Duration), nil
+			},
+			func(_ context.Context, key string, value []byte, ttl time.Duration) error {
+				values.Store(key, value)
+				ttls.Store(key, ttl)
+				return nil
+			},
+			func(_ context.Context, key string) error {
+				values.Delete(key)
+				return nil
+			},
+		)
+	})
+}
@@ -0,0 +1,374 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTagsTTL is the time-to-live of the tag index entries when no TagsTTL option is given
+const DefaultTagsTTL = 720 * time.Hour
+
+// keyTagsMarker is inserted in the tag pattern to build the keys holding the tags of a
+// cache key. Tags starting with this marker are reserved. It has no braces so that the
+// sets are not all hashed to the same slot of a Redis Cluster.
+const keyTagsMarker = "__key__:"
+
+// TagIndexBackend persists the sets of strings used by a TagIndex.
+// Adding and removing members have to be safe with concurrent writers.
+type TagIndexBackend interface {
+	// AddMembers adds the given members to the set, creating it if needed,
+	// and sets its time-to-live
+	AddMembers(ctx context.Context, setKey string, members []string, ttl time.Duration) error
+	// Members returns the members of the set, or none if the set does not exist
+	Members(ctx context.Context, setKey string) ([]string, error)
+	// RemoveMembers removes the given members from the set
+	RemoveMembers(ctx context.Context, setKey string, members []string) error
+	// DeleteSet removes the whole set
+	DeleteSet(ctx context.Context, setKey string) error
+}
+
+// BatchTagIndexBackend is implemented by the backends able to read and update many sets
+// in a single round-trip, which is then used when removing keys from the index
+type BatchTagIndexBackend interface {
+	TagIndexBackend
+	// ManyMembers returns the members of each of the given sets, in the same order
+	ManyMembers(ctx context.Context, setKeys []string) ([][]string, error)
+	// RemoveManyMembers removes the given members from their sets, then deletes the given sets
+	RemoveManyMembers(ctx context.Context, removals []TagSetRemoval, deleteSetKeys []string) error
+}
+
+// TagSetRemoval lists the members to remove from a set of the tag index
+type TagSetRemoval struct {
+	SetKey  string
+	Members []string
+}
+
+// TagIndex associates cache keys with tags so that they can be invalidated together.
+// Both the keys of each tag and the tags of each key are stored, so that a deleted
+// key can be removed from the sets of its tags.
+type TagIndex struct {
+	backend TagIndexBackend
+	pattern string
+}
+
+// NewTagIndex creates a tag index storing its sets in the given backend, under keys
+// built using the given pattern such as "gocache_tag_%s"
+func NewTagIndex(backend TagIndexBackend, pattern string) *TagIndex {
+	return &TagIndex{
+		backend: backend,
+		pattern: pattern,
+	}
+}
+
+// Prefix returns the prefix of all the keys used by the index
+func (i *TagIndex) Prefix() string {
+	return fmt.Sprintf(i.pattern, "")
+}
+
+// IsIndexKey reports whether the given key is used by the index,
+// so that it can be excluded when listing the store keys
+func (i *TagIndex) IsIndexKey(key string) bool {
+	return strings.HasPrefix(key, i.Prefix())
+}
+
+// Add associates the given key with the given tags. A ttl of 0 means DefaultTagsTTL.
+func (i *TagIndex) Add(ctx context.Context, key string, tags []string, ttl time.Duration) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	if ttl <= 0 {
+		ttl = DefaultTagsTTL
+	}
+
+	errs := []error{}
+	for _, tag := range tags {
+		if err := i.backend.AddMembers(ctx, i.tagKey(tag), []string{key}, ttl); err != nil {
+			errs = append(errs, fmt.Errorf("unable to add key '%s' to tag '%s': %w", key, tag, err))
+		}
+	}
+
+	if err := i.backend.AddMembers(ctx, i.keyTagsKey(key), tags, ttl); err != nil {
+		errs = append(errs, fmt.Errorf("unable to store tags of key '%s': %w", key, err))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Remove removes the given keys from the sets of their tags, once they have been deleted.
+// The sets are read and updated in a single round-trip when the backend implements
+// BatchTagIndexBackend, and using one call per set otherwise.
+func (i *TagIndex) Remove(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	keyTagsKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		keyTagsKeys = append(keyTagsKeys, i.keyTagsKey(key))
+	}
+
+	tagsOfKeys, errs := i.manyMembers(ctx, keys, keyTagsKeys)
+
+	removals := []TagSetRemoval{}
+	removalTags := []string{}
+	positions := map[string]int{}
+	deleteSetKeys := []string{}
+	deletedKeys := []string{}
+	for idx, tags := range tagsOfKeys {
+		if len(tags) == 0 {
+			continue
+		}
+
+		for _, tag := range tags {
+			tagKey := i.tagKey(tag)
+			position, ok := positions[tagKey]
+			if !ok {
+				position = len(removals)
+				positions[tagKey] = position
+				removals = append(removals, TagSetRemoval{SetKey: tagKey})
+				removalTags = append(removalTags, tag)
+			}
+			removals[position].Members = append(removals[position].Members, keys[idx])
+		}
+
+		deleteSetKeys = append(deleteSetKeys, keyTagsKeys[idx])
+		deletedKeys = append(deletedKeys, keys[idx])
+	}
+	if len(deleteSetKeys) == 0 {
+		return errors.Join(errs...)
+	}
+
+	if batch, ok := i.backend.(BatchTagIndexBackend); ok {
+		if err := batch.RemoveManyMembers(ctx, removals, deleteSetKeys); err != nil {
+			errs = append(errs, fmt.Errorf("unable to remove keys '%s' from their tags: %w", strings.Join(deletedKeys, "', '"), err))
+		}
+		return errors.Join(errs...)
+	}
+
+	for idx, removal := range removals {
+		if err := i.backend.RemoveMembers(ctx, removal.SetKey, removal.Members); err != nil {
+			errs = append(errs, fmt.Errorf("unable to remove keys '%s' from tag '%s': %w", strings.Join(removal.Members, "', '"), removalTags[idx], err))
+		}
+	}
+
+	for idx, keyTagsKey := range deleteSetKeys {
+		if err := i.backend.DeleteSet(ctx, keyTagsKey); err != nil {
+			errs = append(errs, fmt.Errorf("unable to delete tags of key '%s': %w", deletedKeys[idx], err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// manyMembers returns the members of each of the given sets holding the tags of the given keys
+func (i *TagIndex) manyMembers(ctx context.Context, keys []string, setKeys []string) ([][]string, []error) {
+	if batch, ok := i.backend.(BatchTagIndexBackend); ok {
+		members, err := batch.ManyMembers(ctx, setKeys)
+		if err != nil {
+			return nil, []error{fmt.Errorf("unable to get tags of keys '%s': %w", strings.Join(keys, "', '"), err)}
+		}
+		return members, nil
+	}
+
+	errs := []error{}
+	members := make([][]string, len(setKeys))
+	for idx, setKey := range setKeys {
+		tags, err := i.backend.Members(ctx, setKey)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to get tags of key '%s': %w", keys[idx], err))
+			continue
+		}
+		members[idx] = tags
+	}
+
+	return members, errs
+}
+
+// Invalidate deletes all the keys associated with the given tags using the given
+// function, then deletes the tags. Keys that are not found are ignored and the
+// other errors are returned once every tag has been processed.
+func (i *TagIndex) Invalidate(ctx context.Context, tags []string, deleteKey func(ctx context.Context, key string) error) error {
+	errs := []error{}
+	for _, tag := range tags {
+		tagKey := i.tagKey(tag)
+
+		keys, err := i.backend.Members(ctx, tagKey)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to get keys of tag '%s': %w", tag, err))
+			continue
+		}
+
+		for _, key := range keys {
+			if err := deleteKey(ctx, key); err != nil && !errors.Is(err, NotFound{}) {
+				errs = append(errs, fmt.Errorf("unable to delete key '%s' of tag '%s': %w", key, tag, err))
+			}
+		}
+
+		if err := i.backend.DeleteSet(ctx, tagKey); err != nil {
+			errs = append(errs, fmt.Errorf("unable to delete tag '%s': %w", tag, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (i *TagIndex) tagKey(tag string) string {
+	return fmt.Sprintf(i.pattern, tag)
+}
+
+func (i *TagIndex) keyTagsKey(key string) string {
+	return fmt.Sprintf(i.pattern, keyTagsMarker+key)
+}
+
+// ValueTagIndexBackend is a TagIndexBackend storing each set as a single value encoded
+// using EncodeTagMembers, read and written using the given functions. Writers are serialized using a mutex,
+// so it is meant to be used by stores living in the process memory.
+type ValueTagIndexBackend struct {
+	mu     sync.Mutex
+	get    func(ctx context.Context, key string) ([]byte, time.Duration, error)
+	set    func(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	delete func(ctx context.Context, key string) error
+}
+
+// NewValueTagIndexBackend creates a tag index backend using the given functions
+// to read, write and delete raw values. The get function returns the value along
+// with its remaining time-to-live, or a NotFound error when the value does not exist.
+// Sets are always written with a time-to-live, so a remaining time-to-live of 0 means
+// that the set expires in less than the precision of the store.
+func NewValueTagIndexBackend(
+	get func(ctx context.Context, key string) ([]byte, time.Duration, error),
+	set func(ctx context.Context, key string, value []byte, ttl time.Duration) error,
+	delete func(ctx context.Context, key string) error,
+) *ValueTagIndexBackend {
+	return &ValueTagIndexBackend{
+		get:    get,
+		set:    set,
+		delete: delete,
+	}
+}
+
+// AddMembers adds the given members to the set, creating it if needed, and sets its time-to-live
+func (b *ValueTagIndexBackend) AddMembers(ctx context.Context, setKey string, members []string, ttl time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	current, _, err := b.members(ctx, setKey)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		if !slices.Contains(current, member) {
+			current = append(current, member)
+		}
+	}
+
+	return b.set(ctx, setKey, EncodeTagMembers(current), ttl)
+}
+
+// Members returns the members of the set, or none if the set does not exist
+func (b *ValueTagIndexBackend) Members(ctx context.Context, setKey string) ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	members, _, err := b.members(ctx, setKey)
+	return members, err
+}
+
+// RemoveMembers removes the given members from the set, keeping its expiration,
+// and deletes it once empty or about to expire
+func (b *ValueTagIndexBackend) RemoveMembers(ctx context.Context, setKey string, members []string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	current, ttl, err := b.members(ctx, setKey)
+	if err != nil || len(current) == 0 {
+		return err
+	}
+
+	remaining := slices.DeleteFunc(current, func(member string) bool {
+		return slices.Contains(members, member)
+	})
+	if len(remaining) == 0 || ttl <= 0 {
+		return b.deleteSet(ctx, setKey)
+	}
+
+	return b.set(ctx, setKey, EncodeTagMembers(remaining), ttl)
+}
+
+// DeleteSet removes the whole set
+func (b *ValueTagIndexBackend) DeleteSet(ctx context.Context, setKey string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.deleteSet(ctx, setKey)
+}
+
+func (b *ValueTagIndexBackend) members(ctx context.Context, setKey string) ([]string, time.Duration, error) {
+	value, ttl, err := b.get(ctx, setKey)
+	if errors.Is(err, NotFound{}) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return DecodeTagMembers(value), ttl, nil
+}
+
+func (b *ValueTagIndexBackend) deleteSet(ctx context.Context, setKey string) error {
+	if err := b.delete(ctx, setKey); err != nil && !errors.Is(err, NotFound{}) {
+		return err
+	}
+
+	return nil
+}
+
+// EncodeTagMembers encodes the members of a set of the tag index as a single
+// comma-separated value. Commas and backslashes within members are escaped
+// using a backslash.
+func EncodeTagMembers(members []string) []byte {
+	var value strings.Builder
+	for idx, member := range members {
+		if idx > 0 {
+			value.WriteByte(',')
+		}
+		for _, c := range []byte(member) {
+			if c == ',' || c == '\\' {
+				value.WriteByte('\\')
+			}
+			value.WriteByte(c)
+		}
+	}
+
+	return []byte(value.String())
+}
+
+// DecodeTagMembers decodes the members of a set encoded using EncodeTagMembers
+func DecodeTagMembers(value []byte) []string {
+	if len(value) == 0 {
+		return nil
+	}
+
+	members := []string{}
+	member := []byte{}
+	for idx := 0; idx < len(value); idx++ {
+		switch c := value[idx]; {
+		case c == '\\' && idx+1 < len(value):
+			idx++
+			member = append(member, value[idx])
+		case c == ',':
+			members = append(members, string(member))
+			member = member[:0]
+		default:
+			member = append(member, c)
+		}
+	}
+
+	return append(members, string(member))
+}
@@ -0,0 +1,266 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setsBackend is an in-memory TagIndexBackend recording the time-to-live of its sets
+type setsBackend struct {
+	sets map[string][]string
+	ttls map[string]time.Duration
+	err  error
+}
+
+func newSetsBackend() *setsBackend {
+	return &setsBackend{sets: map[string][]string{}, ttls: map[string]time.Duration{}}
+}
+
+func (b *setsBackend) AddMembers(_ context.Context, setKey string, members []string, ttl time.Duration) error {
+	b.sets[setKey] = append(b.sets[setKey], members...)
+	b.ttls[setKey] = ttl
+	return b.err
+}
+
+func (b *setsBackend) Members(_ context.Context, setKey string) ([]string, error) {
+	return b.sets[setKey], b.err
+}
+
+func (b *setsBackend) RemoveMembers(_ context.Context, setKey string, members []string) error {
+	b.sets[setKey] = slices.DeleteFunc(b.sets[setKey], func(member string) bool {
+		return slices.Contains(members, member)
+	})
+	return b.err
+}
+
+func (b *setsBackend) DeleteSet(_ context.Context, setKey string) error {
+	delete(b.sets, setKey)
+	return b.err
+}
+
+// batchSetsBackend is a setsBackend counting the round-trips of its batch operations
+type batchSetsBackend struct {
+	*setsBackend
+	batches int
+}
+
+func (b *batchSetsBackend) ManyMembers(ctx context.Context, setKeys []string) ([][]string, error) {
+	b.batches++
+	members := [][]string{}
+	for _, setKey := range setKeys {
+		members = append(members, b.sets[setKey])
+	}
+	return members, b.err
+}
+
+func (b *batchSetsBackend) RemoveManyMembers(ctx context.Context, removals []TagSetRemoval, deleteSetKeys []string) error {
+	b.batches++
+	for _, removal := range removals {
+		_ = b.RemoveMembers(ctx, removal.SetKey, removal.Members)
+	}
+	for _, setKey := range deleteSetKeys {
+		_ = b.DeleteSet(ctx, setKey)
+	}
+	return b.err
+}
+
+func TestTagIndexAdd(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	backend := newSetsBackend()
+	index := NewTagIndex(backend, "gocache_tag_%s")
+
+	// When
+	err := index.Add(ctx, "my-key", []string{"tag1", "tag2"}, 0)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{
+		"gocache_tag_tag1":           {"my-key"},
+		"gocache_tag_tag2":           {"my-key"},
+		"gocache_tag___key__:my-key": {"tag1", "tag2"},
+	}, backend.sets)
+	assert.Equal(t, DefaultTagsTTL, backend.ttls["gocache_tag_tag1"])
+	assert.True(t, index.IsIndexKey("gocache_tag___key__:my-key"))
+	assert.False(t, index.IsIndexKey("my-key"))
+}
+
+func TestTagIndexAddWhenError(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	expectedErr := errors.New("an unexpected error occurred")
+
+	backend := newSetsBackend()
+	backend.err = expectedErr
+
+	index := NewTagIndex(backend, "gocache_tag_%s")
+
+	// When
+	err := index.Add(ctx, "my-key", []string{"tag1"}, time.Minute)
+
+	// Then
+	assert.ErrorIs(t, err, expectedErr)
+	assert.ErrorContains(t, err, "unable to add key 'my-key' to tag 'tag1'")
+}
+
+func TestTagIndexInvalidate(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	expectedErr := errors.New("an unexpected error occurred")
+
+	backend := newSetsBackend()
+	index := NewTagIndex(backend, "gocache_tag_%s")
+
+	assert.Nil(t, index.Add(ctx, "key-1", []string{"tag1"}, 0))
+	assert.Nil(t, index.Add(ctx, "key-2", []string{"tag1"}, 0))
+	assert.Nil(t, index.Add(ctx, "key-3", []string{"tag1"}, 0))
+
+	// When
+	err := index.Invalidate(ctx, []string{"tag1"}, func(_ context.Context, key string) error {
+		switch key {
+		case "key-1":
+			return NotFoundWithCause(errors.New("already expired"))
+		case "key-2":
+			return expectedErr
+		}
+		return nil
+	})
+
+	// Then
+	assert.ErrorIs(t, err, expectedErr)
+	assert.EqualError(t, err, "unable to delete key 'key-2' of tag 'tag1': an unexpected error occurred")
+	assert.NotContains(t, backend.sets, "gocache_tag_tag1")
+}
+
+func TestTagIndexRemove(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	backend := newSetsBackend()
+	index := NewTagIndex(backend, "gocache_tag_%s")
+
+	assert.Nil(t, index.Add(ctx, "key-1", []string{"tag1", "tag2"}, 0))
+	assert.Nil(t, index.Add(ctx, "key-2", []string{"tag1"}, 0))
+	assert.Nil(t, index.Add(ctx, "key-3", []string{"tag2"}, 0))
+
+	// When
+	err := index.Remove(ctx, "key-1", "key-2", "key-4")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{
+		"gocache_tag_tag1":          {},
+		"gocache_tag_tag2":          {"key-3"},
+		"gocache_tag___key__:key-3": {"tag2"},
+	}, backend.sets)
+}
+
+func TestTagIndexRemoveWithBatchBackend(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	backend := &batchSetsBackend{setsBackend: newSetsBackend()}
+	index := NewTagIndex(backend, "gocache_tag_%s")
+
+	assert.Nil(t, index.Add(ctx, "key-1", []string{"tag1", "tag2"}, 0))
+	assert.Nil(t, index.Add(ctx, "key-2", []string{"tag1"}, 0))
+	assert.Nil(t, index.Add(ctx, "key-3", []string{"tag2"}, 0))
+
+	// When
+	err := index.Remove(ctx, "key-1", "key-2", "key-4")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 2, backend.batches)
+	assert.Equal(t, map[string][]string{
+		"gocache_tag_tag1":          {},
+		"gocache_tag_tag2":          {"key-3"},
+		"gocache_tag___key__:key-3": {"tag2"},
+	}, backend.sets)
+}
+
+func TestTagIndexRemoveWhenError(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	expectedErr := errors.New("an unexpected error occurred")
+
+	backend := &batchSetsBackend{setsBackend: newSetsBackend()}
+	index := NewTagIndex(backend, "gocache_tag_%s")
+
+	assert.Nil(t, index.Add(ctx, "key-1", []string{"tag1"}, 0))
+	backend.err = expectedErr
+
+	// When
+	err := index.Remove(ctx, "key-1", "key-2")
+
+	// Then
+	assert.ErrorIs(t, err, expectedErr)
+	assert.ErrorContains(t, err, "unable to get tags of keys 'key-1', 'key-2'")
+	assert.Equal(t, 1, backend.batches)
+}
+
+func TestEncodeTagMembers(t *testing.T) {
+	// Given
+	members := []string{"key-1", "key,2", `key\3`, ""}
+
+	// When
+	value := EncodeTagMembers(members)
+
+	// Then
+	assert.Equal(t, `key-1,key\,2,key\\3,`, string(value))
+	assert.Equal(t, members, DecodeTagMembers(value))
+	assert.Equal(t, []string{"key-1", "key-2"}, DecodeTagMembers([]byte("key-1,key-2")))
+	assert.Nil(t, DecodeTagMembers(nil))
+}
+
+func TestValueTagIndexBackendRemoveMembersKeepsExpiration(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	values := map[string][]byte{}
+	ttls := map[string]time.Duration{}
+
+	backend := NewValueTagIndexBackend(
+		func(_ context.Context, key string) ([]byte, time.Duration, error) {
+			value, ok := values[key]
+			if !ok {
+				return nil, 0, NotFoundWithCause(errors.New("value not found"))
+			}
+			return value, ttls[key], nil
+		},
+		func(_ context.Context, key string, value []byte, ttl time.Duration) error {
+			values[key] = value
+			ttls[key] = ttl
+			return nil
+		},
+		func(_ context.Context, key string) error {
+			delete(values, key)
+			return nil
+		},
+	)
+
+	assert.Nil(t, backend.AddMembers(ctx, "gocache_tag_tag1", []string{"key-1", "key-2"}, time.Hour))
+	assert.Nil(t, backend.AddMembers(ctx, "gocache_tag_tag2", []string{"key-1", "key-2"}, time.Hour))
+	ttls["gocache_tag_tag1"] = 10 * time.Minute
+	ttls["gocache_tag_tag2"] = 0
+
+	// When
+	err1 := backend.RemoveMembers(ctx, "gocache_tag_tag1", []string{"key-1"})
+	err2 := backend.RemoveMembers(ctx, "gocache_tag_tag2", []string{"key-1"})
+
+	// Then
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Equal(t, "key-2", string(values["gocache_tag_tag1"]))
+	assert.Equal(t, 10*time.Minute, ttls["gocache_tag_tag1"])
+	assert.NotContains(t, values, "gocache_tag_tag2")
+}
@@ -0,0 +1,24 @@
+package store
+
+// Version is an opaque token identifying the state of a stored value.
+// It is returned by GetWithVersion and expected by CompareAndSwap, and only
+// makes sense for the store that created it.
+type Version struct {
+	value any
+}
+
+// NewVersion creates a version from a store specific value such as
+// a CAS identifier or the value itself
+func NewVersion(value any) Version {
+	return Version{value: value}
+}
+
+// Value returns the store specific value of the version
+func (v Version) Value() any {
+	return v.value
+}
+
+// IsZero returns true if the version has not been created by a store
+func (v Version) IsZero() bool {
+	return v.value == nil
+}
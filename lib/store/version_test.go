@@ -0,0 +1,17 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersion(t *testing.T) {
+	// When
+	version := NewVersion(uint64(42))
+
+	// Then
+	assert.False(t, version.IsZero())
+	assert.Equal(t, uint64(42), version.Value())
+	assert.True(t, Version{}.IsZero())
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"iter"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/allegro/bigcache/v3"
 	"github.com/eko/gocache/lib/v4/store"
 )
 
@@ -16,6 +19,7 @@ type BigcacheClientInterface interface {
 	Set(key string, entry []byte) error
 	Delete(key string) error
 	Reset() error
+	Iterator() *bigcache.EntryInfoIterator
 }
 
 const (
@@ -27,21 +31,58 @@ const (
 
 // BigcacheStore is a store for Bigcache
 type BigcacheStore struct {
-	client  BigcacheClientInterface
-	options *store.Options
+	// counterMu serializes counter updates as Bigcache has no atomic increment
+	counterMu sync.Mutex
+	client    BigcacheClientInterface
+	options   *store.Options
+	tags      *store.TagIndex
 }
 
 // NewBigcache creates a new store to Bigcache instance(s)
 func NewBigcache(client BigcacheClientInterface, options ...store.Option) *BigcacheStore {
-	return &BigcacheStore{
+	s := &BigcacheStore{
 		client:  client,
 		options: store.ApplyOptions(options...),
 	}
+	s.tags = store.NewTagIndex(store.NewValueTagIndexBackend(s.getTagValue, s.setTagValue, s.deleteTagValue), BigcacheTagPattern)
+
+	return s
+}
+
+// getTagValue, setTagValue and deleteTagValue store the tag index in Bigcache.
+// As for Set, the time-to-live of the tag index entries is not supported: entries
+// expire after the life window of the cache, so the default tags TTL is reported.
+func (s *BigcacheStore) getTagValue(_ context.Context, key string) ([]byte, time.Duration, error) {
+	value, err := s.client.Get(key)
+	if errors.Is(err, bigcache.ErrEntryNotFound) {
+		return nil, 0, store.NotFoundWithCause(err)
+	}
+	return value, store.DefaultTagsTTL, err
+}
+
+func (s *BigcacheStore) setTagValue(_ context.Context, key string, value []byte, _ time.Duration) error {
+	return s.client.Set(key, value)
+}
+
+func (s *BigcacheStore) deleteTagValue(_ context.Context, key string) error {
+	err := s.client.Delete(key)
+	if errors.Is(err, bigcache.ErrEntryNotFound) {
+		return store.NotFoundWithCause(err)
+	}
+	return err
 }
 
 // Get returns data stored from a given key
 func (s *BigcacheStore) Get(_ context.Context, key any) (any, error) {
-	item, err := s.client.Get(key.(string))
+	bigcacheKey, err := store.KeyAs[string](key)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.client.Get(bigcacheKey)
+	if errors.Is(err, bigcache.ErrEntryNotFound) {
+		return nil, store.NotFoundWithCause(err)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -63,7 +104,15 @@ func (s *BigcacheStore) GetWithTTL(ctx context.Context, key any) (any, time.Dura
 
 // Set defines data in Bigcache for given key identifier
 func (s *BigcacheStore) Set(ctx context.Context, key any, value any, options ...store.Option) error {
+	bigcacheKey, err := store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
 	opts := store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return err
+	}
 
 	var val []byte
 	switch v := value.(type) {
@@ -72,73 +121,142 @@ func (s *BigcacheStore) Set(ctx context.Context, key any, value any, options ...
 	case []byte:
 		val = v
 	default:
-		return errors.New("value type not supported by Bigcache store")
+		return fmt.Errorf("%w: value type not supported by Bigcache store", store.ErrInvalidValue)
 	}
 
-	err := s.client.Set(key.(string), val)
+	err = s.client.Set(bigcacheKey, val)
 	if err != nil {
-		return err
+		// bigcache only fails to set entries larger than its shards
+		return store.InvalidValueWithCause(err)
 	}
 
-	if tags := opts.Tags; len(tags) > 0 {
-		s.setTags(ctx, key, tags)
+	return s.tags.Add(ctx, bigcacheKey, opts.Tags, opts.TagsTTL)
+}
+
+// Increment adds the given delta to the counter stored for the given key.
+// Counter updates are serialized with a mutex and counters are stored as their
+// decimal representation. As for Set, the expiration option is not supported.
+func (s *BigcacheStore) Increment(_ context.Context, key any, delta int64, options ...store.Option) (int64, error) {
+	bigcacheKey, err := store.KeyAs[string](key)
+	if err != nil {
+		return 0, err
 	}
 
-	return nil
-}
+	opts := store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return 0, err
+	}
 
-func (s *BigcacheStore) setTags(ctx context.Context, key any, tags []string) {
-	for _, tag := range tags {
-		tagKey := fmt.Sprintf(BigcacheTagPattern, tag)
-		cacheKeys := []string{}
+	s.counterMu.Lock()
+	defer s.counterMu.Unlock()
 
-		if result, err := s.Get(ctx, tagKey); err == nil {
-			if bytes, ok := result.([]byte); ok {
-				cacheKeys = strings.Split(string(bytes), ",")
-			}
+	var counter int64
+
+	item, err := s.client.Get(bigcacheKey)
+	if err != nil && !errors.Is(err, bigcache.ErrEntryNotFound) {
+		return 0, err
+	}
+	if item != nil {
+		if counter, err = store.CounterValue(item); err != nil {
+			return 0, err
 		}
+	}
+
+	counter += delta
 
-		alreadyInserted := false
-		for _, cacheKey := range cacheKeys {
-			if cacheKey == key.(string) {
-				alreadyInserted = true
-				break
+	if err := s.client.Set(bigcacheKey, []byte(strconv.FormatInt(counter, 10))); err != nil {
+		return 0, err
+	}
+
+	return counter, nil
+}
+
+// Decrement subtracts the given delta from the counter stored for the given key
+func (s *BigcacheStore) Decrement(ctx context.Context, key any, delta int64, options ...store.Option) (int64, error) {
+	return s.Increment(ctx, key, -delta, options...)
+}
+
+// Keys iterates over the keys stored in Bigcache. Keys are returned in
+// the order of the underlying shards and tag keys are not returned.
+func (s *BigcacheStore) Keys(_ context.Context, options ...store.ScanOption) iter.Seq2[any, error] {
+	opts := store.ApplyScanOptions(options...)
+
+	return func(yield func(any, error) bool) {
+		iterator := s.client.Iterator()
+		for iterator.SetNext() {
+			entry, err := iterator.Value()
+			if err != nil {
+				yield(nil, err)
+				return
 			}
-		}
 
-		if !alreadyInserted {
-			cacheKeys = append(cacheKeys, key.(string))
+			key := entry.Key()
+			if s.tags.IsIndexKey(key) || !store.MatchPattern(opts.Match, key) {
+				continue
+			}
+			if !yield(key, nil) {
+				return
+			}
 		}
-
-		s.Set(ctx, tagKey, []byte(strings.Join(cacheKeys, ",")), store.WithExpiration(720*time.Hour))
 	}
 }
 
 // Delete removes data from Bigcache for given key identifier
-func (s *BigcacheStore) Delete(_ context.Context, key any) error {
-	return s.client.Delete(key.(string))
+func (s *BigcacheStore) Delete(ctx context.Context, key any) error {
+	bigcacheKey, err := store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
+	if err := s.client.Delete(bigcacheKey); err != nil {
+		return err
+	}
+
+	return s.tags.Remove(ctx, bigcacheKey)
 }
 
 // Invalidate invalidates some cache data in Bigcache for given options
 func (s *BigcacheStore) Invalidate(ctx context.Context, options ...store.InvalidateOption) error {
 	opts := store.ApplyInvalidateOptions(options...)
 
+	var err error
 	if tags := opts.Tags; len(tags) > 0 {
-		for _, tag := range tags {
-			tagKey := fmt.Sprintf(BigcacheTagPattern, tag)
-			result, err := s.Get(ctx, tagKey)
-			if err != nil {
-				return nil
+		err = s.tags.Invalidate(ctx, tags, func(ctx context.Context, key string) error {
+			err := s.Delete(ctx, key)
+			if errors.Is(err, bigcache.ErrEntryNotFound) {
+				return store.NotFoundWithCause(err)
 			}
+			return err
+		})
+	}
 
-			cacheKeys := []string{}
-			if bytes, ok := result.([]byte); ok {
-				cacheKeys = strings.Split(string(bytes), ",")
-			}
+	if opts.HasKeyFilter() {
+		err = errors.Join(err, s.invalidateKeys(ctx, opts))
+	}
 
-			for _, cacheKey := range cacheKeys {
-				s.Delete(ctx, cacheKey)
-			}
+	return err
+}
+
+// invalidateKeys deletes the keys matching the prefix or pattern of the given options.
+// Matching keys are collected before being deleted so the iterator is not invalidated.
+func (s *BigcacheStore) invalidateKeys(ctx context.Context, opts *store.InvalidateOptions) error {
+	keys := []string{}
+	for key, err := range s.Keys(ctx, store.WithScanMatch(opts.KeyPattern())) {
+		if err != nil {
+			return err
+		}
+		bigcacheKey, err := store.KeyAs[string](key)
+		if err != nil {
+			return err
+		}
+		if opts.MatchKey(bigcacheKey) {
+			keys = append(keys, bigcacheKey)
+		}
+	}
+
+	for _, key := range keys {
+		if err := s.Delete(ctx, key); err != nil {
+			return err
 		}
 	}
 
@@ -150,6 +268,16 @@ func (s *BigcacheStore) Clear(_ context.Context) error {
 	return s.client.Reset()
 }
 
+// Capabilities returns the features supported by Bigcache. Expirations are not supported
+// as entries share the lifetime given in the Bigcache configuration.
+func (s *BigcacheStore) Capabilities() store.Capabilities {
+	return store.Capabilities{
+		Tags:           true,
+		Scan:           true,
+		AtomicCounters: true,
+	}
+}
+
 // GetType returns the store type
 func (s *BigcacheStore) GetType() string {
 	return BigcacheType
@@ -4,8 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
+
+	"github.com/allegro/bigcache/v3"
 
 	lib_store "github.com/eko/gocache/lib/v4/store"
+	"github.com/eko/gocache/lib/v4/store/storetest"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -70,6 +74,26 @@ func TestBigcacheGetWhenError(t *testing.T) {
 	assert.Nil(t, value)
 }
 
+func TestBigcacheGetWhenEntryNotFound(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	client := NewMockBigcacheClientInterface(ctrl)
+	client.EXPECT().Get(cacheKey).Return(nil, bigcache.ErrEntryNotFound)
+	store := NewBigcache(client)
+
+	// When
+	value, err := store.Get(ctx, cacheKey)
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.NotFound{})
+	assert.ErrorIs(t, err, bigcache.ErrEntryNotFound)
+	assert.Nil(t, value)
+}
+
 func TestBigcacheGetWithTTL(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -112,6 +136,43 @@ func TestBigcacheSet(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestBigcacheSetWhenStrictAndExpirationGiven(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockBigcacheClientInterface(ctrl)
+
+	store := NewBigcache(client)
+
+	// When
+	err := store.Set(ctx, "my-key", []byte("my-cache-value"), lib_store.WithStrictOptions(), lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ErrUnsupported)
+	assert.ErrorContains(t, err, "WithExpiration")
+}
+
+func TestBigcacheIncrementWhenStrictAndExpirationGiven(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockBigcacheClientInterface(ctrl)
+
+	store := NewBigcache(client, lib_store.WithStrictOptions())
+
+	// When
+	counter, err := store.Increment(ctx, "my-counter", 1, lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ErrUnsupported)
+	assert.ErrorContains(t, err, "WithExpiration")
+	assert.Equal(t, int64(0), counter)
+}
+
 func TestBigcacheSetString(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -144,7 +205,7 @@ func TestBigcacheSetWhenError(t *testing.T) {
 	cacheKey := "my-key"
 	cacheValue := []byte("my-cache-value")
 
-	expectedErr := errors.New("an unexpected error occurred")
+	expectedErr := errors.New("entry is bigger than max shard size")
 
 	client := NewMockBigcacheClientInterface(ctrl)
 	client.EXPECT().Set(cacheKey, cacheValue).Return(expectedErr)
@@ -155,7 +216,8 @@ func TestBigcacheSetWhenError(t *testing.T) {
 	err := store.Set(ctx, cacheKey, cacheValue)
 
 	// Then
-	assert.Equal(t, expectedErr, err)
+	assert.ErrorIs(t, err, expectedErr)
+	assert.ErrorIs(t, err, lib_store.ErrInvalidValue)
 }
 
 func TestBigcacheSetWithTags(t *testing.T) {
@@ -171,6 +233,8 @@ func TestBigcacheSetWithTags(t *testing.T) {
 	client.EXPECT().Set(cacheKey, cacheValue).Return(nil)
 	client.EXPECT().Get("gocache_tag_tag1").Return(nil, nil)
 	client.EXPECT().Set("gocache_tag_tag1", []byte("my-key")).Return(nil)
+	client.EXPECT().Get("gocache_tag___key__:my-key").Return(nil, bigcache.ErrEntryNotFound)
+	client.EXPECT().Set("gocache_tag___key__:my-key", []byte("tag1")).Return(nil)
 
 	store := NewBigcache(client)
 
@@ -194,6 +258,8 @@ func TestBigcacheSetWithTagsWhenAlreadyInserted(t *testing.T) {
 	client.EXPECT().Set(cacheKey, cacheValue).Return(nil)
 	client.EXPECT().Get("gocache_tag_tag1").Return([]byte("my-key,a-second-key"), nil)
 	client.EXPECT().Set("gocache_tag_tag1", []byte("my-key,a-second-key")).Return(nil)
+	client.EXPECT().Get("gocache_tag___key__:my-key").Return([]byte("tag1"), nil)
+	client.EXPECT().Set("gocache_tag___key__:my-key", []byte("tag1")).Return(nil)
 
 	store := NewBigcache(client)
 
@@ -214,6 +280,7 @@ func TestBigcacheDelete(t *testing.T) {
 
 	client := NewMockBigcacheClientInterface(ctrl)
 	client.EXPECT().Delete(cacheKey).Return(nil)
+	client.EXPECT().Get("gocache_tag___key__:my-key").Return(nil, bigcache.ErrEntryNotFound)
 
 	store := NewBigcache(client)
 
@@ -246,6 +313,96 @@ func TestBigcacheDeleteWhenError(t *testing.T) {
 	assert.Equal(t, expectedErr, err)
 }
 
+func TestBigcacheIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockBigcacheClientInterface(ctrl)
+	client.EXPECT().Get("my-counter").Return([]byte("3"), nil)
+	client.EXPECT().Set("my-counter", []byte("5")).Return(nil)
+
+	store := NewBigcache(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), value)
+}
+
+func TestBigcacheDecrementWhenCounterDoesNotExist(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockBigcacheClientInterface(ctrl)
+	client.EXPECT().Get("my-counter").Return(nil, bigcache.ErrEntryNotFound)
+	client.EXPECT().Set("my-counter", []byte("-2")).Return(nil)
+
+	store := NewBigcache(client)
+
+	// When
+	value, err := store.Decrement(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-2), value)
+}
+
+func TestBigcacheIncrementWhenValueIsNotAnInteger(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockBigcacheClientInterface(ctrl)
+	client.EXPECT().Get("my-key").Return([]byte("my-value"), nil)
+
+	store := NewBigcache(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-key", 1)
+
+	// Then
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), value)
+}
+
+func TestBigcacheKeys(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	bigcacheClient, err := bigcache.New(ctx, bigcache.DefaultConfig(time.Minute))
+	assert.Nil(t, err)
+	defer bigcacheClient.Close()
+
+	assert.Nil(t, bigcacheClient.Set("key-1", []byte("value-1")))
+	assert.Nil(t, bigcacheClient.Set("key-2", []byte("value-2")))
+	assert.Nil(t, bigcacheClient.Set("other-key", []byte("other-value")))
+	assert.Nil(t, bigcacheClient.Set("gocache_tag_tag1", []byte("key-1")))
+
+	client := NewMockBigcacheClientInterface(ctrl)
+	client.EXPECT().Iterator().Return(bigcacheClient.Iterator())
+
+	store := NewBigcache(client)
+
+	// When
+	keys := []any{}
+	for key, err := range store.Keys(ctx, lib_store.WithScanMatch("key-*")) {
+		assert.Nil(t, err)
+		keys = append(keys, key)
+	}
+
+	// Then
+	assert.ElementsMatch(t, []any{"key-1", "key-2"}, keys)
+}
+
 func TestBigcacheInvalidate(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -257,7 +414,9 @@ func TestBigcacheInvalidate(t *testing.T) {
 	client := NewMockBigcacheClientInterface(ctrl)
 	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, nil)
 	client.EXPECT().Delete("a23fdf987h2svc23").Return(nil)
-	client.EXPECT().Delete("jHG2372x38hf74").Return(nil)
+	client.EXPECT().Get("gocache_tag___key__:a23fdf987h2svc23").Return(nil, bigcache.ErrEntryNotFound)
+	client.EXPECT().Delete("jHG2372x38hf74").Return(bigcache.ErrEntryNotFound)
+	client.EXPECT().Delete("gocache_tag_tag1").Return(nil)
 
 	store := NewBigcache(client)
 
@@ -280,12 +439,41 @@ func TestBigcacheInvalidateWhenError(t *testing.T) {
 	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, nil)
 	client.EXPECT().Delete("a23fdf987h2svc23").Return(errors.New("unexpected error"))
 	client.EXPECT().Delete("jHG2372x38hf74").Return(nil)
+	client.EXPECT().Get("gocache_tag___key__:jHG2372x38hf74").Return(nil, bigcache.ErrEntryNotFound)
+	client.EXPECT().Delete("gocache_tag_tag1").Return(nil)
 
 	store := NewBigcache(client)
 
 	// When
 	err := store.Invalidate(ctx, lib_store.WithInvalidateTags([]string{"tag1"}))
 
+	// Then
+	assert.EqualError(t, err, "unable to delete key 'a23fdf987h2svc23' of tag 'tag1': unexpected error")
+}
+
+func TestBigcacheInvalidateWithPattern(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	bigcacheClient, err := bigcache.New(ctx, bigcache.DefaultConfig(time.Minute))
+	assert.Nil(t, err)
+	defer bigcacheClient.Close()
+
+	assert.Nil(t, bigcacheClient.Set("user:1:profile", []byte("john")))
+	assert.Nil(t, bigcacheClient.Set("user:1:settings", []byte("dark")))
+
+	client := NewMockBigcacheClientInterface(ctrl)
+	client.EXPECT().Iterator().Return(bigcacheClient.Iterator())
+	client.EXPECT().Delete("user:1:profile").Return(nil)
+	client.EXPECT().Get("gocache_tag___key__:user:1:profile").Return(nil, bigcache.ErrEntryNotFound)
+
+	store := NewBigcache(client)
+
+	// When
+	err = store.Invalidate(ctx, lib_store.WithInvalidatePattern("user:*:profile"))
+
 	// Then
 	assert.Nil(t, err)
 }
@@ -339,3 +527,14 @@ func TestBigcacheGetType(t *testing.T) {
 	// When - Then
 	assert.Equal(t, BigcacheType, store.GetType())
 }
+
+func TestBigcacheTagIndexBackend(t *testing.T) {
+	storetest.TagIndexBackend(t, func(t *testing.T) lib_store.TagIndexBackend {
+		client, err := bigcache.New(context.Background(), bigcache.DefaultConfig(time.Minute))
+		assert.Nil(t, err)
+		t.Cleanup(func() { client.Close() })
+
+		s := NewBigcache(client)
+		return lib_store.NewValueTagIndexBackend(s.getTagValue, s.setTagValue, s.deleteTagValue)
+	})
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"iter"
+	"reflect"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/coocood/freecache"
 	lib_store "github.com/eko/gocache/lib/v4/store"
 )
 
@@ -17,51 +21,115 @@ const (
 	FreecacheTagPattern = "freecache_tag_%s"
 )
 
+var (
+	errKeyType       = lib_store.InvalidKeyTypeWithCause(errors.New("only string and int64 keys are supported by Freecache store"))
+	errStringKeyType = lib_store.InvalidKeyTypeWithCause(errors.New("only string keys are supported by this Freecache store operation"))
+	errTagsKeyType   = lib_store.InvalidKeyTypeWithCause(errors.New("tags are only supported with string keys by Freecache store"))
+	errValueType     = fmt.Errorf("%w: only []byte values are supported by Freecache store", lib_store.ErrInvalidValue)
+)
+
 // FreecacheClientInterface represents a coocood/freecache client
 type FreecacheClientInterface interface {
 	Get(key []byte) (value []byte, err error)
 	GetInt(key int64) (value []byte, err error)
+	GetIntWithExpiration(key int64) (value []byte, expireAt uint32, err error)
 	TTL(key []byte) (timeLeft uint32, err error)
 	Set(key, value []byte, expireSeconds int) (err error)
+	Touch(key []byte, expireSeconds int) (err error)
 	SetInt(key int64, value []byte, expireSeconds int) (err error)
 	Del(key []byte) (affected bool)
 	DelInt(key int64) (affected bool)
+	NewIterator() *freecache.Iterator
 	Clear()
 }
 
 // FreecacheStore is a store for freecache
 type FreecacheStore struct {
-	client  FreecacheClientInterface
-	options *lib_store.Options
+	// counterMu serializes counter updates as freecache has no atomic increment
+	counterMu sync.Mutex
+	client    FreecacheClientInterface
+	options   *lib_store.Options
+	tags      *lib_store.TagIndex
 }
 
 // NewFreecache creates a new store to freecache instance(s)
 func NewFreecache(client FreecacheClientInterface, options ...lib_store.Option) *FreecacheStore {
-	return &FreecacheStore{
+	f := &FreecacheStore{
 		client:  client,
 		options: lib_store.ApplyOptions(options...),
 	}
+	f.tags = lib_store.NewTagIndex(lib_store.NewValueTagIndexBackend(f.getTagValue, f.setTagValue, f.deleteTagValue), FreecacheTagPattern)
+
+	return f
+}
+
+// getTagValue, setTagValue and deleteTagValue store the tag index in freecache
+func (f *FreecacheStore) getTagValue(_ context.Context, key string) ([]byte, time.Duration, error) {
+	value, err := f.client.Get([]byte(key))
+	if errors.Is(err, freecache.ErrNotFound) {
+		return nil, 0, lib_store.NotFoundWithCause(err)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ttl, err := f.client.TTL([]byte(key))
+	if err != nil {
+		return nil, 0, lib_store.NotFoundWithCause(err)
+	}
+
+	return value, time.Duration(ttl) * time.Second, nil
+}
+
+func (f *FreecacheStore) setTagValue(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	return f.client.Set([]byte(key), value, int(ttl.Seconds()))
+}
+
+func (f *FreecacheStore) deleteTagValue(_ context.Context, key string) error {
+	if !f.client.Del([]byte(key)) {
+		return lib_store.NotFoundWithCause(fmt.Errorf("failed to delete key %v", key))
+	}
+	return nil
 }
 
-// Get returns data stored from a given key. It returns the value or not found error
+// Get returns data stored from a given key. It returns the value or not found error.
+// Keys can either be strings or int64 values, the latter being natively handled by freecache.
 func (f *FreecacheStore) Get(_ context.Context, key any) (any, error) {
 	var err error
 	var result any
-	if k, ok := key.(string); ok {
-		result, err = f.client.Get([]byte(k))
-		if err != nil {
-			return nil, lib_store.NotFoundWithCause(errors.New("value not found in Freecache store"))
+	switch k := key.(type) {
+	case string:
+		if f.options.SlidingExpirationSupport {
+			result, _, err = f.getWithSlidingExpiration(k)
+		} else {
+			result, err = f.client.Get([]byte(k))
 		}
-		return result, err
+	case int64:
+		result, err = f.client.GetInt(k)
+	default:
+		return nil, errKeyType
 	}
-
-	return nil, errors.New("key type not supported by Freecache store")
+	if err != nil {
+		return nil, lib_store.NotFoundWithCause(errors.New("value not found in Freecache store"))
+	}
+	return result, err
 }
 
 // GetWithTTL returns data stored from a given key and its corresponding TTL
 func (f *FreecacheStore) GetWithTTL(_ context.Context, key any) (any, time.Duration, error) {
-	if k, ok := key.(string); ok {
-		result, err := f.client.Get([]byte(k))
+	switch k := key.(type) {
+	case string:
+		var result []byte
+		var err error
+		if f.options.SlidingExpirationSupport {
+			var sliding time.Duration
+			result, sliding, err = f.getWithSlidingExpiration(k)
+			if err == nil && sliding > 0 {
+				return result, sliding, nil
+			}
+		} else {
+			result, err = f.client.Get([]byte(k))
+		}
 		if err != nil {
 			return nil, 0, lib_store.NotFoundWithCause(errors.New("value not found in Freecache store"))
 		}
@@ -72,9 +140,55 @@ func (f *FreecacheStore) GetWithTTL(_ context.Context, key any) (any, time.Durat
 		}
 
 		return result, time.Duration(ttl) * time.Second, err
+
+	case int64:
+		result, expireAt, err := f.client.GetIntWithExpiration(k)
+		if err != nil {
+			return nil, 0, lib_store.NotFoundWithCause(errors.New("value not found in Freecache store"))
+		}
+
+		var ttl time.Duration
+		if expireAt > 0 {
+			ttl = time.Until(time.Unix(int64(expireAt), 0)).Truncate(time.Second)
+		}
+
+		return result, ttl, nil
+	}
+
+	return nil, 0, errKeyType
+}
+
+// getWithSlidingExpiration returns data stored from a given string key along with its
+// sliding expiration, touching the value when it has one. Freecache is not able to
+// renew an expiration on read so the renewal is not atomic with regard to concurrent writes.
+func (f *FreecacheStore) getWithSlidingExpiration(key string) ([]byte, time.Duration, error) {
+	result, err := f.client.Get([]byte(key))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	sliding := f.slidingExpiration(key)
+	if sliding <= 0 {
+		return result, 0, nil
 	}
 
-	return nil, 0, errors.New("key type not supported by Freecache store")
+	expireSeconds := int(sliding.Seconds())
+	if err := f.client.Touch([]byte(key), expireSeconds); err == nil {
+		_ = f.client.Touch([]byte(lib_store.SlidingExpirationKey(key)), expireSeconds)
+	}
+
+	return result, sliding, nil
+}
+
+// slidingExpiration returns the sliding expiration stored for the given key, if any
+func (f *FreecacheStore) slidingExpiration(key string) time.Duration {
+	value, err := f.client.Get([]byte(lib_store.SlidingExpirationKey(key)))
+	if err != nil {
+		return 0
+	}
+
+	sliding, _ := lib_store.ParseSlidingExpiration(value)
+	return sliding
 }
 
 // Set sets a key, value and expiration for a cache entry and stores it in the cache.
@@ -87,102 +201,243 @@ func (f *FreecacheStore) Set(ctx context.Context, key any, value any, options ..
 
 	// Using default options set during cache initialization
 	opts := lib_store.ApplyOptionsWithDefault(f.options, options...)
+	if err := f.Capabilities().CheckOptions(opts); err != nil {
+		return err
+	}
 
 	// type check for value, as freecache only supports value of type []byte
 	switch v := value.(type) {
 	case []byte:
 		val = v
 	default:
-		return errors.New("value type not supported by Freecache store")
+		return errValueType
 	}
 
-	if k, ok := key.(string); ok {
-		err = f.client.Set([]byte(k), val, int(opts.Expiration.Seconds()))
+	switch k := key.(type) {
+	case string:
+		err = f.client.Set([]byte(k), val, int(opts.EffectiveExpirationWithPrecision(time.Second).Seconds()))
 		if err != nil {
-			return fmt.Errorf("size of key: %v, value: %v, err: %v", k, len(val), err)
+			return lib_store.InvalidValueWithCause(fmt.Errorf("size of key: %v, value: %v, err: %w", k, len(val), err))
 		}
-		if tags := opts.Tags; len(tags) > 0 {
-			f.setTags(ctx, key, tags)
+		if f.options.SlidingExpirationSupport {
+			if opts.SlidingExpiration > 0 {
+				err = f.client.Set([]byte(lib_store.SlidingExpirationKey(k)), []byte(lib_store.SlidingExpirationValue(opts.SlidingExpiration)), int(opts.SlidingExpiration.Seconds()))
+				if err != nil {
+					return err
+				}
+			} else {
+				f.client.Del([]byte(lib_store.SlidingExpirationKey(k)))
+			}
+		}
+		return f.tags.Add(ctx, k, opts.Tags, opts.TagsTTL)
+
+	case int64:
+		if len(opts.Tags) > 0 {
+			return errTagsKeyType
+		}
+		if opts.SlidingExpiration > 0 {
+			return errStringKeyType
+		}
+		err = f.client.SetInt(k, val, int(opts.EffectiveExpirationWithPrecision(time.Second).Seconds()))
+		if err != nil {
+			return lib_store.InvalidValueWithCause(fmt.Errorf("size of key: %v, value: %v, err: %w", k, len(val), err))
 		}
 		return nil
 	}
-	return errors.New("key type not supported by Freecache store")
+	return errKeyType
 }
 
-func (f *FreecacheStore) setTags(ctx context.Context, key any, tags []string) {
-	for _, tag := range tags {
-		tagKey := fmt.Sprintf(FreecacheTagPattern, tag)
-		cacheKeys := f.getCacheKeysForTag(ctx, tagKey)
+// Touch changes the expiration of the given key.
+// As for Set, a ttl lower than one second means no expire.
+func (f *FreecacheStore) Touch(_ context.Context, key any, ttl time.Duration) error {
+	if err := f.Capabilities().CheckOptions(&lib_store.Options{Strict: f.options.Strict, Expiration: ttl}); err != nil {
+		return err
+	}
 
-		alreadyInserted := false
-		for _, cacheKey := range cacheKeys {
-			if cacheKey == key.(string) {
-				alreadyInserted = true
-				break
-			}
-		}
+	k, ok := key.(string)
+	if !ok {
+		return errStringKeyType
+	}
 
-		if !alreadyInserted {
-			cacheKeys = append(cacheKeys, key.(string))
-		}
+	err := f.client.Touch([]byte(k), int(ttl.Seconds()))
+	if errors.Is(err, freecache.ErrNotFound) {
+		return lib_store.NotFoundWithCause(err)
+	}
+	return err
+}
 
-		f.Set(ctx, tagKey, []byte(strings.Join(cacheKeys, ",")), lib_store.WithExpiration(720*time.Hour))
+// Delete deletes an item in the cache by key and returns err or nil if a delete occurred
+func (f *FreecacheStore) Delete(ctx context.Context, key any) error {
+	var affected bool
+	switch k := key.(type) {
+	case string:
+		affected = f.client.Del([]byte(k))
+		if f.options.SlidingExpirationSupport {
+			f.client.Del([]byte(lib_store.SlidingExpirationKey(k)))
+		}
+		if err := f.tags.Remove(ctx, k); err != nil {
+			return err
+		}
+	case int64:
+		affected = f.client.DelInt(k)
+	default:
+		return errKeyType
+	}
+	if !affected {
+		return fmt.Errorf("failed to delete key %v", key)
 	}
+	return nil
 }
 
-func (f *FreecacheStore) getCacheKeysForTag(ctx context.Context, tagKey string) []string {
-	cacheKeys := []string{}
-	if result, err := f.Get(ctx, tagKey); err == nil && result != nil {
-		if str, ok := result.([]byte); ok {
-			cacheKeys = strings.Split(string(str), ",")
+// Increment adds the given delta to the counter stored for the given key.
+// Counter updates are serialized with a mutex and counters are stored as their
+// decimal representation. The counter keeps its expiration when it already exists.
+func (f *FreecacheStore) Increment(_ context.Context, key any, delta int64, options ...lib_store.Option) (int64, error) {
+	k, ok := key.(string)
+	if !ok {
+		return 0, errStringKeyType
+	}
+
+	opts := lib_store.ApplyOptionsWithDefault(f.options, options...)
+	if err := f.Capabilities().CheckOptions(opts); err != nil {
+		return 0, err
+	}
+
+	f.counterMu.Lock()
+	defer f.counterMu.Unlock()
+
+	var counter int64
+	expireSeconds := int(opts.EffectiveExpirationWithPrecision(time.Second).Seconds())
+
+	value, err := f.client.Get([]byte(k))
+	if err != nil && !errors.Is(err, freecache.ErrNotFound) {
+		return 0, err
+	}
+	if err == nil {
+		if counter, err = lib_store.CounterValue(value); err != nil {
+			return 0, err
+		}
+		if ttl, err := f.client.TTL([]byte(k)); err == nil {
+			expireSeconds = int(ttl)
 		}
 	}
-	return cacheKeys
+
+	counter += delta
+
+	if err := f.client.Set([]byte(k), []byte(strconv.FormatInt(counter, 10)), expireSeconds); err != nil {
+		return 0, err
+	}
+
+	return counter, nil
 }
 
-// Delete deletes an item in the cache by key and returns err or nil if a delete occurred
-func (f *FreecacheStore) Delete(_ context.Context, key any) error {
-	if v, ok := key.(string); ok {
-		if f.client.Del([]byte(v)) {
-			return nil
+// Decrement subtracts the given delta from the counter stored for the given key
+func (f *FreecacheStore) Decrement(ctx context.Context, key any, delta int64, options ...lib_store.Option) (int64, error) {
+	return f.Increment(ctx, key, -delta, options...)
+}
+
+// Keys iterates over the keys stored in freecache. Keys are returned in
+// the order of the underlying segments, tag and sliding expiration keys are not returned.
+func (f *FreecacheStore) Keys(_ context.Context, options ...lib_store.ScanOption) iter.Seq2[any, error] {
+	opts := lib_store.ApplyScanOptions(options...)
+
+	return func(yield func(any, error) bool) {
+		iterator := f.client.NewIterator()
+		for entry := iterator.Next(); entry != nil; entry = iterator.Next() {
+			key := string(entry.Key)
+			if f.tags.IsIndexKey(key) || lib_store.IsSlidingExpirationKey(key) || !lib_store.MatchPattern(opts.Match, key) {
+				continue
+			}
+			if !yield(key, nil) {
+				return
+			}
 		}
-		return fmt.Errorf("failed to delete key %v", key)
 	}
-	return errors.New("key type not supported by Freecache store")
 }
 
 // Invalidate invalidates some cache data in freecache for given options
 func (f *FreecacheStore) Invalidate(ctx context.Context, options ...lib_store.InvalidateOption) error {
 	opts := lib_store.ApplyInvalidateOptions(options...)
 
+	var err error
 	if tags := opts.Tags; len(tags) > 0 {
-		for _, tag := range tags {
-			tagKey := fmt.Sprintf(FreecacheTagPattern, tag)
-			cacheKeys := f.getCacheKeysForTag(ctx, tagKey)
-
-			for _, cacheKey := range cacheKeys {
-				err := f.Delete(ctx, cacheKey)
-				if err != nil {
-					return err
-				}
+		err = f.tags.Invalidate(ctx, tags, func(ctx context.Context, key string) error {
+			// keys which have already expired or been evicted are not reported
+			if !f.client.Del([]byte(key)) {
+				return lib_store.NotFoundWithCause(fmt.Errorf("failed to delete key %v", key))
 			}
-
-			err := f.Delete(ctx, tagKey)
-			if err != nil {
-				return err
+			if f.options.SlidingExpirationSupport {
+				f.client.Del([]byte(lib_store.SlidingExpirationKey(key)))
 			}
+			return f.tags.Remove(ctx, key)
+		})
+	}
+
+	if opts.HasKeyFilter() {
+		err = errors.Join(err, f.invalidateKeys(ctx, opts))
+	}
+
+	return err
+}
+
+// invalidateKeys deletes the keys matching the prefix or pattern of the given options.
+// Matching keys are collected before being deleted so the iterator is not invalidated.
+func (f *FreecacheStore) invalidateKeys(ctx context.Context, opts *lib_store.InvalidateOptions) error {
+	keys := []string{}
+	for key, err := range f.Keys(ctx, lib_store.WithScanMatch(opts.KeyPattern())) {
+		if err != nil {
+			return err
+		}
+		freecacheKey, err := lib_store.KeyAs[string](key)
+		if err != nil {
+			return err
+		}
+		if opts.MatchKey(freecacheKey) {
+			keys = append(keys, freecacheKey)
+		}
+	}
+
+	for _, key := range keys {
+		if err := f.Delete(ctx, key); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// SlidingExpiration returns the sliding expiration given to the key, if any
+func (f *FreecacheStore) SlidingExpiration(_ context.Context, key any) (time.Duration, error) {
+	k, ok := key.(string)
+	if !ok {
+		return 0, errStringKeyType
+	}
+	if !f.options.SlidingExpirationSupport {
+		return 0, nil
+	}
+
+	return f.slidingExpiration(k), nil
+}
+
 // Clear resets all data in the store
 func (f *FreecacheStore) Clear(_ context.Context) error {
 	f.client.Clear()
 	return nil
 }
 
+// Capabilities returns the features supported by freecache. Values larger than
+// 1/1024 of the cache size are not stored, which depends on the freecache configuration.
+func (f *FreecacheStore) Capabilities() lib_store.Capabilities {
+	return lib_store.Capabilities{
+		TTLPrecision:      time.Second,
+		Tags:              true,
+		Scan:              true,
+		AtomicCounters:    true,
+		SlidingExpiration: f.options.SlidingExpirationSupport,
+		KeyTypes:          []reflect.Type{reflect.TypeFor[string](), reflect.TypeFor[int64]()},
+	}
+}
+
 // GetType returns the store type
 func (f *FreecacheStore) GetType() string {
 	return FreecacheType
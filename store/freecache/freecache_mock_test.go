@@ -12,6 +12,7 @@ package freecache
 import (
 	reflect "reflect"
 
+	freecache "github.com/coocood/freecache"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -109,6 +110,36 @@ func (mr *MockFreecacheClientInterfaceMockRecorder) GetInt(key any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInt", reflect.TypeOf((*MockFreecacheClientInterface)(nil).GetInt), key)
 }
 
+// GetIntWithExpiration mocks base method.
+func (m *MockFreecacheClientInterface) GetIntWithExpiration(key int64) ([]byte, uint32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIntWithExpiration", key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(uint32)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetIntWithExpiration indicates an expected call of GetIntWithExpiration.
+func (mr *MockFreecacheClientInterfaceMockRecorder) GetIntWithExpiration(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIntWithExpiration", reflect.TypeOf((*MockFreecacheClientInterface)(nil).GetIntWithExpiration), key)
+}
+
+// NewIterator mocks base method.
+func (m *MockFreecacheClientInterface) NewIterator() *freecache.Iterator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewIterator")
+	ret0, _ := ret[0].(*freecache.Iterator)
+	return ret0
+}
+
+// NewIterator indicates an expected call of NewIterator.
+func (mr *MockFreecacheClientInterfaceMockRecorder) NewIterator() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewIterator", reflect.TypeOf((*MockFreecacheClientInterface)(nil).NewIterator))
+}
+
 // Set mocks base method.
 func (m *MockFreecacheClientInterface) Set(key, value []byte, expireSeconds int) error {
 	m.ctrl.T.Helper()
@@ -151,3 +182,17 @@ func (mr *MockFreecacheClientInterfaceMockRecorder) TTL(key any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TTL", reflect.TypeOf((*MockFreecacheClientInterface)(nil).TTL), key)
 }
+
+// Touch mocks base method.
+func (m *MockFreecacheClientInterface) Touch(key []byte, expireSeconds int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", key, expireSeconds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockFreecacheClientInterfaceMockRecorder) Touch(key, expireSeconds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockFreecacheClientInterface)(nil).Touch), key, expireSeconds)
+}
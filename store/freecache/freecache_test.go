@@ -7,7 +7,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/coocood/freecache"
 	lib_store "github.com/eko/gocache/lib/v4/store"
+	"github.com/eko/gocache/lib/v4/store/storetest"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
@@ -92,7 +94,7 @@ func TestFreecacheGetWithInvalidKey(t *testing.T) {
 	s := NewFreecache(client)
 
 	value, err := s.Get(ctx, []byte("key1"))
-	assert.Error(t, err, "key type not supported by Freecache store")
+	assert.ErrorIs(t, err, lib_store.ErrInvalidKeyType)
 	assert.Nil(t, value)
 }
 
@@ -177,7 +179,7 @@ func TestFreecacheGetWithTTLWhenInvalidKey(t *testing.T) {
 	s := NewFreecache(client)
 
 	value, ttl, err := s.GetWithTTL(ctx, []byte("key1"))
-	assert.Error(t, err, "key type not supported by Freecache store")
+	assert.ErrorIs(t, err, lib_store.ErrInvalidKeyType)
 	assert.Nil(t, value)
 	assert.Equal(t, 0*time.Second, ttl)
 }
@@ -199,6 +201,67 @@ func TestFreecacheSet(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestFreecacheSetWithExpirationJitterKeepsWholeSecond(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := []byte("my-cache-value")
+
+	client := NewMockFreecacheClientInterface(ctrl)
+	client.EXPECT().Set([]byte(cacheKey), cacheValue, 1).Return(nil)
+
+	s := NewFreecache(client, lib_store.WithExpirationJitter(1))
+	err := s.Set(ctx, cacheKey, cacheValue, lib_store.WithExpiration(time.Second))
+	assert.Nil(t, err)
+}
+
+func TestFreecacheSetWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := []byte("my-cache-value")
+
+	client := NewMockFreecacheClientInterface(ctrl)
+	client.EXPECT().Set([]byte(cacheKey), cacheValue, 60).Return(nil)
+	client.EXPECT().Set([]byte("gocache_sliding_my-key"), []byte("60000000000"), 60).Return(nil)
+
+	s := NewFreecache(client, lib_store.WithSlidingExpirationSupport())
+	err := s.Set(ctx, cacheKey, cacheValue, lib_store.WithSlidingExpiration(time.Minute))
+	assert.Nil(t, err)
+}
+
+func TestFreecacheGetWithTTLWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := []byte("my-cache-value")
+
+	client := NewMockFreecacheClientInterface(ctrl)
+	client.EXPECT().Get([]byte(cacheKey)).Return(cacheValue, nil)
+	client.EXPECT().Get([]byte("gocache_sliding_my-key")).Return([]byte("60000000000"), nil)
+	client.EXPECT().Touch([]byte(cacheKey), 60).Return(nil)
+	client.EXPECT().Touch([]byte("gocache_sliding_my-key"), 60).Return(nil)
+
+	s := NewFreecache(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	value, ttl, err := s.GetWithTTL(ctx, cacheKey)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, cacheValue, value)
+	assert.Equal(t, time.Minute, ttl)
+}
+
 func TestFreecacheSetWithDefaultOptions(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -224,13 +287,12 @@ func TestFreecacheSetInvalidValue(t *testing.T) {
 
 	cacheKey := "my-key"
 	cacheValue := "my-cache-value"
-	expectedErr := errors.New("value type not supported by Freecache store")
 
 	client := NewMockFreecacheClientInterface(ctrl)
 
 	s := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
 	err := s.Set(ctx, cacheKey, cacheValue, lib_store.WithExpiration(6*time.Second))
-	assert.Equal(t, expectedErr, err)
+	assert.ErrorIs(t, err, lib_store.ErrInvalidValue)
 }
 
 func TestFreecacheSetInvalidSize(t *testing.T) {
@@ -247,7 +309,7 @@ func TestFreecacheSetInvalidSize(t *testing.T) {
 
 	s := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
 	err := s.Set(ctx, cacheKey, cacheValue, lib_store.WithExpiration(6*time.Second))
-	assert.NotNil(t, err)
+	assert.ErrorIs(t, err, lib_store.ErrInvalidValue)
 }
 
 func TestFreecacheSetInvalidKey(t *testing.T) {
@@ -259,13 +321,11 @@ func TestFreecacheSetInvalidKey(t *testing.T) {
 	cacheKey := 1
 	cacheValue := []byte("my-cache-value")
 
-	expectedErr := errors.New("key type not supported by Freecache store")
-
 	client := NewMockFreecacheClientInterface(ctrl)
 
 	s := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
 	err := s.Set(ctx, cacheKey, cacheValue, lib_store.WithExpiration(6*time.Second))
-	assert.Equal(t, expectedErr, err)
+	assert.ErrorIs(t, err, lib_store.ErrInvalidKeyType)
 }
 
 func TestFreecacheDelete(t *testing.T) {
@@ -278,6 +338,7 @@ func TestFreecacheDelete(t *testing.T) {
 
 	client := NewMockFreecacheClientInterface(ctrl)
 	client.EXPECT().Del(gomock.Any()).Return(true)
+	client.EXPECT().Get([]byte("freecache_tag___key__:key")).Return(nil, freecache.ErrNotFound)
 
 	s := NewFreecache(client)
 	err := s.Delete(ctx, cacheKey)
@@ -294,6 +355,7 @@ func TestFreecacheDeleteFailed(t *testing.T) {
 	expectedErr := fmt.Errorf("failed to delete key %v", cacheKey)
 	client := NewMockFreecacheClientInterface(ctrl)
 	client.EXPECT().Del(gomock.Any()).Return(false)
+	client.EXPECT().Get([]byte("freecache_tag___key__:key")).Return(nil, freecache.ErrNotFound)
 
 	s := NewFreecache(client)
 	err := s.Delete(ctx, cacheKey)
@@ -307,12 +369,71 @@ func TestFreecacheDeleteInvalidKey(t *testing.T) {
 	ctx := context.Background()
 
 	cacheKey := 1
-	expectedErr := errors.New("key type not supported by Freecache store")
 	client := NewMockFreecacheClientInterface(ctrl)
 
 	s := NewFreecache(client)
 	err := s.Delete(ctx, cacheKey)
-	assert.Equal(t, expectedErr, err)
+	assert.ErrorIs(t, err, lib_store.ErrInvalidKeyType)
+}
+
+func TestFreecacheWithInt64Key(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockFreecacheClientInterface(ctrl)
+	client.EXPECT().SetInt(int64(42), []byte("my-value"), 60).Return(nil)
+	client.EXPECT().GetInt(int64(42)).Return([]byte("my-value"), nil)
+	client.EXPECT().DelInt(int64(42)).Return(true)
+
+	s := NewFreecache(client)
+
+	// When - Then
+	err := s.Set(ctx, int64(42), []byte("my-value"), lib_store.WithExpiration(time.Minute))
+	assert.Nil(t, err)
+
+	value, err := s.Get(ctx, int64(42))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("my-value"), value)
+
+	err = s.Delete(ctx, int64(42))
+	assert.Nil(t, err)
+}
+
+func TestFreecacheGetWithTTLWithInt64Key(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	s := NewFreecache(freecache.NewCache(512 * 1024))
+
+	err := s.Set(ctx, int64(42), []byte("my-value"), lib_store.WithExpiration(time.Minute))
+	assert.Nil(t, err)
+
+	// When
+	value, ttl, err := s.GetWithTTL(ctx, int64(42))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("my-value"), value)
+	assert.InDelta(t, time.Minute, ttl, float64(2*time.Second))
+}
+
+func TestFreecacheSetWithTagsWithInt64Key(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockFreecacheClientInterface(ctrl)
+
+	s := NewFreecache(client)
+
+	// When
+	err := s.Set(ctx, int64(42), []byte("my-value"), lib_store.WithTags([]string{"tag1"}))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ErrInvalidKeyType)
 }
 
 func TestFreecacheSetWithTags(t *testing.T) {
@@ -326,8 +447,10 @@ func TestFreecacheSetWithTags(t *testing.T) {
 
 	client := NewMockFreecacheClientInterface(ctrl)
 	client.EXPECT().Set([]byte(cacheKey), cacheValue, 6).Return(nil)
-	client.EXPECT().Get([]byte("freecache_tag_tag1")).MaxTimes(1).Return(nil, errors.New("value not found in store"))
+	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(nil, freecache.ErrNotFound)
 	client.EXPECT().Set([]byte("freecache_tag_tag1"), []byte("my-key"), 2592000).Return(nil)
+	client.EXPECT().Get([]byte("freecache_tag___key__:my-key")).Return(nil, freecache.ErrNotFound)
+	client.EXPECT().Set([]byte("freecache_tag___key__:my-key"), []byte("tag1"), 2592000).Return(nil)
 
 	s := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
 	err := s.Set(ctx, cacheKey, cacheValue, lib_store.WithExpiration(6*time.Second), lib_store.WithTags([]string{"tag1"}))
@@ -344,7 +467,9 @@ func TestFreecacheInvalidate(t *testing.T) {
 
 	client := NewMockFreecacheClientInterface(ctrl)
 	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(cacheKeys, nil)
+	client.EXPECT().TTL([]byte("freecache_tag_tag1")).Return(uint32(60), nil)
 	client.EXPECT().Del([]byte("my-key")).Return(true)
+	client.EXPECT().Get([]byte("freecache_tag___key__:my-key")).Return(nil, freecache.ErrNotFound)
 	client.EXPECT().Del([]byte("freecache_tag_tag1")).Return(true)
 
 	s := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
@@ -369,8 +494,11 @@ func TestFreecacheTagsAlreadyPresent(t *testing.T) {
 
 	client := NewMockFreecacheClientInterface(ctrl)
 	client.EXPECT().Set([]byte(cacheKey), cacheValue, 6).Return(nil)
-	client.EXPECT().Get([]byte("freecache_tag_tag1")).MaxTimes(1).Return(oldCacheKeys, nil)
+	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(oldCacheKeys, nil)
+	client.EXPECT().TTL([]byte("freecache_tag_tag1")).Return(uint32(60), nil)
 	client.EXPECT().Set([]byte("freecache_tag_tag1"), []byte("key1,key2,my-key"), 2592000).Return(nil)
+	client.EXPECT().Get([]byte("freecache_tag___key__:my-key")).Return(nil, freecache.ErrNotFound)
+	client.EXPECT().Set([]byte("freecache_tag___key__:my-key"), []byte("tag1"), 2592000).Return(nil)
 
 	s := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
 	err := s.Set(ctx, cacheKey, cacheValue, lib_store.WithExpiration(6*time.Second), lib_store.WithTags([]string{"tag1"}))
@@ -390,8 +518,12 @@ func TestFreecacheTagsRefreshTime(t *testing.T) {
 
 	client := NewMockFreecacheClientInterface(ctrl)
 	client.EXPECT().Set([]byte(cacheKey), cacheValue, 6).Return(nil)
-	client.EXPECT().Get([]byte("freecache_tag_tag1")).MaxTimes(1).Return(oldCacheKeys, nil)
+	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(oldCacheKeys, nil)
+	client.EXPECT().TTL([]byte("freecache_tag_tag1")).Return(uint32(60), nil)
 	client.EXPECT().Set([]byte("freecache_tag_tag1"), []byte("my-key"), 2592000).Return(nil)
+	client.EXPECT().Get([]byte("freecache_tag___key__:my-key")).Return([]byte("tag1"), nil)
+	client.EXPECT().TTL([]byte("freecache_tag___key__:my-key")).Return(uint32(60), nil)
+	client.EXPECT().Set([]byte("freecache_tag___key__:my-key"), []byte("tag1"), 2592000).Return(nil)
 
 	s := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
 	err := s.Set(ctx, cacheKey, cacheValue, lib_store.WithExpiration(6*time.Second), lib_store.WithTags([]string{"tag1"}))
@@ -408,9 +540,13 @@ func TestFreecacheInvalidateMultipleKeys(t *testing.T) {
 
 	client := NewMockFreecacheClientInterface(ctrl)
 	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(cacheKeys, nil)
+	client.EXPECT().TTL([]byte("freecache_tag_tag1")).Return(uint32(60), nil)
 	client.EXPECT().Del([]byte("my-key")).Return(true)
+	client.EXPECT().Get([]byte("freecache_tag___key__:my-key")).Return(nil, freecache.ErrNotFound)
 	client.EXPECT().Del([]byte("key1")).Return(true)
+	client.EXPECT().Get([]byte("freecache_tag___key__:key1")).Return(nil, freecache.ErrNotFound)
 	client.EXPECT().Del([]byte("key2")).Return(true)
+	client.EXPECT().Get([]byte("freecache_tag___key__:key2")).Return(nil, freecache.ErrNotFound)
 	client.EXPECT().Del([]byte("freecache_tag_tag1")).Return(true)
 
 	s := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
@@ -422,17 +558,21 @@ func TestFreecacheInvalidateMultipleKeys(t *testing.T) {
 	assert.Nil(t, err)
 }
 
-func TestFreecacheFailedInvalidateMultipleKeys(t *testing.T) {
+func TestFreecacheInvalidateWhenKeysAreMissing(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
 
 	ctx := context.Background()
 
-	cacheKeys := []byte("my-key,key1,key2")
+	cacheKeys := []byte("my-key,key1")
 
 	client := NewMockFreecacheClientInterface(ctrl)
 	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(cacheKeys, nil)
+	client.EXPECT().TTL([]byte("freecache_tag_tag1")).Return(uint32(60), nil)
 	client.EXPECT().Del([]byte("my-key")).Return(false)
+	client.EXPECT().Del([]byte("key1")).Return(true)
+	client.EXPECT().Get([]byte("freecache_tag___key__:key1")).Return(nil, freecache.ErrNotFound)
+	client.EXPECT().Del([]byte("freecache_tag_tag1")).Return(false)
 
 	s := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
 
@@ -440,23 +580,19 @@ func TestFreecacheFailedInvalidateMultipleKeys(t *testing.T) {
 	err := s.Invalidate(ctx, lib_store.WithInvalidateTags([]string{"tag1"}))
 
 	// Then
-	assert.EqualError(t, err, "failed to delete key my-key")
+	assert.Nil(t, err)
 }
 
-func TestFreecacheFailedInvalidatePattern(t *testing.T) {
+func TestFreecacheInvalidateWhenError(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
 
 	ctx := context.Background()
 
-	cacheKeys := []byte("my-key,key1,key2")
+	expectedErr := errors.New("unexpected error")
 
 	client := NewMockFreecacheClientInterface(ctrl)
-	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(cacheKeys, nil)
-	client.EXPECT().Del([]byte("my-key")).Return(true)
-	client.EXPECT().Del([]byte("key1")).Return(true)
-	client.EXPECT().Del([]byte("key2")).Return(true)
-	client.EXPECT().Del([]byte("freecache_tag_tag1")).Return(false)
+	client.EXPECT().Get([]byte("freecache_tag_tag1")).Return(nil, expectedErr)
 
 	s := NewFreecache(client, lib_store.WithExpiration(6*time.Second))
 
@@ -464,7 +600,32 @@ func TestFreecacheFailedInvalidatePattern(t *testing.T) {
 	err := s.Invalidate(ctx, lib_store.WithInvalidateTags([]string{"tag1"}))
 
 	// Then
-	assert.EqualError(t, err, "failed to delete key freecache_tag_tag1")
+	assert.ErrorIs(t, err, expectedErr)
+	assert.EqualError(t, err, "unable to get keys of tag 'tag1': unexpected error")
+}
+
+func TestFreecacheInvalidateWithPrefix(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	freecacheClient := freecache.NewCache(512 * 1024)
+	assert.Nil(t, freecacheClient.Set([]byte("user:1"), []byte("john"), 0))
+	assert.Nil(t, freecacheClient.Set([]byte("other-key"), []byte("other-value"), 0))
+
+	client := NewMockFreecacheClientInterface(ctrl)
+	client.EXPECT().NewIterator().Return(freecacheClient.NewIterator())
+	client.EXPECT().Del([]byte("user:1")).Return(true)
+	client.EXPECT().Get([]byte("freecache_tag___key__:user:1")).Return(nil, freecache.ErrNotFound)
+
+	s := NewFreecache(client)
+
+	// When
+	err := s.Invalidate(ctx, lib_store.WithInvalidatePrefix("user:"))
+
+	// Then
+	assert.Nil(t, err)
 }
 
 func TestFreecacheClearAll(t *testing.T) {
@@ -499,3 +660,10 @@ func TestFreecacheGetType(t *testing.T) {
 	// Then
 	assert.Equal(t, FreecacheType, ty)
 }
+
+func TestFreecacheTagIndexBackend(t *testing.T) {
+	storetest.TagIndexBackend(t, func(t *testing.T) lib_store.TagIndexBackend {
+		s := NewFreecache(freecache.NewCache(512 * 1024))
+		return lib_store.NewValueTagIndexBackend(s.getTagValue, s.setTagValue, s.deleteTagValue)
+	})
+}
@@ -1,13 +1,16 @@
 package go_cache
 
 import (
+	"cmp"
 	"context"
 	"errors"
-	"fmt"
-	"sync"
+	"iter"
+	"maps"
+	"slices"
 	"time"
 
 	lib_store "github.com/eko/gocache/lib/v4/store"
+	"github.com/patrickmn/go-cache"
 )
 
 const (
@@ -22,32 +25,71 @@ type GoCacheClientInterface interface {
 	Get(k string) (any, bool)
 	GetWithExpiration(k string) (any, time.Time, bool)
 	Set(k string, x any, d time.Duration)
+	Add(k string, x any, d time.Duration) error
+	Replace(k string, x any, d time.Duration) error
+	IncrementInt64(k string, n int64) (int64, error)
 	Delete(k string)
+	Items() map[string]cache.Item
 	Flush()
 }
 
 // GoCacheStore is a store for GoCache (memory) library
 type GoCacheStore struct {
-	mu      sync.RWMutex
 	client  GoCacheClientInterface
 	options *lib_store.Options
+	tags    *lib_store.TagIndex
 }
 
 // NewGoCache creates a new store to GoCache (memory) library instance
 func NewGoCache(client GoCacheClientInterface, options ...lib_store.Option) *GoCacheStore {
-	return &GoCacheStore{
+	s := &GoCacheStore{
 		client:  client,
 		options: lib_store.ApplyOptions(options...),
 	}
+	s.tags = lib_store.NewTagIndex(lib_store.NewValueTagIndexBackend(s.getTagValue, s.setTagValue, s.deleteTagValue), GoCacheTagPattern)
+
+	return s
+}
+
+// getTagValue, setTagValue and deleteTagValue store the tag index in GoCache
+func (s *GoCacheStore) getTagValue(_ context.Context, key string) ([]byte, time.Duration, error) {
+	value, expiration, exists := s.client.GetWithExpiration(key)
+	if !exists {
+		return nil, 0, lib_store.NotFoundWithCause(errors.New("value not found in GoCache store"))
+	}
+
+	var ttl time.Duration
+	if !expiration.IsZero() {
+		ttl = time.Until(expiration)
+	}
+
+	bytes, _ := value.([]byte)
+	return bytes, ttl, nil
+}
+
+func (s *GoCacheStore) setTagValue(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.client.Set(key, value, ttl)
+	return nil
 }
 
-// Get returns data stored from a given key
+func (s *GoCacheStore) deleteTagValue(_ context.Context, key string) error {
+	s.client.Delete(key)
+	return nil
+}
+
+// Get returns data stored from a given key. When the store supports sliding expirations,
+// the expiration of the key is renewed if it has been set with one.
 func (s *GoCacheStore) Get(_ context.Context, key any) (any, error) {
-	var err error
-	keyStr := key.(string)
-	value, exists := s.client.Get(keyStr)
+	goCacheKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return nil, err
+	}
+
+	value, exists := s.client.Get(goCacheKey)
 	if !exists {
 		err = lib_store.NotFoundWithCause(errors.New("value not found in GoCache store"))
+	} else if s.options.SlidingExpirationSupport {
+		s.renew(goCacheKey, value)
 	}
 
 	return value, err
@@ -55,94 +97,248 @@ func (s *GoCacheStore) Get(_ context.Context, key any) (any, error) {
 
 // GetWithTTL returns data stored from a given key and its corresponding TTL
 func (s *GoCacheStore) GetWithTTL(_ context.Context, key any) (any, time.Duration, error) {
-	data, t, exists := s.client.GetWithExpiration(key.(string))
+	goCacheKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	data, t, exists := s.client.GetWithExpiration(goCacheKey)
 	if !exists {
 		return data, 0, lib_store.NotFoundWithCause(errors.New("value not found in GoCache store"))
 	}
+	if s.options.SlidingExpirationSupport {
+		if sliding := s.renew(goCacheKey, data); sliding > 0 {
+			return data, sliding, nil
+		}
+	}
 	duration := time.Until(t)
 	return data, duration, nil
 }
 
+// renew sets the given value again with its sliding expiration, if it has one,
+// along with the item holding it. The sliding expiration is returned.
+// GoCache has no way to renew an expiration on read, so the renewal is not
+// atomic with regard to concurrent writes of the key.
+func (s *GoCacheStore) renew(key string, value any) time.Duration {
+	sliding := s.slidingExpiration(key)
+	if sliding <= 0 {
+		return 0
+	}
+
+	// the item may have expired or been deleted in the meantime
+	if err := s.client.Replace(key, value, sliding); err == nil {
+		s.client.Set(lib_store.SlidingExpirationKey(key), sliding, sliding)
+	}
+
+	return sliding
+}
+
+// slidingExpiration returns the sliding expiration stored for the given key, if any
+func (s *GoCacheStore) slidingExpiration(key string) time.Duration {
+	value, exists := s.client.Get(lib_store.SlidingExpirationKey(key))
+	if !exists {
+		return 0
+	}
+
+	sliding, _ := value.(time.Duration)
+	return sliding
+}
+
 // Set defines data in GoCache memoey cache for given key identifier
 func (s *GoCacheStore) Set(ctx context.Context, key any, value any, options ...lib_store.Option) error {
+	goCacheKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
 	opts := lib_store.ApplyOptions(options...)
 	if opts == nil {
 		opts = s.options
 	}
+	// default options are not applied, except for the strict mode and the expiration jitter
+	opts.Strict = opts.Strict || s.options.Strict
+	opts.ExpirationJitter = cmp.Or(opts.ExpirationJitter, s.options.ExpirationJitter)
+	opts.MaxExpirationJitter = cmp.Or(opts.MaxExpirationJitter, s.options.MaxExpirationJitter)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return err
+	}
 
-	s.client.Set(key.(string), value, opts.Expiration)
+	s.client.Set(goCacheKey, value, opts.EffectiveExpiration())
 
-	if tags := opts.Tags; len(tags) > 0 {
-		s.setTags(ctx, key, tags)
+	if s.options.SlidingExpirationSupport {
+		if opts.SlidingExpiration > 0 {
+			s.client.Set(lib_store.SlidingExpirationKey(goCacheKey), opts.SlidingExpiration, opts.SlidingExpiration)
+		} else {
+			s.client.Delete(lib_store.SlidingExpirationKey(goCacheKey))
+		}
 	}
 
-	return nil
+	return s.tags.Add(ctx, goCacheKey, opts.Tags, opts.TagsTTL)
 }
 
-func (s *GoCacheStore) setTags(ctx context.Context, key any, tags []string) {
-	for _, tag := range tags {
-		tagKey := fmt.Sprintf(GoCacheTagPattern, tag)
-		var cacheKeys map[string]struct{}
+// Increment atomically adds the given delta to the counter stored for the given key.
+// Counters are stored as int64 values.
+func (s *GoCacheStore) Increment(_ context.Context, key any, delta int64, options ...lib_store.Option) (int64, error) {
+	goCacheKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return 0, err
+	}
 
-		if result, err := s.Get(ctx, tagKey); err == nil {
-			if bytes, ok := result.(map[string]struct{}); ok {
-				cacheKeys = bytes
-			}
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < 3; i++ {
+		var value int64
+		if value, err = s.client.IncrementInt64(goCacheKey, delta); err == nil {
+			return value, nil
+		}
+		if _, exists := s.client.Get(goCacheKey); exists {
+			// the stored value is not an int64
+			return 0, lib_store.InvalidValueWithCause(err)
 		}
 
-		s.mu.RLock()
-		if _, exists := cacheKeys[key.(string)]; exists {
-			s.mu.RUnlock()
-			continue
+		// use Add to create the counter only if still not there
+		if err = s.client.Add(goCacheKey, delta, opts.EffectiveExpiration()); err == nil {
+			return delta, nil
 		}
-		s.mu.RUnlock()
+		// loop to retry as the counter has been created in the meantime
+	}
+
+	return 0, err
+}
+
+// Decrement atomically subtracts the given delta from the counter stored for the given key
+func (s *GoCacheStore) Decrement(ctx context.Context, key any, delta int64, options ...lib_store.Option) (int64, error) {
+	return s.Increment(ctx, key, -delta, options...)
+}
+
+// Keys iterates over the keys stored in GoCache memory cache, sorted
+// alphabetically. Expired items, tag and sliding expiration keys are not returned.
+func (s *GoCacheStore) Keys(_ context.Context, options ...lib_store.ScanOption) iter.Seq2[any, error] {
+	opts := lib_store.ApplyScanOptions(options...)
 
-		if cacheKeys == nil {
-			cacheKeys = make(map[string]struct{})
+	return func(yield func(any, error) bool) {
+		for _, key := range slices.Sorted(maps.Keys(s.client.Items())) {
+			if s.tags.IsIndexKey(key) || lib_store.IsSlidingExpirationKey(key) || !lib_store.MatchPattern(opts.Match, key) {
+				continue
+			}
+			if !yield(key, nil) {
+				return
+			}
 		}
+	}
+}
+
+// Touch changes the expiration of the given key.
+// GoCache is not able to change the expiration of an item so the value is
+// replaced by itself, which is not atomic with regard to concurrent writes.
+func (s *GoCacheStore) Touch(_ context.Context, key any, ttl time.Duration) error {
+	goCacheKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Capabilities().CheckOptions(&lib_store.Options{Strict: s.options.Strict, Expiration: ttl}); err != nil {
+		return err
+	}
 
-		s.mu.Lock()
-		cacheKeys[key.(string)] = struct{}{}
-		s.mu.Unlock()
+	value, exists := s.client.Get(goCacheKey)
+	if !exists {
+		return lib_store.NotFoundWithCause(errors.New("value not found in GoCache store"))
+	}
 
-		s.client.Set(tagKey, cacheKeys, 720*time.Hour)
+	if err := s.client.Replace(goCacheKey, value, ttl); err != nil {
+		// the item has expired or has been deleted in the meantime
+		return lib_store.NotFoundWithCause(err)
 	}
+
+	return nil
 }
 
 // Delete removes data in GoCache memoey cache for given key identifier
-func (s *GoCacheStore) Delete(_ context.Context, key any) error {
-	s.client.Delete(key.(string))
-	return nil
+func (s *GoCacheStore) Delete(ctx context.Context, key any) error {
+	goCacheKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
+	s.client.Delete(goCacheKey)
+	if s.options.SlidingExpirationSupport {
+		s.client.Delete(lib_store.SlidingExpirationKey(goCacheKey))
+	}
+	return s.tags.Remove(ctx, goCacheKey)
 }
 
 // Invalidate invalidates some cache data in GoCache memoey cache for given options
 func (s *GoCacheStore) Invalidate(ctx context.Context, options ...lib_store.InvalidateOption) error {
 	opts := lib_store.ApplyInvalidateOptions(options...)
 
+	var err error
 	if tags := opts.Tags; len(tags) > 0 {
-		for _, tag := range tags {
-			tagKey := fmt.Sprintf(GoCacheTagPattern, tag)
-			result, err := s.Get(ctx, tagKey)
-			if err != nil {
-				return nil
-			}
+		err = s.tags.Invalidate(ctx, tags, func(ctx context.Context, key string) error {
+			return s.Delete(ctx, key)
+		})
+	}
 
-			var cacheKeys map[string]struct{}
-			if bytes, ok := result.(map[string]struct{}); ok {
-				cacheKeys = bytes
-			}
+	if opts.HasKeyFilter() {
+		err = errors.Join(err, s.invalidateKeys(ctx, opts))
+	}
 
-			s.mu.RLock()
-			for cacheKey := range cacheKeys {
-				_ = s.Delete(ctx, cacheKey)
-			}
-			s.mu.RUnlock()
+	return err
+}
+
+// invalidateKeys deletes the keys matching the prefix or pattern of the given options.
+// Matching keys are collected before being deleted so the iterator is not invalidated.
+func (s *GoCacheStore) invalidateKeys(ctx context.Context, opts *lib_store.InvalidateOptions) error {
+	keys := []string{}
+	for key, err := range s.Keys(ctx, lib_store.WithScanMatch(opts.KeyPattern())) {
+		if err != nil {
+			return err
+		}
+		goCacheKey, err := lib_store.KeyAs[string](key)
+		if err != nil {
+			return err
+		}
+		if opts.MatchKey(goCacheKey) {
+			keys = append(keys, goCacheKey)
+		}
+	}
+
+	for _, key := range keys {
+		if err := s.Delete(ctx, key); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// SlidingExpiration returns the sliding expiration given to the key, if any
+func (s *GoCacheStore) SlidingExpiration(_ context.Context, key any) (time.Duration, error) {
+	goCacheKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return 0, err
+	}
+
+	if !s.options.SlidingExpirationSupport {
+		return 0, nil
+	}
+	return s.slidingExpiration(goCacheKey), nil
+}
+
+// Capabilities returns the features supported by GoCache memory cache
+func (s *GoCacheStore) Capabilities() lib_store.Capabilities {
+	return lib_store.Capabilities{
+		TTLPrecision:      time.Nanosecond,
+		Tags:              true,
+		Scan:              true,
+		AtomicCounters:    true,
+		SlidingExpiration: s.options.SlidingExpirationSupport,
+	}
+}
+
 // GetType returns the store type
 func (s *GoCacheStore) GetType() string {
 	return GoCacheType
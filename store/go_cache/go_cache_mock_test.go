@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	go_cache "github.com/patrickmn/go-cache"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,6 +41,20 @@ func (m *MockGoCacheClientInterface) EXPECT() *MockGoCacheClientInterfaceMockRec
 	return m.recorder
 }
 
+// Add mocks base method.
+func (m *MockGoCacheClientInterface) Add(k string, x any, d time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", k, x, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockGoCacheClientInterfaceMockRecorder) Add(k, x, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockGoCacheClientInterface)(nil).Add), k, x, d)
+}
+
 // Delete mocks base method.
 func (m *MockGoCacheClientInterface) Delete(k string) {
 	m.ctrl.T.Helper()
@@ -95,6 +110,49 @@ func (mr *MockGoCacheClientInterfaceMockRecorder) GetWithExpiration(k any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithExpiration", reflect.TypeOf((*MockGoCacheClientInterface)(nil).GetWithExpiration), k)
 }
 
+// IncrementInt64 mocks base method.
+func (m *MockGoCacheClientInterface) IncrementInt64(k string, n int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementInt64", k, n)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementInt64 indicates an expected call of IncrementInt64.
+func (mr *MockGoCacheClientInterfaceMockRecorder) IncrementInt64(k, n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementInt64", reflect.TypeOf((*MockGoCacheClientInterface)(nil).IncrementInt64), k, n)
+}
+
+// Items mocks base method.
+func (m *MockGoCacheClientInterface) Items() map[string]go_cache.Item {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Items")
+	ret0, _ := ret[0].(map[string]go_cache.Item)
+	return ret0
+}
+
+// Items indicates an expected call of Items.
+func (mr *MockGoCacheClientInterfaceMockRecorder) Items() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Items", reflect.TypeOf((*MockGoCacheClientInterface)(nil).Items))
+}
+
+// Replace mocks base method.
+func (m *MockGoCacheClientInterface) Replace(k string, x any, d time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", k, x, d)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockGoCacheClientInterfaceMockRecorder) Replace(k, x, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockGoCacheClientInterface)(nil).Replace), k, x, d)
+}
+
 // Set mocks base method.
 func (m *MockGoCacheClientInterface) Set(k string, x any, d time.Duration) {
 	m.ctrl.T.Helper()
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	lib_store "github.com/eko/gocache/lib/v4/store"
+	"github.com/eko/gocache/lib/v4/store/storetest"
 	"github.com/patrickmn/go-cache"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -167,9 +168,10 @@ func TestGoCacheSetWithTags(t *testing.T) {
 
 	client := NewMockGoCacheClientInterface(ctrl)
 	client.EXPECT().Set(cacheKey, cacheValue, 0*time.Second)
-	client.EXPECT().Get("gocache_tag_tag1").Return(nil, true)
-	cacheKeys := map[string]struct{}{"my-key": {}}
-	client.EXPECT().Set("gocache_tag_tag1", cacheKeys, 720*time.Hour)
+	client.EXPECT().GetWithExpiration("gocache_tag_tag1").Return(nil, time.Time{}, false)
+	client.EXPECT().Set("gocache_tag_tag1", []byte("my-key"), lib_store.DefaultTagsTTL)
+	client.EXPECT().GetWithExpiration("gocache_tag___key__:my-key").Return(nil, time.Time{}, false)
+	client.EXPECT().Set("gocache_tag___key__:my-key", []byte("tag1"), lib_store.DefaultTagsTTL)
 
 	store := NewGoCache(client)
 
@@ -191,9 +193,10 @@ func TestGoCacheSetWithTagsWhenAlreadyInserted(t *testing.T) {
 
 	client := NewMockGoCacheClientInterface(ctrl)
 	client.EXPECT().Set(cacheKey, cacheValue, 0*time.Second)
-
-	cacheKeys := map[string]struct{}{"my-key": {}, "a-second-key": {}}
-	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, true)
+	client.EXPECT().GetWithExpiration("gocache_tag_tag1").Return([]byte("my-key,a-second-key"), time.Time{}, true)
+	client.EXPECT().Set("gocache_tag_tag1", []byte("my-key,a-second-key"), lib_store.DefaultTagsTTL)
+	client.EXPECT().GetWithExpiration("gocache_tag___key__:my-key").Return(nil, time.Time{}, false)
+	client.EXPECT().Set("gocache_tag___key__:my-key", []byte("tag1"), lib_store.DefaultTagsTTL)
 
 	store := NewGoCache(client)
 
@@ -204,6 +207,81 @@ func TestGoCacheSetWithTagsWhenAlreadyInserted(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestGoCacheSetWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := "my-cache-value"
+
+	client := NewMockGoCacheClientInterface(ctrl)
+	client.EXPECT().Set(cacheKey, cacheValue, time.Minute)
+	client.EXPECT().Set("gocache_sliding_my-key", time.Minute, time.Minute)
+
+	store := NewGoCache(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Set(ctx, cacheKey, cacheValue, lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestGoCacheGetWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := "my-cache-value"
+
+	client := NewMockGoCacheClientInterface(ctrl)
+	client.EXPECT().GetWithExpiration(cacheKey).Return(cacheValue, time.Now().Add(time.Second), true)
+	client.EXPECT().Get("gocache_sliding_my-key").Return(time.Minute, true)
+	client.EXPECT().Replace(cacheKey, cacheValue, time.Minute).Return(nil)
+	client.EXPECT().Set("gocache_sliding_my-key", time.Minute, time.Minute)
+
+	store := NewGoCache(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	value, ttl, err := store.GetWithTTL(ctx, cacheKey)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, cacheValue, value)
+	assert.Equal(t, time.Minute, ttl)
+}
+
+func TestGoCacheSlidingExpirationRenewal(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	store := NewGoCache(cache.New(cache.NoExpiration, cache.NoExpiration), lib_store.WithSlidingExpirationSupport())
+
+	err := store.Set(ctx, "my-key", "my-cache-value", lib_store.WithSlidingExpiration(100*time.Millisecond))
+	assert.Nil(t, err)
+
+	// When
+	for range 4 {
+		time.Sleep(50 * time.Millisecond)
+
+		_, err = store.Get(ctx, "my-key")
+		assert.Nil(t, err)
+	}
+	sliding, slidingErr := store.SlidingExpiration(ctx, "my-key")
+
+	time.Sleep(150 * time.Millisecond)
+	_, expiredErr := store.Get(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, slidingErr)
+	assert.Equal(t, 100*time.Millisecond, sliding)
+	assert.ErrorIs(t, expiredErr, lib_store.NotFound{})
+}
+
 func TestGoCacheDelete(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -214,6 +292,7 @@ func TestGoCacheDelete(t *testing.T) {
 
 	client := NewMockGoCacheClientInterface(ctrl)
 	client.EXPECT().Delete(cacheKey)
+	client.EXPECT().GetWithExpiration("gocache_tag___key__:my-key").Return(nil, time.Time{}, false)
 
 	store := NewGoCache(client)
 
@@ -224,18 +303,141 @@ func TestGoCacheDelete(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestGoCacheIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockGoCacheClientInterface(ctrl)
+	client.EXPECT().IncrementInt64("my-counter", int64(2)).Return(int64(5), nil)
+
+	store := NewGoCache(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), value)
+}
+
+func TestGoCacheIncrementWhenCounterDoesNotExist(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := cache.New(cache.NoExpiration, cache.NoExpiration)
+
+	store := NewGoCache(client)
+
+	// When
+	first, firstErr := store.Increment(ctx, "my-counter", 2, lib_store.WithExpiration(time.Minute))
+	second, secondErr := store.Decrement(ctx, "my-counter", 5, lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, firstErr)
+	assert.Equal(t, int64(2), first)
+
+	assert.Nil(t, secondErr)
+	assert.Equal(t, int64(-3), second)
+
+	_, expiration, _ := client.GetWithExpiration("my-counter")
+	assert.WithinDuration(t, time.Now().Add(time.Minute), expiration, time.Second)
+}
+
+func TestGoCacheIncrementWhenValueIsNotAnInteger(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := cache.New(cache.NoExpiration, cache.NoExpiration)
+	client.Set("my-key", "my-value", cache.NoExpiration)
+
+	store := NewGoCache(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-key", 1)
+
+	// Then
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), value)
+}
+
+func TestGoCacheTouch(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := cache.New(cache.NoExpiration, cache.NoExpiration)
+	client.Set("my-key", "my-value", cache.NoExpiration)
+
+	store := NewGoCache(client)
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.Nil(t, err)
+
+	value, ttl, err := store.GetWithTTL(ctx, "my-key")
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.InDelta(t, time.Minute, ttl, float64(time.Second))
+}
+
+func TestGoCacheTouchWhenKeyDoesNotExist(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	store := NewGoCache(cache.New(cache.NoExpiration, cache.NoExpiration))
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.NotFound{})
+}
+
+func TestGoCacheKeys(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockGoCacheClientInterface(ctrl)
+	client.EXPECT().Items().Return(map[string]cache.Item{
+		"key-2":            {Object: "value-2"},
+		"key-1":            {Object: "value-1"},
+		"other-key":        {Object: "other-value"},
+		"gocache_tag_key1": {Object: []byte("key-1")},
+	})
+
+	store := NewGoCache(client)
+
+	// When
+	keys := []any{}
+	for key, err := range store.Keys(ctx, lib_store.WithScanMatch("key-*")) {
+		assert.Nil(t, err)
+		keys = append(keys, key)
+	}
+
+	// Then
+	assert.Equal(t, []any{"key-1", "key-2"}, keys)
+}
+
 func TestGoCacheInvalidate(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
 
 	ctx := context.Background()
 
-	cacheKeys := map[string]struct{}{"a23fdf987h2svc23": {}, "jHG2372x38hf74": {}}
+	cacheKeys := []byte("a23fdf987h2svc23,jHG2372x38hf74")
 
 	client := NewMockGoCacheClientInterface(ctrl)
-	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, true)
+	client.EXPECT().GetWithExpiration("gocache_tag_tag1").Return(cacheKeys, time.Time{}, true)
 	client.EXPECT().Delete("a23fdf987h2svc23")
+	client.EXPECT().GetWithExpiration("gocache_tag___key__:a23fdf987h2svc23").Return(nil, time.Time{}, false)
 	client.EXPECT().Delete("jHG2372x38hf74")
+	client.EXPECT().GetWithExpiration("gocache_tag___key__:jHG2372x38hf74").Return(nil, time.Time{}, false)
+	client.EXPECT().Delete("gocache_tag_tag1")
 
 	store := NewGoCache(client)
 
@@ -255,7 +457,8 @@ func TestGoCacheInvalidateWhenError(t *testing.T) {
 	cacheKeys := []byte("a23fdf987h2svc23,jHG2372x38hf74")
 
 	client := NewMockGoCacheClientInterface(ctrl)
-	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, false)
+	client.EXPECT().GetWithExpiration("gocache_tag_tag1").Return(cacheKeys, time.Time{}, false)
+	client.EXPECT().Delete("gocache_tag_tag1")
 
 	store := NewGoCache(client)
 
@@ -266,6 +469,33 @@ func TestGoCacheInvalidateWhenError(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestGoCacheInvalidateWithPrefix(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockGoCacheClientInterface(ctrl)
+	client.EXPECT().Items().Return(map[string]cache.Item{
+		"user:1":           {Object: "john"},
+		"user:2":           {Object: "jane"},
+		"other-key":        {Object: "other-value"},
+		"gocache_tag_tag1": {Object: []byte("user:1")},
+	})
+	client.EXPECT().Delete("user:1")
+	client.EXPECT().GetWithExpiration("gocache_tag___key__:user:1").Return(nil, time.Time{}, false)
+	client.EXPECT().Delete("user:2")
+	client.EXPECT().GetWithExpiration("gocache_tag___key__:user:2").Return(nil, time.Time{}, false)
+
+	store := NewGoCache(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePrefix("user:"))
+
+	// Then
+	assert.Nil(t, err)
+}
+
 func TestGoCacheClear(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -344,3 +574,10 @@ func TestGoCacheInvalidateConcurrency(t *testing.T) {
 
 	}
 }
+
+func TestGoCacheTagIndexBackend(t *testing.T) {
+	storetest.TagIndexBackend(t, func(t *testing.T) lib_store.TagIndexBackend {
+		s := NewGoCache(cache.New(10*time.Second, 30*time.Second))
+		return lib_store.NewValueTagIndexBackend(s.getTagValue, s.setTagValue, s.deleteTagValue)
+	})
+}
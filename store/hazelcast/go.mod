@@ -7,7 +7,6 @@ require (
 	github.com/hazelcast/hazelcast-go-client v1.4.1
 	github.com/stretchr/testify v1.11.1
 	go.uber.org/mock v0.6.0
-	golang.org/x/sync v0.19.0
 )
 
 require (
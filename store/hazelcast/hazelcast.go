@@ -4,13 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"slices"
 	"strings"
 	"time"
+	"unicode"
 
 	lib_store "github.com/eko/gocache/lib/v4/store"
+	"github.com/hazelcast/hazelcast-go-client/hzerrors"
+	"github.com/hazelcast/hazelcast-go-client/predicate"
 	"github.com/hazelcast/hazelcast-go-client/types"
-	"golang.org/x/sync/errgroup"
 )
 
 // HazelcastMapInterface represents a hazelcast/hazelcast-go-client map
@@ -18,10 +19,13 @@ type HazelcastMapInterface interface {
 	Get(ctx context.Context, key any) (any, error)
 	GetEntryView(ctx context.Context, key any) (*types.SimpleEntryView, error)
 	SetWithTTL(ctx context.Context, key any, value any, ttl time.Duration) error
+	SetWithTTLAndMaxIdle(ctx context.Context, key any, value any, ttl time.Duration, maxIdle time.Duration) error
 	SetTTL(ctx context.Context, key any, ttl time.Duration) error
+	SetTTLAffected(ctx context.Context, key any, ttl time.Duration) (bool, error)
 	PutIfAbsentWithTTL(ctx context.Context, key any, value any, ttl time.Duration) (any, error)
 	ReplaceIfSame(ctx context.Context, key any, oldValue any, newValue any) (bool, error)
 	Remove(ctx context.Context, key any) (any, error)
+	RemoveAll(ctx context.Context, predicate predicate.Predicate) error
 	Clear(ctx context.Context) error
 }
 
@@ -36,6 +40,7 @@ const (
 type HazelcastStore struct {
 	hzMap   HazelcastMapInterface
 	options *lib_store.Options
+	tags    *lib_store.TagIndex
 }
 
 // NewHazelcast creates a new store to Hazelcast instance(s)
@@ -43,6 +48,7 @@ func NewHazelcast(hzMap HazelcastMapInterface, options ...lib_store.Option) *Haz
 	return &HazelcastStore{
 		hzMap:   hzMap,
 		options: lib_store.ApplyOptions(options...),
+		tags:    lib_store.NewTagIndex(&tagIndexBackend{hzMap: hzMap}, HazelcastTagPattern),
 	}
 }
 
@@ -50,7 +56,7 @@ func NewHazelcast(hzMap HazelcastMapInterface, options ...lib_store.Option) *Haz
 func (s *HazelcastStore) Get(ctx context.Context, key any) (any, error) {
 	value, err := s.hzMap.Get(ctx, key)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 	if value == nil {
 		return nil, lib_store.NotFoundWithCause(errors.New("unable to retrieve data from hazelcast"))
@@ -58,122 +64,283 @@ func (s *HazelcastStore) Get(ctx context.Context, key any) (any, error) {
 	return value, err
 }
 
-// GetWithTTL returns data stored from a given key and its corresponding TTL
+// GetWithTTL returns data stored from a given key and its corresponding TTL.
+// The TTL of a value set with a sliding expiration is its maximum idle time,
+// the value being read again in order to renew it.
 func (s *HazelcastStore) GetWithTTL(ctx context.Context, key any) (any, time.Duration, error) {
 	entryView, err := s.hzMap.GetEntryView(ctx, key)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, mapError(err)
 	}
 	if entryView == nil {
 		return nil, 0, lib_store.NotFoundWithCause(errors.New("unable to retrieve data from hazelcast"))
 	}
+	if entryView.MaxIdle > 0 {
+		value, err := s.Get(ctx, key)
+		return value, time.Duration(entryView.MaxIdle) * time.Millisecond, err
+	}
 	return entryView.Value, time.Duration(entryView.TTL) * time.Millisecond, err
 }
 
-// Set defines data in Hazelcast for given key identifier
+// Set defines data in Hazelcast for given key identifier. A sliding expiration
+// is set as the maximum idle time of the entry, which Hazelcast renews on reads.
 func (s *HazelcastStore) Set(ctx context.Context, key any, value any, options ...lib_store.Option) error {
-	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
-	err := s.hzMap.SetWithTTL(ctx, key, value, opts.Expiration)
+	hazelcastKey, err := lib_store.KeyAs[string](key)
 	if err != nil {
 		return err
 	}
-	if tags := opts.Tags; len(tags) > 0 {
-		s.setTags(ctx, s.hzMap, key, tags, opts.TagsTTL)
+
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return err
 	}
-	return nil
+
+	if opts.SlidingExpiration > 0 {
+		err = s.hzMap.SetWithTTLAndMaxIdle(ctx, key, value, 0, opts.SlidingExpiration)
+	} else {
+		err = s.hzMap.SetWithTTL(ctx, key, value, opts.EffectiveExpiration())
+	}
+	if err != nil {
+		return mapError(err)
+	}
+	return s.tags.Add(ctx, hazelcastKey, opts.Tags, opts.TagsTTL)
 }
 
-func (s *HazelcastStore) setTags(ctx context.Context, hzMap HazelcastMapInterface, key any, tags []string, ttl time.Duration) {
-	group, ctx := errgroup.WithContext(ctx)
-	for _, tag := range tags {
-		currentTag := tag
-		group.Go(func() error {
-			tagKey := fmt.Sprintf(HazelcastTagPattern, currentTag)
+// SetIfNotExists defines data in Hazelcast for given key identifier only if
+// it does not exist yet
+func (s *HazelcastStore) SetIfNotExists(ctx context.Context, key any, value any, options ...lib_store.Option) error {
+	hazelcastKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
 
-			var err error
-			for i := 0; i < 3; i++ {
-				if err = s.addKeyToTagValue(ctx, hzMap, tagKey, key, ttl); err == nil {
-					return nil
-				}
-				// loop to retry any failure (including race conditions)
-			}
-			return err
-		})
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return err
 	}
-	group.Wait()
+
+	existing, err := s.hzMap.PutIfAbsentWithTTL(ctx, key, value, opts.EffectiveExpiration())
+	if err != nil {
+		return mapError(err)
+	}
+	if existing != nil {
+		return lib_store.ConditionFailedWithCause(nil)
+	}
+	return s.tags.Add(ctx, hazelcastKey, opts.Tags, opts.TagsTTL)
+}
+
+// GetWithVersion returns data stored from a given key, the value itself
+// being used as version
+func (s *HazelcastStore) GetWithVersion(ctx context.Context, key any) (any, lib_store.Version, error) {
+	value, err := s.Get(ctx, key)
+	if err != nil {
+		return nil, lib_store.Version{}, err
+	}
+	return value, lib_store.NewVersion(value), nil
 }
 
-func (s *HazelcastStore) addKeyToTagValue(ctx context.Context, hzMap HazelcastMapInterface, tagKey string, key any, ttl time.Duration) error {
-	tagValue, err := hzMap.Get(ctx, tagKey)
+// CompareAndSwap defines data in Hazelcast for given key identifier only if its
+// current value still is the one the version has been created from
+func (s *HazelcastStore) CompareAndSwap(ctx context.Context, key any, value any, version lib_store.Version, options ...lib_store.Option) error {
+	hazelcastKey, err := lib_store.KeyAs[string](key)
 	if err != nil {
 		return err
 	}
 
-	if tagValue == nil {
-		// first writer: try to insert atomically
-		prev, err := hzMap.PutIfAbsentWithTTL(ctx, tagKey, key.(string), ttl)
-		if err != nil {
-			return err
-		}
-		if prev == nil {
-			// PutIfAbsent returns the existing value or nil if absent;
-			// nil here means our insert succeeded.
-			return nil
-		}
-		// somebody else inserted; fall through to update path with the new value
-		tagValue = prev
+	if version.IsZero() {
+		return lib_store.ConditionFailedWithCause(nil)
 	}
 
-	oldStr := tagValue.(string)
-	cacheKeys := strings.Split(oldStr, ",")
-	if slices.Contains(cacheKeys, key.(string)) {
-		return hzMap.SetTTL(ctx, tagKey, ttl)
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return err
 	}
-	newStr := strings.Join(append(cacheKeys, key.(string)), ",")
 
-	ok, err := hzMap.ReplaceIfSame(ctx, tagKey, oldStr, newStr)
+	replaced, err := s.hzMap.ReplaceIfSame(ctx, key, version.Value(), value)
 	if err != nil {
+		return mapError(err)
+	}
+	if !replaced {
+		return lib_store.ConditionFailedWithCause(nil)
+	}
+	if opts.Expiration > 0 || !opts.ExpireAt.IsZero() {
+		if err := s.hzMap.SetTTL(ctx, key, opts.EffectiveExpiration()); err != nil {
+			return mapError(err)
+		}
+	}
+	return s.tags.Add(ctx, hazelcastKey, opts.Tags, opts.TagsTTL)
+}
+
+// Touch changes the expiration of the given key using SetTTL
+func (s *HazelcastStore) Touch(ctx context.Context, key any, ttl time.Duration) error {
+	if err := s.Capabilities().CheckOptions(&lib_store.Options{Strict: s.options.Strict, Expiration: ttl}); err != nil {
 		return err
 	}
-	if !ok {
-		return errors.New("hazelcast tag key contended")
+
+	affected, err := s.hzMap.SetTTLAffected(ctx, key, ttl)
+	if err != nil {
+		return mapError(err)
 	}
+	if !affected {
+		return lib_store.NotFoundWithCause(errors.New("unable to retrieve data from hazelcast"))
+	}
+	return nil
+}
 
-	return hzMap.SetTTL(ctx, tagKey, ttl)
+// SlidingExpiration returns the sliding expiration given to the key, if any,
+// which is the maximum idle time of its entry
+func (s *HazelcastStore) SlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	entryView, err := s.hzMap.GetEntryView(ctx, key)
+	if err != nil {
+		return 0, mapError(err)
+	}
+	if entryView == nil {
+		return 0, nil
+	}
+	return time.Duration(entryView.MaxIdle) * time.Millisecond, nil
 }
 
 // Delete removes data from Hazelcast for given key identifier
 func (s *HazelcastStore) Delete(ctx context.Context, key any) error {
-	_, err := s.hzMap.Remove(ctx, key)
-	return err
+	hazelcastKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.hzMap.Remove(ctx, key); err != nil {
+		return mapError(err)
+	}
+	return s.tags.Remove(ctx, hazelcastKey)
 }
 
 // Invalidate invalidates some cache data in Hazelcast for given options
 func (s *HazelcastStore) Invalidate(ctx context.Context, options ...lib_store.InvalidateOption) error {
 	opts := lib_store.ApplyInvalidateOptions(options...)
+
+	var err error
 	if tags := opts.Tags; len(tags) > 0 {
-		for _, tag := range tags {
-			tagKey := fmt.Sprintf(HazelcastTagPattern, tag)
-			tagValue, err := s.hzMap.Get(ctx, tagKey)
-			if err != nil || tagValue == nil {
-				continue
+		err = s.tags.Invalidate(ctx, tags, func(ctx context.Context, key string) error {
+			return s.Delete(ctx, key)
+		})
+	}
+	if opts.HasKeyFilter() {
+		err = errors.Join(err, mapError(s.hzMap.RemoveAll(ctx, keyPredicate(opts))))
+	}
+	return err
+}
+
+// keyPredicate returns a predicate matching the keys with the prefix or pattern of the
+// given options, evaluated by the Hazelcast members. Tag keys are never matched.
+func keyPredicate(opts *lib_store.InvalidateOptions) predicate.Predicate {
+	tagPattern := lib_store.EscapePattern(fmt.Sprintf(HazelcastTagPattern, "")) + "*"
+
+	predicates := []predicate.Predicate{
+		predicate.Not(predicate.Regex("__key", patternRegex(tagPattern))),
+	}
+	if opts.Prefix != "" {
+		predicates = append(predicates, predicate.Regex("__key", patternRegex(lib_store.EscapePattern(opts.Prefix)+"*")))
+	}
+	if opts.Pattern != "" {
+		predicates = append(predicates, predicate.Regex("__key", patternRegex(opts.Pattern)))
+	}
+
+	return predicate.And(predicates...)
+}
+
+// patternRegex converts a glob-style pattern, as matched by lib_store.MatchPattern,
+// into an equivalent Java regular expression
+func patternRegex(pattern string) string {
+	var builder strings.Builder
+	builder.WriteString("(?s)")
+
+	literal := func(char rune) {
+		if !unicode.IsLetter(char) && !unicode.IsDigit(char) {
+			builder.WriteRune('\\')
+		}
+		builder.WriteRune(char)
+	}
+
+	chars := []rune(pattern)
+	for i := 0; i < len(chars); i++ {
+		switch chars[i] {
+		case '*':
+			builder.WriteString(".*")
+		case '?':
+			builder.WriteRune('.')
+		case '\\':
+			if i+1 < len(chars) {
+				i++
+			}
+			literal(chars[i])
+		case '[':
+			builder.WriteRune('[')
+			i++
+			if i < len(chars) && (chars[i] == '^' || chars[i] == '!') {
+				builder.WriteRune('^')
+				i++
 			}
-			cacheKeys := strings.Split(tagValue.(string), ",")
-			for _, cacheKey := range cacheKeys {
-				s.hzMap.Remove(ctx, cacheKey)
+			for ; i < len(chars) && chars[i] != ']'; i++ {
+				switch {
+				case chars[i] == '\\' && i+1 < len(chars):
+					i++
+					literal(chars[i])
+				case i+2 < len(chars) && chars[i+1] == '-' && chars[i+2] != ']':
+					low, high := chars[i], chars[i+2]
+					if low > high {
+						low, high = high, low
+					}
+					literal(low)
+					builder.WriteRune('-')
+					literal(high)
+					i += 2
+				default:
+					literal(chars[i])
+				}
 			}
-			s.hzMap.Remove(ctx, tagKey)
+			builder.WriteRune(']')
+		default:
+			literal(chars[i])
 		}
 	}
-	return nil
+
+	return builder.String()
 }
 
 // Clear resets all data in the store
 func (s *HazelcastStore) Clear(ctx context.Context) error {
-	return s.hzMap.Clear(ctx)
+	return mapError(s.hzMap.Clear(ctx))
+}
+
+// Capabilities returns the features supported by Hazelcast
+func (s *HazelcastStore) Capabilities() lib_store.Capabilities {
+	return lib_store.Capabilities{
+		TTLPrecision:      time.Millisecond,
+		Tags:              true,
+		ConditionalWrites: true,
+		SlidingExpiration: true,
+	}
 }
 
 // GetType returns the store type
 func (s *HazelcastStore) GetType() string {
 	return HazelcastType
 }
+
+// mapError maps the errors returned by the Hazelcast client onto the store errors
+func mapError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, hzerrors.ErrTimeout), errors.Is(err, hzerrors.ErrOperationTimeout):
+		return lib_store.TimeoutWithCause(err)
+	case errors.Is(err, hzerrors.ErrClientOffline), errors.Is(err, hzerrors.ErrClientNotActive),
+		errors.Is(err, hzerrors.ErrHazelcastInstanceNotActive), errors.Is(err, hzerrors.ErrHazelcastOverLoad),
+		errors.Is(err, hzerrors.ErrTargetDisconnected), errors.Is(err, hzerrors.ErrIO):
+		return lib_store.UnavailableWithCause(err)
+	case errors.Is(err, hzerrors.ErrHazelcastSerialization), errors.Is(err, hzerrors.ErrNotSerializable),
+		errors.Is(err, hzerrors.ErrClassCast):
+		return lib_store.InvalidValueWithCause(err)
+	}
+
+	return lib_store.ClassifyError(err)
+}
@@ -14,6 +14,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	predicate "github.com/hazelcast/hazelcast-go-client/predicate"
 	types "github.com/hazelcast/hazelcast-go-client/types"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -116,6 +117,20 @@ func (mr *MockHazelcastMapInterfaceMockRecorder) Remove(ctx, key any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockHazelcastMapInterface)(nil).Remove), ctx, key)
 }
 
+// RemoveAll mocks base method.
+func (m *MockHazelcastMapInterface) RemoveAll(ctx context.Context, arg1 predicate.Predicate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveAll", ctx, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveAll indicates an expected call of RemoveAll.
+func (mr *MockHazelcastMapInterfaceMockRecorder) RemoveAll(ctx, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveAll", reflect.TypeOf((*MockHazelcastMapInterface)(nil).RemoveAll), ctx, arg1)
+}
+
 // ReplaceIfSame mocks base method.
 func (m *MockHazelcastMapInterface) ReplaceIfSame(ctx context.Context, key, oldValue, newValue any) (bool, error) {
 	m.ctrl.T.Helper()
@@ -145,6 +160,21 @@ func (mr *MockHazelcastMapInterfaceMockRecorder) SetTTL(ctx, key, ttl any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTTL", reflect.TypeOf((*MockHazelcastMapInterface)(nil).SetTTL), ctx, key, ttl)
 }
 
+// SetTTLAffected mocks base method.
+func (m *MockHazelcastMapInterface) SetTTLAffected(ctx context.Context, key any, ttl time.Duration) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTTLAffected", ctx, key, ttl)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetTTLAffected indicates an expected call of SetTTLAffected.
+func (mr *MockHazelcastMapInterfaceMockRecorder) SetTTLAffected(ctx, key, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTTLAffected", reflect.TypeOf((*MockHazelcastMapInterface)(nil).SetTTLAffected), ctx, key, ttl)
+}
+
 // SetWithTTL mocks base method.
 func (m *MockHazelcastMapInterface) SetWithTTL(ctx context.Context, key, value any, ttl time.Duration) error {
 	m.ctrl.T.Helper()
@@ -158,3 +188,17 @@ func (mr *MockHazelcastMapInterfaceMockRecorder) SetWithTTL(ctx, key, value, ttl
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWithTTL", reflect.TypeOf((*MockHazelcastMapInterface)(nil).SetWithTTL), ctx, key, value, ttl)
 }
+
+// SetWithTTLAndMaxIdle mocks base method.
+func (m *MockHazelcastMapInterface) SetWithTTLAndMaxIdle(ctx context.Context, key, value any, ttl, maxIdle time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWithTTLAndMaxIdle", ctx, key, value, ttl, maxIdle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetWithTTLAndMaxIdle indicates an expected call of SetWithTTLAndMaxIdle.
+func (mr *MockHazelcastMapInterfaceMockRecorder) SetWithTTLAndMaxIdle(ctx, key, value, ttl, maxIdle any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWithTTLAndMaxIdle", reflect.TypeOf((*MockHazelcastMapInterface)(nil).SetWithTTLAndMaxIdle), ctx, key, value, ttl, maxIdle)
+}
@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hazelcast/hazelcast-go-client/predicate"
+	"github.com/hazelcast/hazelcast-go-client/types"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
@@ -66,6 +68,49 @@ func TestHazelcastSet(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestHazelcastSetWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().SetWithTTLAndMaxIdle(ctx, "my-key", "my-cache-value", time.Duration(0), time.Minute).Return(nil)
+
+	store := NewHazelcast(hzMap)
+
+	// When
+	err := store.Set(ctx, "my-key", "my-cache-value", lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestHazelcastGetWithTTLWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().GetEntryView(ctx, "my-key").Return(&types.SimpleEntryView{
+		Key:     "my-key",
+		Value:   "my-value",
+		MaxIdle: 60000,
+	}, nil)
+	hzMap.EXPECT().Get(ctx, "my-key").Return("my-value", nil)
+
+	store := NewHazelcast(hzMap)
+
+	// When
+	value, ttl, err := store.GetWithTTL(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, time.Minute, ttl)
+}
+
 func TestHazelcastSetWhenNoOptionsGiven(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -99,7 +144,9 @@ func TestHazelcastSetWithTags(t *testing.T) {
 	hzMap := NewMockHazelcastMapInterface(ctrl)
 	hzMap.EXPECT().SetWithTTL(ctx, cacheKey, cacheValue, time.Duration(0)).Return(nil)
 	hzMap.EXPECT().Get(gomock.Any(), "gocache_tag_tag1").Return(nil, nil)
-	hzMap.EXPECT().PutIfAbsentWithTTL(gomock.Any(), "gocache_tag_tag1", cacheKey, time.Duration(0)).Return(nil, nil)
+	hzMap.EXPECT().PutIfAbsentWithTTL(gomock.Any(), "gocache_tag_tag1", cacheKey, lib_store.DefaultTagsTTL).Return(nil, nil)
+	hzMap.EXPECT().Get(gomock.Any(), "gocache_tag___key__:my-key").Return(nil, nil)
+	hzMap.EXPECT().PutIfAbsentWithTTL(gomock.Any(), "gocache_tag___key__:my-key", "tag1", lib_store.DefaultTagsTTL).Return(nil, nil)
 
 	store := NewHazelcast(hzMap)
 
@@ -123,6 +170,8 @@ func TestHazelcastSetWithTagsAndTagsTTL(t *testing.T) {
 	hzMap.EXPECT().SetWithTTL(ctx, cacheKey, cacheValue, time.Duration(0)).Return(nil)
 	hzMap.EXPECT().Get(gomock.Any(), "gocache_tag_tag1").Return(nil, nil)
 	hzMap.EXPECT().PutIfAbsentWithTTL(gomock.Any(), "gocache_tag_tag1", cacheKey, 10*time.Second).Return(nil, nil)
+	hzMap.EXPECT().Get(gomock.Any(), "gocache_tag___key__:my-key").Return(nil, nil)
+	hzMap.EXPECT().PutIfAbsentWithTTL(gomock.Any(), "gocache_tag___key__:my-key", "tag1", 10*time.Second).Return(nil, nil)
 
 	store := NewHazelcast(hzMap)
 
@@ -145,7 +194,9 @@ func TestHazelcastSetWithTagsWhenAlreadyInserted(t *testing.T) {
 	hzMap := NewMockHazelcastMapInterface(ctrl)
 	hzMap.EXPECT().SetWithTTL(ctx, cacheKey, cacheValue, time.Duration(0)).Return(nil)
 	hzMap.EXPECT().Get(gomock.Any(), "gocache_tag_tag1").Return("my-key,a-second-key", nil)
-	hzMap.EXPECT().SetTTL(gomock.Any(), "gocache_tag_tag1", time.Duration(0)).Return(nil)
+	hzMap.EXPECT().SetTTL(gomock.Any(), "gocache_tag_tag1", lib_store.DefaultTagsTTL).Return(nil)
+	hzMap.EXPECT().Get(gomock.Any(), "gocache_tag___key__:my-key").Return("tag1", nil)
+	hzMap.EXPECT().SetTTL(gomock.Any(), "gocache_tag___key__:my-key", lib_store.DefaultTagsTTL).Return(nil)
 
 	store := NewHazelcast(hzMap)
 
@@ -169,7 +220,9 @@ func TestHazelcastSetWithTagsWhenTagExists(t *testing.T) {
 	hzMap.EXPECT().SetWithTTL(ctx, cacheKey, cacheValue, time.Duration(0)).Return(nil)
 	hzMap.EXPECT().Get(gomock.Any(), "gocache_tag_tag1").Return("a-second-key", nil)
 	hzMap.EXPECT().ReplaceIfSame(gomock.Any(), "gocache_tag_tag1", "a-second-key", "a-second-key,my-key").Return(true, nil)
-	hzMap.EXPECT().SetTTL(gomock.Any(), "gocache_tag_tag1", time.Duration(0)).Return(nil)
+	hzMap.EXPECT().SetTTL(gomock.Any(), "gocache_tag_tag1", lib_store.DefaultTagsTTL).Return(nil)
+	hzMap.EXPECT().Get(gomock.Any(), "gocache_tag___key__:my-key").Return(nil, nil)
+	hzMap.EXPECT().PutIfAbsentWithTTL(gomock.Any(), "gocache_tag___key__:my-key", "tag1", lib_store.DefaultTagsTTL).Return(nil, nil)
 
 	store := NewHazelcast(hzMap)
 
@@ -180,6 +233,119 @@ func TestHazelcastSetWithTagsWhenTagExists(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestHazelcastSetIfNotExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().PutIfAbsentWithTTL(ctx, "my-lock", "owner", time.Minute).Return(nil, nil)
+
+	store := NewHazelcast(hzMap)
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-lock", "owner", lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestHazelcastSetIfNotExistsWhenKeyExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().PutIfAbsentWithTTL(ctx, "my-lock", "owner", time.Duration(0)).Return("other-owner", nil)
+
+	store := NewHazelcast(hzMap)
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-lock", "owner")
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ConditionFailed{})
+}
+
+func TestHazelcastCompareAndSwap(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().Get(ctx, "my-key").Return("my-value", nil)
+	hzMap.EXPECT().ReplaceIfSame(ctx, "my-key", "my-value", "new-value").Return(true, nil)
+	hzMap.EXPECT().SetTTL(ctx, "my-key", time.Minute).Return(nil)
+
+	store := NewHazelcast(hzMap)
+
+	_, version, err := store.GetWithVersion(ctx, "my-key")
+	assert.Nil(t, err)
+
+	// When
+	err = store.CompareAndSwap(ctx, "my-key", "new-value", version, lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestHazelcastCompareAndSwapWhenValueHasChanged(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().ReplaceIfSame(ctx, "my-key", "my-value", "new-value").Return(false, nil)
+
+	store := NewHazelcast(hzMap)
+
+	// When
+	err := store.CompareAndSwap(ctx, "my-key", "new-value", lib_store.NewVersion("my-value"))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ConditionFailed{})
+}
+
+func TestHazelcastTouch(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().SetTTLAffected(ctx, "my-key", time.Minute).Return(true, nil)
+
+	store := NewHazelcast(hzMap)
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestHazelcastTouchWhenKeyDoesNotExist(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().SetTTLAffected(ctx, "my-key", time.Minute).Return(false, nil)
+
+	store := NewHazelcast(hzMap)
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.NotFound{})
+}
+
 func TestHazelcastDelete(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -190,6 +356,40 @@ func TestHazelcastDelete(t *testing.T) {
 
 	hzMap := NewMockHazelcastMapInterface(ctrl)
 	hzMap.EXPECT().Remove(ctx, "my-key").Return(0, nil)
+	hzMap.EXPECT().Get(ctx, "gocache_tag___key__:my-key").Return(nil, nil)
+
+	store := NewHazelcast(hzMap)
+
+	// When
+	err := store.Delete(ctx, cacheKey)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestHazelcastDeleteWhenTagged(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().Remove(ctx, "my-key").Return("my-value", nil)
+	hzMap.EXPECT().Get(ctx, "gocache_tag___key__:my-key").Return("tag1,tag2", nil)
+	hzMap.EXPECT().GetEntryView(ctx, "gocache_tag_tag1").Return(&types.SimpleEntryView{
+		Value: "my-key,a-second-key",
+		TTL:   10000,
+	}, nil)
+	hzMap.EXPECT().ReplaceIfSame(ctx, "gocache_tag_tag1", "my-key,a-second-key", "a-second-key").Return(true, nil)
+	hzMap.EXPECT().SetTTL(ctx, "gocache_tag_tag1", 10*time.Second).Return(nil)
+	hzMap.EXPECT().GetEntryView(ctx, "gocache_tag_tag2").Return(&types.SimpleEntryView{
+		Value: "my-key",
+		TTL:   10000,
+	}, nil)
+	hzMap.EXPECT().Remove(ctx, "gocache_tag_tag2").Return("my-key", nil)
+	hzMap.EXPECT().Remove(ctx, "gocache_tag___key__:my-key").Return("tag1,tag2", nil)
 
 	store := NewHazelcast(hzMap)
 
@@ -208,6 +408,7 @@ func TestHazelcastInvalidate(t *testing.T) {
 
 	hzMap := NewMockHazelcastMapInterface(ctrl)
 	hzMap.EXPECT().Get(ctx, "gocache_tag_tag1").Return(nil, nil)
+	hzMap.EXPECT().Remove(ctx, "gocache_tag_tag1").Return(nil, nil)
 
 	store := NewHazelcast(hzMap)
 
@@ -229,8 +430,11 @@ func TestHazelcastInvalidateWhenCacheKeysExist(t *testing.T) {
 	hzMap := NewMockHazelcastMapInterface(ctrl)
 	hzMap.EXPECT().Get(ctx, "gocache_tag_tag1").Return(cacheKeys, nil)
 	hzMap.EXPECT().Remove(ctx, "my-key0").Return("my-value0", nil)
+	hzMap.EXPECT().Get(ctx, "gocache_tag___key__:my-key0").Return(nil, nil)
 	hzMap.EXPECT().Remove(ctx, "my-key1").Return("my-value1", nil)
+	hzMap.EXPECT().Get(ctx, "gocache_tag___key__:my-key1").Return(nil, nil)
 	hzMap.EXPECT().Remove(ctx, "my-key2").Return("my-value2", nil)
+	hzMap.EXPECT().Get(ctx, "gocache_tag___key__:my-key2").Return(nil, nil)
 	hzMap.EXPECT().Remove(ctx, "gocache_tag_tag1").Return(cacheKeys, nil)
 
 	store := NewHazelcast(hzMap)
@@ -242,6 +446,43 @@ func TestHazelcastInvalidateWhenCacheKeysExist(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestHazelcastInvalidateWithPrefix(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	hzMap := NewMockHazelcastMapInterface(ctrl)
+	hzMap.EXPECT().RemoveAll(ctx, predicate.And(
+		predicate.Not(predicate.Regex("__key", `(?s)gocache\_tag\_.*`)),
+		predicate.Regex("__key", `(?s)user\:.*`),
+	)).Return(nil)
+
+	store := NewHazelcast(hzMap)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePrefix("user:"))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestPatternRegex(t *testing.T) {
+	testCases := map[string]string{
+		"user:*":        `(?s)user\:.*`,
+		"user-?":        `(?s)user\-.`,
+		"user[0-9]":     `(?s)user[0-9]`,
+		"user[^a.]":     `(?s)user[^a\.]`,
+		`user\*`:        `(?s)user\*`,
+		"user.1+2":      `(?s)user\.1\+2`,
+		"id[!z-a]*end$": `(?s)id[^a-z].*end\$`,
+	}
+
+	for pattern, expected := range testCases {
+		assert.Equal(t, expected, patternRegex(pattern), pattern)
+	}
+}
+
 func TestHazelcastClear(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
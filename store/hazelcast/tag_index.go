@@ -0,0 +1,157 @@
+package hazelcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	lib_store "github.com/eko/gocache/lib/v4/store"
+)
+
+// tagIndexUpdateAttempts is the number of times a set update is attempted
+// when it conflicts with a concurrent writer
+const tagIndexUpdateAttempts = 3
+
+// errTagIndexContended is returned when a set has been modified by a concurrent writer
+var errTagIndexContended = errors.New("hazelcast tag key contended")
+
+// tagIndexBackend stores the sets of the tag index as strings encoded using lib_store.EncodeTagMembers,
+// updated using ReplaceIfSame so that concurrent writers do not lose members
+type tagIndexBackend struct {
+	hzMap HazelcastMapInterface
+}
+
+// AddMembers adds the given members to the set and sets its time-to-live
+func (b *tagIndexBackend) AddMembers(ctx context.Context, setKey string, members []string, ttl time.Duration) error {
+	return b.update(func() error {
+		value, err := b.hzMap.Get(ctx, setKey)
+		if err != nil {
+			return mapError(err)
+		}
+
+		if value == nil {
+			// first writer: PutIfAbsent returns nil when our insert succeeded,
+			// otherwise the value inserted by somebody else is updated
+			prev, err := b.hzMap.PutIfAbsentWithTTL(ctx, setKey, encodeMembers(members), ttl)
+			if err != nil || prev == nil {
+				return mapError(err)
+			}
+			value = prev
+		}
+
+		current, err := setValue(setKey, value)
+		if err != nil {
+			return err
+		}
+		updated := decodeMembers(current)
+		for _, member := range members {
+			if !slices.Contains(updated, member) {
+				updated = append(updated, member)
+			}
+		}
+
+		if err := b.replace(ctx, setKey, current, encodeMembers(updated)); err != nil {
+			return err
+		}
+
+		return b.hzMap.SetTTL(ctx, setKey, ttl)
+	})
+}
+
+// Members returns the members of the set, or none if the set does not exist
+func (b *tagIndexBackend) Members(ctx context.Context, setKey string) ([]string, error) {
+	value, err := b.hzMap.Get(ctx, setKey)
+	if err != nil || value == nil {
+		return nil, mapError(err)
+	}
+
+	current, err := setValue(setKey, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeMembers(current), nil
+}
+
+// RemoveMembers removes the given members from the set, keeping its time-to-live,
+// and deletes the set once empty
+func (b *tagIndexBackend) RemoveMembers(ctx context.Context, setKey string, members []string) error {
+	return b.update(func() error {
+		entryView, err := b.hzMap.GetEntryView(ctx, setKey)
+		if err != nil || entryView == nil {
+			return mapError(err)
+		}
+
+		current, err := setValue(setKey, entryView.Value)
+		if err != nil {
+			return err
+		}
+		remaining := slices.DeleteFunc(decodeMembers(current), func(member string) bool {
+			return slices.Contains(members, member)
+		})
+		if len(remaining) == 0 {
+			return b.DeleteSet(ctx, setKey)
+		}
+
+		if err := b.replace(ctx, setKey, current, encodeMembers(remaining)); err != nil {
+			return err
+		}
+
+		return b.hzMap.SetTTL(ctx, setKey, time.Duration(entryView.TTL)*time.Millisecond)
+	})
+}
+
+// DeleteSet removes the whole set
+func (b *tagIndexBackend) DeleteSet(ctx context.Context, setKey string) error {
+	_, err := b.hzMap.Remove(ctx, setKey)
+	return mapError(err)
+}
+
+func (b *tagIndexBackend) replace(ctx context.Context, setKey string, oldValue string, newValue string) error {
+	if oldValue == newValue {
+		return nil
+	}
+
+	ok, err := b.hzMap.ReplaceIfSame(ctx, setKey, oldValue, newValue)
+	if err != nil {
+		return mapError(err)
+	}
+	if !ok {
+		return errTagIndexContended
+	}
+
+	return nil
+}
+
+// update runs the given function, retrying when the set has been modified concurrently
+func (b *tagIndexBackend) update(update func() error) error {
+	var err error
+	for i := 0; i < tagIndexUpdateAttempts; i++ {
+		if err = update(); !errors.Is(err, errTagIndexContended) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// setValue returns the encoded members of a set read from Hazelcast,
+// or an ErrInvalidValue error when another kind of value is stored under the set key
+func setValue(setKey string, value any) (string, error) {
+	current, ok := value.(string)
+	if !ok {
+		return "", lib_store.InvalidValueWithCause(fmt.Errorf("tag key '%s' holds a %T instead of a string", setKey, value))
+	}
+
+	return current, nil
+}
+
+func encodeMembers(members []string) string {
+	return string(lib_store.EncodeTagMembers(members))
+}
+
+func decodeMembers(value string) []string {
+	return lib_store.DecodeTagMembers([]byte(value))
+}
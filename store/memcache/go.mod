@@ -6,7 +6,6 @@ require (
 	github.com/bradfitz/gomemcache v0.0.0-20250403215159-8d39553ac7cf
 	github.com/eko/gocache/lib/v4 v4.1.6
 	github.com/stretchr/testify v1.11.1
-	golang.org/x/sync v0.19.0
 )
 
 require (
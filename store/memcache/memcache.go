@@ -3,13 +3,11 @@ package memcache
 import (
 	"context"
 	"errors"
-	"fmt"
-	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	lib_store "github.com/eko/gocache/lib/v4/store"
-	"golang.org/x/sync/errgroup"
 
 	"github.com/bradfitz/gomemcache/memcache"
 )
@@ -17,11 +15,15 @@ import (
 // MemcacheClientInterface represents a bradfitz/gomemcache client
 type MemcacheClientInterface interface {
 	Get(key string) (item *memcache.Item, err error)
+	GetMulti(keys []string) (items map[string]*memcache.Item, err error)
 	Set(item *memcache.Item) error
 	Delete(item string) error
 	FlushAll() error
 	CompareAndSwap(item *memcache.Item) error
 	Add(item *memcache.Item) error
+	Increment(key string, delta uint64) (newValue uint64, err error)
+	Decrement(key string, delta uint64) (newValue uint64, err error)
+	Touch(key string, seconds int32) (err error)
 }
 
 const (
@@ -30,13 +32,14 @@ const (
 	// MemcacheTagPattern represents the tag pattern to be used as a key in specified storage
 	MemcacheTagPattern = "gocache_tag_%s"
 
-	TagKeyExpiry = 720 * time.Hour
+	TagKeyExpiry = lib_store.DefaultTagsTTL
 )
 
 // MemcacheStore is a store for Memcache
 type MemcacheStore struct {
 	client  MemcacheClientInterface
 	options *lib_store.Options
+	tags    *lib_store.TagIndex
 }
 
 // NewMemcache creates a new store to Memcache instance(s)
@@ -44,18 +47,30 @@ func NewMemcache(client MemcacheClientInterface, options ...lib_store.Option) *M
 	return &MemcacheStore{
 		client:  client,
 		options: lib_store.ApplyOptions(options...),
+		tags:    lib_store.NewTagIndex(&tagIndexBackend{client: client}, MemcacheTagPattern),
 	}
 }
 
-// Get returns data stored from a given key
+// Get returns data stored from a given key. When the store supports sliding expirations,
+// the expiration of the key is renewed if it has been set with one.
 func (s *MemcacheStore) Get(_ context.Context, key any) (any, error) {
-	item, err := s.client.Get(key.(string))
+	memcacheKey, err := lib_store.KeyAs[string](key)
 	if err != nil {
-		if errors.Is(err, memcache.ErrCacheMiss) {
-			return nil, lib_store.NotFoundWithCause(err)
-		}
 		return nil, err
 	}
+
+	if s.options.SlidingExpirationSupport {
+		item, _, err := s.getWithSlidingExpiration(memcacheKey)
+		if err != nil {
+			return nil, err
+		}
+		return item.Value, nil
+	}
+
+	item, err := s.client.Get(memcacheKey)
+	if err != nil {
+		return nil, mapError(err)
+	}
 	if item == nil {
 		return nil, lib_store.NotFoundWithCause(errors.New("unable to retrieve data from memcache"))
 	}
@@ -63,15 +78,29 @@ func (s *MemcacheStore) Get(_ context.Context, key any) (any, error) {
 	return item.Value, err
 }
 
-// GetWithTTL returns data stored from a given key and its corresponding TTL
+// GetWithTTL returns data stored from a given key and its corresponding TTL.
+// The sliding expiration of the key is returned as TTL when it has one.
 func (s *MemcacheStore) GetWithTTL(_ context.Context, key any) (any, time.Duration, error) {
-	item, err := s.client.Get(key.(string))
+	memcacheKey, err := lib_store.KeyAs[string](key)
 	if err != nil {
-		if errors.Is(err, memcache.ErrCacheMiss) {
-			return nil, 0, lib_store.NotFoundWithCause(err)
-		}
 		return nil, 0, err
 	}
+
+	if s.options.SlidingExpirationSupport {
+		item, sliding, err := s.getWithSlidingExpiration(memcacheKey)
+		if err != nil {
+			return nil, 0, err
+		}
+		if sliding > 0 {
+			return item.Value, sliding, nil
+		}
+		return item.Value, time.Duration(item.Expiration) * time.Second, nil
+	}
+
+	item, err := s.client.Get(memcacheKey)
+	if err != nil {
+		return nil, 0, mapError(err)
+	}
 	if item == nil {
 		return nil, 0, lib_store.NotFoundWithCause(errors.New("unable to retrieve data from memcache"))
 	}
@@ -79,131 +108,459 @@ func (s *MemcacheStore) GetWithTTL(_ context.Context, key any) (any, time.Durati
 	return item.Value, time.Duration(item.Expiration) * time.Second, err
 }
 
+// getWithSlidingExpiration reads the given key along with its sliding expiration using a
+// single GetMulti call, renewing it when the key has one
+func (s *MemcacheStore) getWithSlidingExpiration(key string) (*memcache.Item, time.Duration, error) {
+	items, err := s.client.GetMulti([]string{key, lib_store.SlidingExpirationKey(key)})
+	if err != nil {
+		return nil, 0, mapError(err)
+	}
+
+	item := items[key]
+	if item == nil {
+		return nil, 0, lib_store.NotFoundWithCause(errors.New("unable to retrieve data from memcache"))
+	}
+
+	sliding, err := s.renew(key, items)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return item, sliding, nil
+}
+
+// renew touches the given key along with the one holding its sliding expiration, when it
+// is part of the given items, and returns the sliding expiration. Memcache is not able to
+// touch both keys at once: a concurrent write may happen in between.
+func (s *MemcacheStore) renew(key string, items map[string]*memcache.Item) (time.Duration, error) {
+	slidingKey := lib_store.SlidingExpirationKey(key)
+
+	slidingItem := items[slidingKey]
+	if slidingItem == nil {
+		return 0, nil
+	}
+
+	sliding, err := lib_store.ParseSlidingExpiration(slidingItem.Value)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, k := range []string{key, slidingKey} {
+		if err := s.client.Touch(k, int32(sliding.Seconds())); err != nil {
+			return 0, mapError(err)
+		}
+	}
+
+	return sliding, nil
+}
+
 // Set defines data in Memcache for given key identifier
 func (s *MemcacheStore) Set(ctx context.Context, key any, value any, options ...lib_store.Option) error {
+	memcacheKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
 	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return err
+	}
 
 	item := &memcache.Item{
-		Key:        key.(string),
+		Key:        memcacheKey,
 		Value:      value.([]byte),
-		Expiration: int32(opts.Expiration.Seconds()),
+		Expiration: expiration(opts),
 	}
 
-	err := s.client.Set(item)
+	err = s.client.Set(item)
 	if err != nil {
-		return err
+		return mapError(err)
 	}
 
-	if tags := opts.Tags; len(tags) > 0 {
-		ttl := opts.TagsTTL
-		if ttl == 0 {
-			ttl = TagKeyExpiry
+	if s.options.SlidingExpirationSupport {
+		if err := s.setSlidingExpiration(memcacheKey, opts); err != nil {
+			return err
 		}
-		s.setTags(ctx, key, tags, ttl)
+	}
+
+	return s.tags.Add(ctx, memcacheKey, opts.Tags, opts.TagsTTL)
+}
+
+// setSlidingExpiration records the sliding expiration of the given key,
+// or removes the one it had when it is set without sliding expiration
+func (s *MemcacheStore) setSlidingExpiration(key string, opts *lib_store.Options) error {
+	slidingKey := lib_store.SlidingExpirationKey(key)
+	if opts.SlidingExpiration > 0 {
+		return mapError(s.client.Set(&memcache.Item{
+			Key:        slidingKey,
+			Value:      []byte(lib_store.SlidingExpirationValue(opts.SlidingExpiration)),
+			Expiration: int32(opts.SlidingExpiration.Seconds()),
+		}))
+	}
+
+	return s.deleteKey(slidingKey)
+}
+
+// deleteKey removes the given key, which may not exist
+func (s *MemcacheStore) deleteKey(key string) error {
+	err := s.client.Delete(key)
+	if err != nil && !errors.Is(err, memcache.ErrCacheMiss) {
+		return mapError(err)
 	}
 
 	return nil
 }
 
-func (s *MemcacheStore) setTags(ctx context.Context, key any, tags []string, ttl time.Duration) {
-	group, ctx := errgroup.WithContext(ctx)
-	for _, tag := range tags {
-		currentTag := tag
-		group.Go(func() error {
-			tagKey := fmt.Sprintf(MemcacheTagPattern, currentTag)
+// GetMany returns data stored from the given keys using a single GetMulti call.
+// When the store supports sliding expirations, they are read along with the keys
+// and renewed as Get does. Keys that are not found are omitted from the returned map.
+func (s *MemcacheStore) GetMany(_ context.Context, keys []any) (map[any]any, error) {
+	memcacheKeys, err := lib_store.KeysAs[string](keys)
+	if err != nil {
+		return nil, err
+	}
 
-			var err error
-			for i := 0; i < 3; i++ {
-				if err = s.addKeyToTagValue(tagKey, key, ttl); err == nil {
-					return nil
-				}
-				// loop to retry any failure (including race conditions)
-			}
+	items, err := s.client.GetMulti(s.withSlidingExpirationKeys(memcacheKeys))
+	if err != nil {
+		return nil, mapError(err)
+	}
 
-			return err
-		})
+	values := make(map[any]any, len(items))
+	for i, key := range keys {
+		item, ok := items[memcacheKeys[i]]
+		if !ok || item == nil {
+			continue
+		}
+		if s.options.SlidingExpirationSupport {
+			if _, err := s.renew(memcacheKeys[i], items); err != nil {
+				return nil, err
+			}
+		}
+		values[key] = item.Value
 	}
 
-	group.Wait()
+	return values, nil
 }
 
-func (s *MemcacheStore) addKeyToTagValue(tagKey string, key any, ttl time.Duration) error {
-	var (
-		cacheKeys = []string{}
-		result    *memcache.Item
-		err       error
-	)
+// withSlidingExpirationKeys returns the given keys along with their sliding expiration keys
+// when the store supports sliding expirations
+func (s *MemcacheStore) withSlidingExpirationKeys(keys []string) []string {
+	if !s.options.SlidingExpirationSupport {
+		return keys
+	}
 
-	result, err = s.client.Get(tagKey)
-	if err == nil {
-		cacheKeys = strings.Split(string(result.Value), ",")
-	} else if !errors.Is(err, memcache.ErrCacheMiss) {
+	withSliding := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		withSliding = append(withSliding, key, lib_store.SlidingExpirationKey(key))
+	}
+
+	return withSliding
+}
+
+// SetMany defines data in Memcache for the given items.
+// Memcache has no multi-set command so items are set one by one.
+func (s *MemcacheStore) SetMany(ctx context.Context, items map[any]any, options ...lib_store.Option) error {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
 		return err
 	}
 
-	if !slices.Contains(cacheKeys, key.(string)) {
-		cacheKeys = append(cacheKeys, key.(string))
+	errs := []error{}
+	for key, value := range items {
+		if err := s.Set(ctx, key, value, options...); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	newVal := []byte(strings.Join(cacheKeys, ","))
+	return errors.Join(errs...)
+}
+
+// DeleteMany removes data from Memcache for the given keys.
+// Memcache has no multi-delete command so keys are deleted one by one.
+func (s *MemcacheStore) DeleteMany(ctx context.Context, keys []any) error {
+	errs := []error{}
+	for _, key := range keys {
+		if err := s.Delete(ctx, key); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Increment atomically adds the given delta to the counter stored for the given key.
+// Memcache counters are unsigned so a negative delta decrements the counter.
+func (s *MemcacheStore) Increment(ctx context.Context, key any, delta int64, options ...lib_store.Option) (int64, error) {
+	memcacheKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return 0, err
+	}
+
+	if delta < 0 {
+		return s.Decrement(ctx, key, -delta, options...)
+	}
+
+	return s.updateCounter(memcacheKey, delta, delta, s.client.Increment, options...)
+}
+
+// Decrement atomically subtracts the given delta from the counter stored for the given key.
+// Memcache counters are unsigned so they cannot be decremented below 0.
+func (s *MemcacheStore) Decrement(ctx context.Context, key any, delta int64, options ...lib_store.Option) (int64, error) {
+	memcacheKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return 0, err
+	}
+
+	if delta < 0 {
+		return s.Increment(ctx, key, -delta, options...)
+	}
+
+	return s.updateCounter(memcacheKey, delta, 0, s.client.Decrement, options...)
+}
+
+func (s *MemcacheStore) updateCounter(key string, delta int64, initial int64, update func(string, uint64) (uint64, error), options ...lib_store.Option) (int64, error) {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return 0, err
+	}
+
+	var err error
+	for i := 0; i < 3; i++ {
+		var value uint64
+		if value, err = update(key, uint64(delta)); err == nil {
+			return int64(value), nil
+		}
+		if !errors.Is(err, memcache.ErrCacheMiss) {
+			return 0, mapError(err)
+		}
 
-	if result == nil {
-		// if key didnt exist, use Add to create only if still not there
-		return s.client.Add(&memcache.Item{
-			Key:        tagKey,
-			Value:      newVal,
-			Expiration: int32(ttl.Seconds()),
+		// if the counter does not exist, use Add to create it only if still not there
+		err = s.client.Add(&memcache.Item{
+			Key:        key,
+			Value:      []byte(strconv.FormatInt(initial, 10)),
+			Expiration: expiration(opts),
 		})
+		if err == nil {
+			return initial, nil
+		}
+		if !errors.Is(err, memcache.ErrNotStored) {
+			return 0, mapError(err)
+		}
+		// loop to retry as the counter has been created in the meantime
 	}
 
-	// update existing value
-	// using CompareAndSwap to ensure not to run over writes between Get and here
-	result.Value = newVal
-	result.Expiration = int32(ttl.Seconds())
-	return s.client.CompareAndSwap(result)
+	return 0, mapError(err)
 }
 
-// Delete removes data from Memcache for given key identifier
-func (s *MemcacheStore) Delete(_ context.Context, key any) error {
-	err := s.client.Delete(key.(string))
+// SetIfNotExists defines data in Memcache for given key identifier only if
+// it does not exist yet, using the add command
+func (s *MemcacheStore) SetIfNotExists(ctx context.Context, key any, value any, options ...lib_store.Option) error {
+	memcacheKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return err
+	}
+
+	err = s.client.Add(&memcache.Item{
+		Key:        memcacheKey,
+		Value:      value.([]byte),
+		Expiration: expiration(opts),
+	})
+	if errors.Is(err, memcache.ErrNotStored) {
+		return lib_store.ConditionFailedWithCause(err)
+	}
+	if err != nil {
+		return mapError(err)
+	}
+
+	return s.tags.Add(ctx, memcacheKey, opts.Tags, opts.TagsTTL)
+}
+
+// GetWithVersion returns data stored from a given key along with a version
+// holding the CAS identifier of the item
+func (s *MemcacheStore) GetWithVersion(_ context.Context, key any) (any, lib_store.Version, error) {
+	memcacheKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return nil, lib_store.Version{}, err
+	}
+
+	item, err := s.client.Get(memcacheKey)
 	if errors.Is(err, memcache.ErrCacheMiss) {
-		return nil
+		return nil, lib_store.Version{}, lib_store.NotFoundWithCause(err)
+	}
+	if err != nil {
+		return nil, lib_store.Version{}, mapError(err)
+	}
+	if item == nil {
+		return nil, lib_store.Version{}, lib_store.NotFoundWithCause(errors.New("unable to retrieve data from memcache"))
 	}
-	return err
+
+	return item.Value, lib_store.NewVersion(item), nil
 }
 
-// Invalidate invalidates some cache data in Memcache for given options
-func (s *MemcacheStore) Invalidate(ctx context.Context, options ...lib_store.InvalidateOption) error {
-	opts := lib_store.ApplyInvalidateOptions(options...)
+// CompareAndSwap defines data in Memcache for given key identifier only if it
+// has not been modified since the version has been retrieved, using the cas command
+func (s *MemcacheStore) CompareAndSwap(ctx context.Context, key any, value any, version lib_store.Version, options ...lib_store.Option) error {
+	memcacheKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
 
-	if tags := opts.Tags; len(tags) > 0 {
-		for _, tag := range tags {
-			tagKey := fmt.Sprintf(MemcacheTagPattern, tag)
-			result, err := s.Get(ctx, tagKey)
-			if err != nil {
-				return nil
-			}
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return err
+	}
 
-			cacheKeys := []string{}
-			if bytes, ok := result.([]byte); ok {
-				cacheKeys = strings.Split(string(bytes), ",")
-			}
+	versionItem, ok := version.Value().(*memcache.Item)
+	if !ok || versionItem.Key != memcacheKey {
+		return lib_store.ConditionFailedWithCause(nil)
+	}
 
-			for _, cacheKey := range cacheKeys {
-				s.Delete(ctx, cacheKey)
-			}
+	// copy the item to keep its CAS identifier without altering the version
+	item := *versionItem
+	item.Value = value.([]byte)
+	item.Expiration = expiration(opts)
+
+	err = s.client.CompareAndSwap(&item)
+	if errors.Is(err, memcache.ErrCASConflict) || errors.Is(err, memcache.ErrNotStored) || errors.Is(err, memcache.ErrCacheMiss) {
+		return lib_store.ConditionFailedWithCause(err)
+	}
+	if err != nil {
+		return mapError(err)
+	}
+
+	return s.tags.Add(ctx, memcacheKey, opts.Tags, opts.TagsTTL)
+}
+
+// Touch changes the expiration of the given key using the touch command
+func (s *MemcacheStore) Touch(_ context.Context, key any, ttl time.Duration) error {
+	memcacheKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Capabilities().CheckOptions(&lib_store.Options{Strict: s.options.Strict, Expiration: ttl}); err != nil {
+		return err
+	}
+
+	return mapError(s.client.Touch(memcacheKey, int32(ttl.Seconds())))
+}
+
+// Delete removes data from Memcache for given key identifier, along with its sliding
+// expiration when the store supports them
+func (s *MemcacheStore) Delete(ctx context.Context, key any) error {
+	memcacheKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
+	if err := s.deleteKey(memcacheKey); err != nil {
+		return err
+	}
+	if s.options.SlidingExpirationSupport {
+		if err := s.deleteKey(lib_store.SlidingExpirationKey(memcacheKey)); err != nil {
+			return err
 		}
 	}
 
-	return nil
+	return s.tags.Remove(ctx, memcacheKey)
+}
+
+// Invalidate invalidates some cache data in Memcache for given options
+func (s *MemcacheStore) Invalidate(ctx context.Context, options ...lib_store.InvalidateOption) error {
+	opts := lib_store.ApplyInvalidateOptions(options...)
+
+	// Keys cannot be invalidated by prefix or pattern as Memcache cannot list its keys
+	if opts.HasKeyFilter() {
+		return lib_store.ErrUnsupported
+	}
+
+	return s.tags.Invalidate(ctx, opts.Tags, func(ctx context.Context, key string) error {
+		return s.Delete(ctx, key)
+	})
 }
 
 // Clear resets all data in the store
 func (s *MemcacheStore) Clear(_ context.Context) error {
-	return s.client.FlushAll()
+	return mapError(s.client.FlushAll())
+}
+
+// SlidingExpiration returns the sliding expiration given to the key, if any.
+// No sliding expiration is returned when the store does not support them.
+func (s *MemcacheStore) SlidingExpiration(_ context.Context, key any) (time.Duration, error) {
+	memcacheKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return 0, err
+	}
+
+	if !s.options.SlidingExpirationSupport {
+		return 0, nil
+	}
+
+	item, err := s.client.Get(lib_store.SlidingExpirationKey(memcacheKey))
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, mapError(err)
+	}
+
+	return lib_store.ParseSlidingExpiration(item.Value)
+}
+
+// Capabilities returns the features supported by Memcache. The maximum value size
+// is the default item size limit of Memcache servers.
+func (s *MemcacheStore) Capabilities() lib_store.Capabilities {
+	return lib_store.Capabilities{
+		TTLPrecision:      time.Second,
+		Tags:              true,
+		Batch:             true,
+		AtomicCounters:    true,
+		ConditionalWrites: true,
+		SlidingExpiration: s.options.SlidingExpirationSupport,
+		MaxValueSize:      1024 * 1024,
+	}
 }
 
 // GetType returns the store type
 func (s *MemcacheStore) GetType() string {
 	return MemcacheType
 }
+
+// expiration returns the expiration of the item to write. A deadline is given to Memcache
+// as a unix timestamp so that the expiration is resolved when the value is written.
+// Memcache keeps expirations in whole seconds, so the jitter never goes below a second.
+func expiration(opts *lib_store.Options) int32 {
+	if deadline := opts.EffectiveExpireAt(); !deadline.IsZero() {
+		return int32(deadline.Unix())
+	}
+
+	return int32(opts.EffectiveExpirationWithPrecision(time.Second).Seconds())
+}
+
+// mapError maps the errors returned by the memcache client onto the store errors
+func mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var connectTimeoutErr *memcache.ConnectTimeoutError
+	switch {
+	case errors.Is(err, memcache.ErrCacheMiss):
+		return lib_store.NotFoundWithCause(err)
+	case errors.As(err, &connectTimeoutErr):
+		return lib_store.TimeoutWithCause(err)
+	case errors.Is(err, memcache.ErrNoServers), errors.Is(err, memcache.ErrServerError):
+		return lib_store.UnavailableWithCause(err)
+	case errors.Is(err, memcache.ErrMalformedKey):
+		return lib_store.InvalidKeyTypeWithCause(err)
+	case strings.HasPrefix(err.Error(), "memcache: client error"):
+		return lib_store.InvalidValueWithCause(err)
+	}
+
+	return lib_store.ClassifyError(err)
+}
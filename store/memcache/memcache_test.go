@@ -47,6 +47,73 @@ func TestMemcacheGet(t *testing.T) {
 	assert.Equal(t, cacheValue, value)
 }
 
+func TestMemcacheGetWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := []byte("my-cache-value")
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().GetMulti([]string{cacheKey, "gocache_sliding_my-key"}).Return(map[string]*memcache.Item{
+		cacheKey:                 {Value: cacheValue},
+		"gocache_sliding_my-key": {Value: []byte("60000000000")},
+	}, nil)
+	client.EXPECT().Touch(cacheKey, int32(60)).Return(nil)
+	client.EXPECT().Touch("gocache_sliding_my-key", int32(60)).Return(nil)
+
+	store := NewMemcache(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	value, ttl, err := store.GetWithTTL(ctx, cacheKey)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, cacheValue, value)
+	assert.Equal(t, time.Minute, ttl)
+}
+
+func TestMemcacheGetManyWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().GetMulti([]string{"key-1", "gocache_sliding_key-1", "key-2", "gocache_sliding_key-2"}).Return(map[string]*memcache.Item{
+		"key-1":                 {Value: []byte("value-1")},
+		"gocache_sliding_key-1": {Value: []byte("60000000000")},
+		"key-2":                 {Value: []byte("value-2")},
+	}, nil)
+	client.EXPECT().Touch("key-1", int32(60)).Return(nil)
+	client.EXPECT().Touch("gocache_sliding_key-1", int32(60)).Return(nil)
+
+	store := NewMemcache(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	values, err := store.GetMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": []byte("value-1"), "key-2": []byte("value-2")}, values)
+}
+
+func TestMemcacheDeleteWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().Delete("my-key").Return(nil)
+	client.EXPECT().Delete("gocache_sliding_my-key").Return(memcache.ErrCacheMiss)
+	client.EXPECT().Get("gocache_tag___key__:my-key").Return(nil, memcache.ErrCacheMiss)
+
+	store := NewMemcache(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Delete(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+}
+
 func TestMemcacheGetWithMissingItem(t *testing.T) {
 	// Given
 	ctx := context.Background()
@@ -176,6 +243,80 @@ func TestMemcacheSet(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestMemcacheSetWithExpirationJitterKeepsWholeSecond(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := []byte("my-cache-value")
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().Set(&memcache.Item{
+		Key:        cacheKey,
+		Value:      cacheValue,
+		Expiration: int32(1),
+	}).Return(nil)
+
+	store := NewMemcache(client, lib_store.WithExpirationJitter(1))
+
+	// When
+	err := store.Set(ctx, cacheKey, cacheValue, lib_store.WithExpiration(time.Second))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestMemcacheSetWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := []byte("my-cache-value")
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().Set(&memcache.Item{
+		Key:        cacheKey,
+		Value:      cacheValue,
+		Expiration: int32(60),
+	}).Return(nil)
+	client.EXPECT().Set(&memcache.Item{
+		Key:        "gocache_sliding_my-key",
+		Value:      []byte("60000000000"),
+		Expiration: int32(60),
+	}).Return(nil)
+
+	store := NewMemcache(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Set(ctx, cacheKey, cacheValue, lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestMemcacheSetWithExpireAt(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := []byte("my-cache-value")
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().Set(&memcache.Item{
+		Key:        cacheKey,
+		Value:      cacheValue,
+		Expiration: int32(1767225600),
+	}).Return(nil)
+
+	store := NewMemcache(client, lib_store.WithExpiration(time.Minute))
+
+	// When
+	err := store.Set(ctx, cacheKey, cacheValue, lib_store.WithExpireAt(time.Unix(1767225600, 0)))
+
+	// Then
+	assert.Nil(t, err)
+}
+
 func TestMemcacheSetWhenNoOptionsGiven(t *testing.T) {
 	// Given
 	ctx := context.Background()
@@ -241,6 +382,12 @@ func TestMemcacheSetWithTags(t *testing.T) {
 		Value:      []byte(cacheKey),
 		Expiration: int32(TagKeyExpiry.Seconds()),
 	}).Return(nil)
+	client.EXPECT().Get("gocache_tag___key__:my-key").Return(nil, memcache.ErrCacheMiss)
+	client.EXPECT().Add(&memcache.Item{
+		Key:        "gocache_tag___key__:my-key",
+		Value:      []byte("tag1"),
+		Expiration: int32(TagKeyExpiry.Seconds()),
+	}).Return(nil)
 
 	store := NewMemcache(client)
 
@@ -268,6 +415,12 @@ func TestMemcacheSetWithTagsAndTagsTTL(t *testing.T) {
 		Value:      []byte(cacheKey),
 		Expiration: int32((5 * time.Minute).Seconds()),
 	}).Return(nil)
+	client.EXPECT().Get("gocache_tag___key__:my-key").Return(nil, memcache.ErrCacheMiss)
+	client.EXPECT().Add(&memcache.Item{
+		Key:        "gocache_tag___key__:my-key",
+		Value:      []byte("tag1"),
+		Expiration: int32((5 * time.Minute).Seconds()),
+	}).Return(nil)
 
 	store := NewMemcache(client)
 
@@ -301,6 +454,12 @@ func TestMemcacheSetWithTagsWhenTagExists(t *testing.T) {
 		Value:      []byte("a-second-key,my-key"),
 		Expiration: int32((5 * time.Minute).Seconds()),
 	}).Return(nil)
+	client.EXPECT().Get("gocache_tag___key__:my-key").Return(nil, memcache.ErrCacheMiss)
+	client.EXPECT().Add(&memcache.Item{
+		Key:        "gocache_tag___key__:my-key",
+		Value:      []byte("tag1"),
+		Expiration: int32((5 * time.Minute).Seconds()),
+	}).Return(nil)
 
 	store := NewMemcache(client)
 
@@ -330,6 +489,13 @@ func TestMemcacheSetWithTagsWhenAlreadyInserted(t *testing.T) {
 		Value:      []byte("my-key,a-second-key"),
 		Expiration: int32(TagKeyExpiry.Seconds()),
 	}).Return(nil)
+	client.EXPECT().Get("gocache_tag___key__:my-key").Return(&memcache.Item{
+		Value: []byte("tag1"),
+	}, nil)
+	client.EXPECT().CompareAndSwap(&memcache.Item{
+		Value:      []byte("tag1"),
+		Expiration: int32(TagKeyExpiry.Seconds()),
+	}).Return(nil)
 
 	store := NewMemcache(client)
 
@@ -340,6 +506,246 @@ func TestMemcacheSetWithTagsWhenAlreadyInserted(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestMemcacheGetMany(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().GetMulti([]string{"key-1", "key-2"}).Return(map[string]*memcache.Item{
+		"key-1": {Key: "key-1", Value: []byte("value-1")},
+	}, nil)
+
+	store := NewMemcache(client)
+
+	// When
+	values, err := store.GetMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": []byte("value-1")}, values)
+}
+
+func TestMemcacheGetManyWhenError(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	expectedErr := errors.New("an unexpected error occurred")
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().GetMulti([]string{"key-1", "key-2"}).Return(nil, expectedErr)
+
+	store := NewMemcache(client)
+
+	// When
+	values, err := store.GetMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Equal(t, expectedErr, err)
+	assert.Nil(t, values)
+}
+
+func TestMemcacheIncrement(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().Increment("my-counter", uint64(2)).Return(uint64(5), nil)
+
+	store := NewMemcache(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), value)
+}
+
+func TestMemcacheIncrementWhenCounterDoesNotExist(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().Increment("my-counter", uint64(2)).Return(uint64(0), memcache.ErrCacheMiss)
+	client.EXPECT().Add(&memcache.Item{
+		Key:        "my-counter",
+		Value:      []byte("2"),
+		Expiration: int32(60),
+	}).Return(nil)
+
+	store := NewMemcache(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2, lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), value)
+}
+
+func TestMemcacheIncrementWhenCounterCreatedConcurrently(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().Increment("my-counter", uint64(1)).Return(uint64(0), memcache.ErrCacheMiss).Once()
+	client.EXPECT().Add(&memcache.Item{
+		Key:   "my-counter",
+		Value: []byte("1"),
+	}).Return(memcache.ErrNotStored)
+	client.EXPECT().Increment("my-counter", uint64(1)).Return(uint64(2), nil).Once()
+
+	store := NewMemcache(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 1)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(2), value)
+}
+
+func TestMemcacheDecrement(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().Decrement("my-counter", uint64(3)).Return(uint64(7), nil)
+
+	store := NewMemcache(client)
+
+	// When
+	value, err := store.Decrement(ctx, "my-counter", 3)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(7), value)
+}
+
+func TestMemcacheIncrementWithNegativeDelta(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().Decrement("my-counter", uint64(3)).Return(uint64(0), nil)
+
+	store := NewMemcache(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", -3)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), value)
+}
+
+func TestMemcacheSetIfNotExistsWhenKeyExists(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().Add(&memcache.Item{
+		Key:        "my-lock",
+		Value:      []byte("owner"),
+		Expiration: int32(60),
+	}).Return(memcache.ErrNotStored)
+
+	store := NewMemcache(client)
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-lock", []byte("owner"), lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ConditionFailed{})
+}
+
+func TestMemcacheCompareAndSwap(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	item := &memcache.Item{
+		Key:   "my-key",
+		Value: []byte("my-value"),
+	}
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().Get("my-key").Return(item, nil)
+	client.EXPECT().CompareAndSwap(&memcache.Item{
+		Key:        "my-key",
+		Value:      []byte("new-value"),
+		Expiration: int32(5),
+	}).Return(nil)
+
+	store := NewMemcache(client, lib_store.WithExpiration(5*time.Second))
+
+	value, version, err := store.GetWithVersion(ctx, "my-key")
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("my-value"), value)
+
+	// When
+	err = store.CompareAndSwap(ctx, "my-key", []byte("new-value"), version)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("my-value"), item.Value)
+}
+
+func TestMemcacheCompareAndSwapWhenItemHasChanged(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	item := &memcache.Item{
+		Key:   "my-key",
+		Value: []byte("my-value"),
+	}
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().CompareAndSwap(&memcache.Item{
+		Key:   "my-key",
+		Value: []byte("new-value"),
+	}).Return(memcache.ErrCASConflict)
+
+	store := NewMemcache(client)
+
+	// When
+	err := store.CompareAndSwap(ctx, "my-key", []byte("new-value"), lib_store.NewVersion(item))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ConditionFailed{})
+}
+
+func TestMemcacheTouch(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().Touch("my-key", int32(60)).Return(nil)
+
+	store := NewMemcache(client)
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestMemcacheTouchWhenKeyDoesNotExist(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(t)
+	client.EXPECT().Touch("my-key", int32(60)).Return(memcache.ErrCacheMiss)
+
+	store := NewMemcache(client)
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.NotFound{})
+}
+
 func TestMemcacheDelete(t *testing.T) {
 	// Given
 	ctx := context.Background()
@@ -348,6 +754,7 @@ func TestMemcacheDelete(t *testing.T) {
 
 	client := NewMockMemcacheClientInterface(t)
 	client.EXPECT().Delete(cacheKey).Return(nil)
+	client.EXPECT().Get("gocache_tag___key__:my-key").Return(nil, memcache.ErrCacheMiss)
 
 	store := NewMemcache(client)
 
@@ -366,6 +773,7 @@ func TestMemcacheDeleteWithMissingItem(t *testing.T) {
 
 	client := NewMockMemcacheClientInterface(t)
 	client.EXPECT().Delete(cacheKey).Return(memcache.ErrCacheMiss)
+	client.EXPECT().Get("gocache_tag___key__:my-key").Return(nil, memcache.ErrCacheMiss)
 
 	store := NewMemcache(client)
 
@@ -407,7 +815,10 @@ func TestMemcacheInvalidate(t *testing.T) {
 	client := NewMockMemcacheClientInterface(t)
 	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, nil)
 	client.EXPECT().Delete("a23fdf987h2svc23").Return(nil)
+	client.EXPECT().Get("gocache_tag___key__:a23fdf987h2svc23").Return(nil, memcache.ErrCacheMiss)
 	client.EXPECT().Delete("jHG2372x38hf74").Return(nil)
+	client.EXPECT().Get("gocache_tag___key__:jHG2372x38hf74").Return(nil, memcache.ErrCacheMiss)
+	client.EXPECT().Delete("gocache_tag_tag1").Return(nil)
 
 	store := NewMemcache(client)
 
@@ -430,6 +841,8 @@ func TestMemcacheInvalidateWhenError(t *testing.T) {
 	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, nil)
 	client.EXPECT().Delete("a23fdf987h2svc23").Return(errors.New("unexpected error"))
 	client.EXPECT().Delete("jHG2372x38hf74").Return(nil)
+	client.EXPECT().Get("gocache_tag___key__:jHG2372x38hf74").Return(nil, memcache.ErrCacheMiss)
+	client.EXPECT().Delete("gocache_tag_tag1").Return(nil)
 
 	store := NewMemcache(client)
 
@@ -437,7 +850,22 @@ func TestMemcacheInvalidateWhenError(t *testing.T) {
 	err := store.Invalidate(ctx, lib_store.WithInvalidateTags([]string{"tag1"}))
 
 	// Then
-	assert.Nil(t, err)
+	assert.EqualError(t, err, "unable to delete key 'a23fdf987h2svc23' of tag 'tag1': unexpected error")
+}
+
+func TestMemcacheInvalidateWithPrefix(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockMemcacheClientInterface(t)
+
+	store := NewMemcache(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePrefix("user:"))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ErrUnsupported)
 }
 
 func TestMemcacheClear(t *testing.T) {
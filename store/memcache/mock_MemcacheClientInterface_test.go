@@ -138,6 +138,72 @@ func (_c *MockMemcacheClientInterface_CompareAndSwap_Call) RunAndReturn(run func
 	return _c
 }
 
+// Decrement provides a mock function for the type MockMemcacheClientInterface
+func (_mock *MockMemcacheClientInterface) Decrement(key string, delta uint64) (uint64, error) {
+	ret := _mock.Called(key, delta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decrement")
+	}
+
+	var r0 uint64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, uint64) (uint64, error)); ok {
+		return returnFunc(key, delta)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, uint64) uint64); ok {
+		r0 = returnFunc(key, delta)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, uint64) error); ok {
+		r1 = returnFunc(key, delta)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMemcacheClientInterface_Decrement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decrement'
+type MockMemcacheClientInterface_Decrement_Call struct {
+	*mock.Call
+}
+
+// Decrement is a helper method to define mock.On call
+//   - key string
+//   - delta uint64
+func (_e *MockMemcacheClientInterface_Expecter) Decrement(key interface{}, delta interface{}) *MockMemcacheClientInterface_Decrement_Call {
+	return &MockMemcacheClientInterface_Decrement_Call{Call: _e.mock.On("Decrement", key, delta)}
+}
+
+func (_c *MockMemcacheClientInterface_Decrement_Call) Run(run func(key string, delta uint64)) *MockMemcacheClientInterface_Decrement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMemcacheClientInterface_Decrement_Call) Return(newValue uint64, err error) *MockMemcacheClientInterface_Decrement_Call {
+	_c.Call.Return(newValue, err)
+	return _c
+}
+
+func (_c *MockMemcacheClientInterface_Decrement_Call) RunAndReturn(run func(key string, delta uint64) (uint64, error)) *MockMemcacheClientInterface_Decrement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Delete provides a mock function for the type MockMemcacheClientInterface
 func (_mock *MockMemcacheClientInterface) Delete(item string) error {
 	ret := _mock.Called(item)
@@ -295,6 +361,134 @@ func (_c *MockMemcacheClientInterface_Get_Call) RunAndReturn(run func(key string
 	return _c
 }
 
+// GetMulti provides a mock function for the type MockMemcacheClientInterface
+func (_mock *MockMemcacheClientInterface) GetMulti(keys []string) (map[string]*memcache.Item, error) {
+	ret := _mock.Called(keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMulti")
+	}
+
+	var r0 map[string]*memcache.Item
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func([]string) (map[string]*memcache.Item, error)); ok {
+		return returnFunc(keys)
+	}
+	if returnFunc, ok := ret.Get(0).(func([]string) map[string]*memcache.Item); ok {
+		r0 = returnFunc(keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]*memcache.Item)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = returnFunc(keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMemcacheClientInterface_GetMulti_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMulti'
+type MockMemcacheClientInterface_GetMulti_Call struct {
+	*mock.Call
+}
+
+// GetMulti is a helper method to define mock.On call
+//   - keys []string
+func (_e *MockMemcacheClientInterface_Expecter) GetMulti(keys interface{}) *MockMemcacheClientInterface_GetMulti_Call {
+	return &MockMemcacheClientInterface_GetMulti_Call{Call: _e.mock.On("GetMulti", keys)}
+}
+
+func (_c *MockMemcacheClientInterface_GetMulti_Call) Run(run func(keys []string)) *MockMemcacheClientInterface_GetMulti_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		if args[0] != nil {
+			arg0 = args[0].([]string)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMemcacheClientInterface_GetMulti_Call) Return(items map[string]*memcache.Item, err error) *MockMemcacheClientInterface_GetMulti_Call {
+	_c.Call.Return(items, err)
+	return _c
+}
+
+func (_c *MockMemcacheClientInterface_GetMulti_Call) RunAndReturn(run func(keys []string) (map[string]*memcache.Item, error)) *MockMemcacheClientInterface_GetMulti_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Increment provides a mock function for the type MockMemcacheClientInterface
+func (_mock *MockMemcacheClientInterface) Increment(key string, delta uint64) (uint64, error) {
+	ret := _mock.Called(key, delta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Increment")
+	}
+
+	var r0 uint64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(string, uint64) (uint64, error)); ok {
+		return returnFunc(key, delta)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, uint64) uint64); ok {
+		r0 = returnFunc(key, delta)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, uint64) error); ok {
+		r1 = returnFunc(key, delta)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockMemcacheClientInterface_Increment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Increment'
+type MockMemcacheClientInterface_Increment_Call struct {
+	*mock.Call
+}
+
+// Increment is a helper method to define mock.On call
+//   - key string
+//   - delta uint64
+func (_e *MockMemcacheClientInterface_Expecter) Increment(key interface{}, delta interface{}) *MockMemcacheClientInterface_Increment_Call {
+	return &MockMemcacheClientInterface_Increment_Call{Call: _e.mock.On("Increment", key, delta)}
+}
+
+func (_c *MockMemcacheClientInterface_Increment_Call) Run(run func(key string, delta uint64)) *MockMemcacheClientInterface_Increment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMemcacheClientInterface_Increment_Call) Return(newValue uint64, err error) *MockMemcacheClientInterface_Increment_Call {
+	_c.Call.Return(newValue, err)
+	return _c
+}
+
+func (_c *MockMemcacheClientInterface_Increment_Call) RunAndReturn(run func(key string, delta uint64) (uint64, error)) *MockMemcacheClientInterface_Increment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Set provides a mock function for the type MockMemcacheClientInterface
 func (_mock *MockMemcacheClientInterface) Set(item *memcache.Item) error {
 	ret := _mock.Called(item)
@@ -345,3 +539,60 @@ func (_c *MockMemcacheClientInterface_Set_Call) RunAndReturn(run func(item *memc
 	_c.Call.Return(run)
 	return _c
 }
+
+// Touch provides a mock function for the type MockMemcacheClientInterface
+func (_mock *MockMemcacheClientInterface) Touch(key string, seconds int32) error {
+	ret := _mock.Called(key, seconds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Touch")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(string, int32) error); ok {
+		r0 = returnFunc(key, seconds)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockMemcacheClientInterface_Touch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Touch'
+type MockMemcacheClientInterface_Touch_Call struct {
+	*mock.Call
+}
+
+// Touch is a helper method to define mock.On call
+//   - key string
+//   - seconds int32
+func (_e *MockMemcacheClientInterface_Expecter) Touch(key interface{}, seconds interface{}) *MockMemcacheClientInterface_Touch_Call {
+	return &MockMemcacheClientInterface_Touch_Call{Call: _e.mock.On("Touch", key, seconds)}
+}
+
+func (_c *MockMemcacheClientInterface_Touch_Call) Run(run func(key string, seconds int32)) *MockMemcacheClientInterface_Touch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockMemcacheClientInterface_Touch_Call) Return(err error) *MockMemcacheClientInterface_Touch_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockMemcacheClientInterface_Touch_Call) RunAndReturn(run func(key string, seconds int32) error) *MockMemcacheClientInterface_Touch_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,104 @@
+package memcache
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/bradfitz/gomemcache/memcache"
+	lib_store "github.com/eko/gocache/lib/v4/store"
+)
+
+// tagIndexUpdateAttempts is the number of times a set update is attempted
+// when it conflicts with a concurrent writer
+const tagIndexUpdateAttempts = 3
+
+// tagIndexBackend stores the sets of the tag index as values encoded using lib_store.EncodeTagMembers,
+// updated using compare-and-swap so that concurrent writers do not lose members
+type tagIndexBackend struct {
+	client MemcacheClientInterface
+}
+
+// AddMembers adds the given members to the set and sets its time-to-live
+func (b *tagIndexBackend) AddMembers(_ context.Context, setKey string, members []string, ttl time.Duration) error {
+	return b.update(setKey, ttl, true, func(current []string) []string {
+		for _, member := range members {
+			if !slices.Contains(current, member) {
+				current = append(current, member)
+			}
+		}
+		return current
+	})
+}
+
+// Members returns the members of the set, or none if the set does not exist
+func (b *tagIndexBackend) Members(_ context.Context, setKey string) ([]string, error) {
+	item, err := b.client.Get(setKey)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	return lib_store.DecodeTagMembers(item.Value), nil
+}
+
+// RemoveMembers removes the given members from the set. As Memcache does not return
+// the expiration of items, the set then expires after the default tags TTL.
+func (b *tagIndexBackend) RemoveMembers(_ context.Context, setKey string, members []string) error {
+	return b.update(setKey, lib_store.DefaultTagsTTL, false, func(current []string) []string {
+		return slices.DeleteFunc(current, func(member string) bool {
+			return slices.Contains(members, member)
+		})
+	})
+}
+
+// DeleteSet removes the whole set
+func (b *tagIndexBackend) DeleteSet(_ context.Context, setKey string) error {
+	err := b.client.Delete(setKey)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		return nil
+	}
+	return mapError(err)
+}
+
+// update applies the given function to the members of the set, retrying when
+// the set has been modified concurrently
+func (b *tagIndexBackend) update(setKey string, ttl time.Duration, create bool, update func([]string) []string) error {
+	var err error
+	for i := 0; i < tagIndexUpdateAttempts; i++ {
+		err = b.tryUpdate(setKey, ttl, create, update)
+		if !errors.Is(err, memcache.ErrCASConflict) && !errors.Is(err, memcache.ErrNotStored) &&
+			!errors.Is(err, memcache.ErrCacheMiss) {
+			return mapError(err)
+		}
+	}
+
+	return mapError(err)
+}
+
+func (b *tagIndexBackend) tryUpdate(setKey string, ttl time.Duration, create bool, update func([]string) []string) error {
+	item, err := b.client.Get(setKey)
+	if errors.Is(err, memcache.ErrCacheMiss) {
+		if !create {
+			return nil
+		}
+
+		// Add only creates the set if it still does not exist
+		return b.client.Add(&memcache.Item{
+			Key:        setKey,
+			Value:      lib_store.EncodeTagMembers(update(nil)),
+			Expiration: int32(ttl.Seconds()),
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	item.Value = lib_store.EncodeTagMembers(update(lib_store.DecodeTagMembers(item.Value)))
+	item.Expiration = int32(ttl.Seconds())
+
+	return b.client.CompareAndSwap(item)
+}
@@ -1,13 +1,14 @@
 package pegasus
 
 import (
+	"cmp"
 	"context"
 	"errors"
-	"fmt"
-	"strings"
+	"iter"
 	"time"
 
 	"github.com/XiaoMi/pegasus-go-client/admin"
+	"github.com/XiaoMi/pegasus-go-client/idl/base"
 	"github.com/XiaoMi/pegasus-go-client/pegasus"
 	"github.com/spf13/cast"
 
@@ -45,6 +46,7 @@ type OptionsPegasus struct {
 type PegasusStore struct {
 	client  pegasus.Client
 	options *OptionsPegasus
+	tags    *lib_store.TagIndex
 }
 
 // NewPegasus creates a new store to pegasus instance(s)
@@ -66,10 +68,46 @@ func NewPegasus(ctx context.Context, options *OptionsPegasus) (*PegasusStore, er
 	}
 	defer table.Close()
 
-	return &PegasusStore{
+	return newPegasusStore(client, options), nil
+}
+
+// newPegasusStore creates a store using the given client, once its table exists
+func newPegasusStore(client pegasus.Client, options *OptionsPegasus) *PegasusStore {
+	p := &PegasusStore{
 		client:  client,
 		options: options,
-	}, nil
+	}
+	p.tags = lib_store.NewTagIndex(lib_store.NewValueTagIndexBackend(p.getTagValue, p.setTagValue, p.deleteTagValue), PegasusTagPattern)
+
+	return p
+}
+
+// getTagValue, setTagValue and deleteTagValue store the tag index in Pegasus.
+// Tag index updates are only serialized within the current process.
+func (p *PegasusStore) getTagValue(ctx context.Context, key string) ([]byte, time.Duration, error) {
+	value, ttl, err := p.GetWithTTL(ctx, key)
+	if err != nil {
+		return nil, 0, err
+	}
+	if ttl < 0 {
+		ttl = 0
+	}
+
+	return value.([]byte), ttl, nil
+}
+
+func (p *PegasusStore) setTagValue(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return p.Set(ctx, key, value, lib_store.WithExpiration(ttl))
+}
+
+func (p *PegasusStore) deleteTagValue(ctx context.Context, key string) error {
+	table, err := p.client.OpenTable(ctx, p.options.TableName)
+	if err != nil {
+		return mapError(err)
+	}
+	defer table.Close()
+
+	return mapError(table.Del(ctx, []byte(key), empty))
 }
 
 // validateOptions validate pegasus options
@@ -127,17 +165,26 @@ func (p *PegasusStore) Close() error {
 	return p.client.Close()
 }
 
-// Get returns data stored from a given key
+// Get returns data stored from a given key. When the store supports sliding expirations,
+// the expiration of the key is renewed if it has been set with one.
 func (p *PegasusStore) Get(ctx context.Context, key any) (any, error) {
 	table, err := p.client.OpenTable(ctx, p.options.TableName)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 	defer table.Close()
 
+	if p.slidingExpirationSupport() {
+		value, _, err := p.getWithSlidingExpiration(ctx, table, cast.ToString(key))
+		if err != nil {
+			return nil, err
+		}
+		return value, nil
+	}
+
 	value, err := table.Get(ctx, []byte(cast.ToString(key)), empty)
 	if err != nil {
-		return nil, err
+		return nil, mapError(err)
 	}
 	if value == nil {
 		return nil, &lib_store.NotFound{}
@@ -149,75 +196,150 @@ func (p *PegasusStore) Get(ctx context.Context, key any) (any, error) {
 func (p *PegasusStore) GetWithTTL(ctx context.Context, key any) (any, time.Duration, error) {
 	table, err := p.client.OpenTable(ctx, p.options.TableName)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, mapError(err)
 	}
 	defer table.Close()
 
-	value, err := table.Get(ctx, []byte(cast.ToString(key)), empty)
+	var value []byte
+	if p.slidingExpirationSupport() {
+		var sliding time.Duration
+		value, sliding, err = p.getWithSlidingExpiration(ctx, table, cast.ToString(key))
+		if err != nil {
+			return nil, 0, err
+		}
+		if sliding > 0 {
+			return value, sliding, nil
+		}
+	} else {
+		value, err = table.Get(ctx, []byte(cast.ToString(key)), empty)
+		if err != nil {
+			return nil, 0, mapError(err)
+		}
+		if value == nil {
+			return nil, 0, &lib_store.NotFound{}
+		}
+	}
+
+	ttl, err := table.TTL(ctx, []byte(cast.ToString(key)), empty)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, mapError(err)
 	}
-	if value == nil {
+
+	return value, time.Duration(ttl) * time.Second, nil
+}
+
+// slidingExpirationSupport returns whether the store has been created with
+// the lib_store.WithSlidingExpirationSupport option
+func (p *PegasusStore) slidingExpirationSupport() bool {
+	return p.options.Options != nil && p.options.SlidingExpirationSupport
+}
+
+// getWithSlidingExpiration reads the given key along with its sliding expiration using a batch get.
+// Pegasus is not able to change the expiration of a value so when the key has a
+// sliding expiration, both keys are set again in order to renew it, which is not
+// atomic with regard to concurrent writes.
+func (p *PegasusStore) getWithSlidingExpiration(ctx context.Context, table pegasus.TableConnector, key string) ([]byte, time.Duration, error) {
+	hashKey := []byte(key)
+	slidingHashKey := []byte(lib_store.SlidingExpirationKey(key))
+
+	values, err := table.BatchGet(ctx, []pegasus.CompositeKey{
+		{HashKey: hashKey, SortKey: empty},
+		{HashKey: slidingHashKey, SortKey: empty},
+	})
+	if err != nil {
+		return nil, 0, mapError(err)
+	}
+	if values[0] == nil {
 		return nil, 0, &lib_store.NotFound{}
 	}
+	if values[1] == nil {
+		return values[0], 0, nil
+	}
 
-	ttl, err := table.TTL(ctx, []byte(cast.ToString(key)), empty)
+	sliding, err := lib_store.ParseSlidingExpiration(values[1])
 	if err != nil {
 		return nil, 0, err
 	}
 
-	return value, time.Duration(ttl) * time.Second, nil
+	if err := table.SetTTL(ctx, hashKey, empty, values[0], sliding); err != nil {
+		return nil, 0, mapError(err)
+	}
+	if err := table.SetTTL(ctx, slidingHashKey, empty, values[1], sliding); err != nil {
+		return nil, 0, mapError(err)
+	}
+
+	return values[0], sliding, nil
 }
 
 // Set defines data in Pegasus for given key identifier
 func (p *PegasusStore) Set(ctx context.Context, key, value any, options ...lib_store.Option) error {
 	opts := lib_store.ApplyOptions(options...)
+	// default options are not applied, except for the strict mode and the expiration jitter
+	if p.options.Options != nil {
+		opts.Strict = opts.Strict || p.options.Strict
+		opts.ExpirationJitter = cmp.Or(opts.ExpirationJitter, p.options.ExpirationJitter)
+		opts.MaxExpirationJitter = cmp.Or(opts.MaxExpirationJitter, p.options.MaxExpirationJitter)
+	}
+	if err := p.Capabilities().CheckOptions(opts); err != nil {
+		return err
+	}
 
 	table, err := p.client.OpenTable(ctx, p.options.TableName)
 	if err != nil {
-		return err
+		return mapError(err)
 	}
 	defer table.Close()
 
-	err = table.SetTTL(ctx, []byte(cast.ToString(key)), empty, []byte(cast.ToString(value)), opts.Expiration)
+	err = table.SetTTL(ctx, []byte(cast.ToString(key)), empty, []byte(cast.ToString(value)), opts.EffectiveExpiration())
 	if err != nil {
-		return err
+		return mapError(err)
 	}
 
-	if tags := opts.Tags; len(tags) > 0 {
-		if err = p.setTags(ctx, key, tags); err != nil {
-			return err
+	if p.slidingExpirationSupport() {
+		slidingHashKey := []byte(lib_store.SlidingExpirationKey(cast.ToString(key)))
+		if opts.SlidingExpiration > 0 {
+			err = table.SetTTL(ctx, slidingHashKey, empty, []byte(lib_store.SlidingExpirationValue(opts.SlidingExpiration)), opts.SlidingExpiration)
+		} else {
+			err = table.Del(ctx, slidingHashKey, empty)
+		}
+		if err != nil {
+			return mapError(err)
 		}
 	}
-	return nil
-}
 
-func (p *PegasusStore) setTags(ctx context.Context, key any, tags []string) error {
-	for _, tag := range tags {
-		tagKey := fmt.Sprintf(PegasusTagPattern, tag)
-		cacheKeys := []string{}
+	return p.tags.Add(ctx, cast.ToString(key), opts.Tags, opts.TagsTTL)
+}
 
-		if result, err := p.Get(ctx, tagKey); err == nil {
-			if bytes, ok := result.([]byte); ok {
-				cacheKeys = strings.Split(string(bytes), ",")
-			}
-		}
+// Touch changes the expiration of the given key.
+// Pegasus is not able to change the expiration of a value so the value is
+// set again, which is not atomic with regard to concurrent writes. As the key
+// then has a fixed expiration, its sliding expiration is removed, if any.
+func (p *PegasusStore) Touch(ctx context.Context, key any, ttl time.Duration) error {
+	strict := p.options.Options != nil && p.options.Strict
+	if err := p.Capabilities().CheckOptions(&lib_store.Options{Strict: strict, Expiration: ttl}); err != nil {
+		return err
+	}
 
-		alreadyInserted := false
-		for _, cacheKey := range cacheKeys {
-			if cacheKey == key.(string) {
-				alreadyInserted = true
-				break
-			}
-		}
+	table, err := p.client.OpenTable(ctx, p.options.TableName)
+	if err != nil {
+		return mapError(err)
+	}
+	defer table.Close()
 
-		if !alreadyInserted {
-			cacheKeys = append(cacheKeys, key.(string))
-		}
+	hashKey := []byte(cast.ToString(key))
+	value, err := table.Get(ctx, hashKey, empty)
+	if err != nil {
+		return mapError(err)
+	}
+	if value == nil {
+		return &lib_store.NotFound{}
+	}
 
-		if err := p.Set(ctx, tagKey, []byte(strings.Join(cacheKeys, ",")), lib_store.WithExpiration(720*time.Hour)); err != nil {
-			return err
-		}
+	if err := table.SetTTL(ctx, hashKey, empty, value, ttl); err != nil {
+		return mapError(err)
+	}
+	if p.slidingExpirationSupport() {
+		return mapError(table.Del(ctx, []byte(lib_store.SlidingExpirationKey(cast.ToString(key))), empty))
 	}
 
 	return nil
@@ -227,79 +349,231 @@ func (p *PegasusStore) setTags(ctx context.Context, key any, tags []string) erro
 func (p *PegasusStore) Delete(ctx context.Context, key any) error {
 	table, err := p.client.OpenTable(ctx, p.options.TableName)
 	if err != nil {
-		return err
+		return mapError(err)
 	}
 	defer table.Close()
 
-	return table.Del(ctx, []byte(cast.ToString(key)), empty)
+	if err := p.deleteKey(ctx, table, cast.ToString(key)); err != nil {
+		return err
+	}
+
+	return p.tags.Remove(ctx, cast.ToString(key))
+}
+
+// deleteKey removes the given key and its sliding expiration from the table,
+// leaving the tag index untouched
+func (p *PegasusStore) deleteKey(ctx context.Context, table pegasus.TableConnector, key string) error {
+	if err := table.Del(ctx, []byte(key), empty); err != nil {
+		return mapError(err)
+	}
+	if p.slidingExpirationSupport() {
+		if err := table.Del(ctx, []byte(lib_store.SlidingExpirationKey(key)), empty); err != nil {
+			return mapError(err)
+		}
+	}
+
+	return nil
 }
 
 // Invalidate invalidates some cache data in Pegasus for given options
 func (p *PegasusStore) Invalidate(ctx context.Context, options ...lib_store.InvalidateOption) error {
 	opts := lib_store.ApplyInvalidateOptions(options...)
+
+	var err error
 	if tags := opts.Tags; len(tags) > 0 {
-		for _, tag := range tags {
-			tagKey := fmt.Sprintf(PegasusTagPattern, tag)
-			result, err := p.Get(ctx, tagKey)
+		err = p.tags.Invalidate(ctx, tags, func(ctx context.Context, key string) error {
+			return p.Delete(ctx, key)
+		})
+	}
+
+	if opts.HasKeyFilter() {
+		err = errors.Join(err, p.invalidateKeys(ctx, opts))
+	}
+
+	return err
+}
+
+// invalidateKeys deletes the keys matching the prefix or pattern of the given options,
+// found using Pegasus scanners, then removes them from the tag index at once
+func (p *PegasusStore) invalidateKeys(ctx context.Context, opts *lib_store.InvalidateOptions) error {
+	table, err := p.client.OpenTable(ctx, p.options.TableName)
+	if err != nil {
+		return mapError(err)
+	}
+	defer table.Close()
+
+	keys := []string{}
+	for key, err := range p.Keys(ctx, lib_store.WithScanMatch(opts.KeyPattern())) {
+		if err != nil {
+			return err
+		}
+		pegasusKey, err := lib_store.KeyAs[string](key)
+		if err != nil {
+			return err
+		}
+		if !opts.MatchKey(pegasusKey) {
+			continue
+		}
+		if err := p.deleteKey(ctx, table, pegasusKey); err != nil {
+			return err
+		}
+		keys = append(keys, pegasusKey)
+	}
+
+	return p.tags.Remove(ctx, keys...)
+}
+
+// Keys iterates over the keys stored in Pegasus using a full table scan.
+// Tag and sliding expiration keys are not returned.
+func (p *PegasusStore) Keys(ctx context.Context, options ...lib_store.ScanOption) iter.Seq2[any, error] {
+	opts := lib_store.ApplyScanOptions(options...)
+
+	return func(yield func(any, error) bool) {
+		for hashKey, err := range p.scan(ctx) {
 			if err != nil {
-				return nil
+				yield(nil, err)
+				return
 			}
 
-			cacheKeys := []string{}
-			if bytes, ok := result.([]byte); ok {
-				cacheKeys = strings.Split(string(bytes), ",")
+			key := string(hashKey)
+			if p.tags.IsIndexKey(key) || lib_store.IsSlidingExpirationKey(key) || !lib_store.MatchPattern(opts.Match, key) {
+				continue
 			}
-
-			for _, cacheKey := range cacheKeys {
-				if err := p.Delete(ctx, cacheKey); err != nil {
-					return err
-				}
+			if !yield(key, nil) {
+				return
 			}
 		}
 	}
-
-	return nil
 }
 
-// Clear resets all data in the store
+// Clear resets all data in the store. The tag index and the sliding expirations are
+// stored in the same table, so that they are deleted along with the values.
 func (p *PegasusStore) Clear(ctx context.Context) error {
 	table, err := p.client.OpenTable(ctx, p.options.TableName)
 	if err != nil {
-		return err
+		return mapError(err)
 	}
 	defer table.Close()
 
-	// init full scan
-	scanners, err := table.GetUnorderedScanners(ctx, p.options.TablePartitionNum, &pegasus.ScannerOptions{
-		BatchSize: p.options.TableScanNum,
-		// Values can be optimized out during scanning to reduce the workload.
-		NoValue: true,
-	})
-	if err != nil {
-		return err
+	// full scan and delete
+	for hashKey, err := range p.scan(ctx) {
+		if err != nil {
+			return err
+		}
+		if err := table.Del(ctx, hashKey, empty); err != nil {
+			return mapError(err)
+		}
 	}
+	return nil
+}
 
-	// full scan and delete
-	for _, scanner := range scanners {
-		// Iterates sequentially.
-		for {
-			completed, hashKey, _, _, err := scanner.Next(ctx)
-			if err != nil {
-				return err
-			}
-			if completed {
-				break
-			}
-			err = p.Delete(ctx, hashKey)
-			if err != nil {
-				return err
+// scan iterates over all the hash keys of the table
+func (p *PegasusStore) scan(ctx context.Context) iter.Seq2[[]byte, error] {
+	return func(yield func([]byte, error) bool) {
+		table, err := p.client.OpenTable(ctx, p.options.TableName)
+		if err != nil {
+			yield(nil, mapError(err))
+			return
+		}
+		defer table.Close()
+
+		// init full scan
+		scanners, err := table.GetUnorderedScanners(ctx, p.options.TablePartitionNum, &pegasus.ScannerOptions{
+			BatchSize: p.options.TableScanNum,
+			// Values can be optimized out during scanning to reduce the workload.
+			NoValue: true,
+		})
+		if err != nil {
+			yield(nil, mapError(err))
+			return
+		}
+
+		for _, scanner := range scanners {
+			// Iterates sequentially.
+			for {
+				completed, hashKey, _, _, err := scanner.Next(ctx)
+				if err != nil {
+					yield(nil, mapError(err))
+					return
+				}
+				if completed {
+					break
+				}
+				if !yield(hashKey, nil) {
+					return
+				}
 			}
 		}
 	}
-	return nil
+}
+
+// SlidingExpiration returns the sliding expiration given to the key, if any
+func (p *PegasusStore) SlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	if !p.slidingExpirationSupport() {
+		return 0, nil
+	}
+
+	table, err := p.client.OpenTable(ctx, p.options.TableName)
+	if err != nil {
+		return 0, mapError(err)
+	}
+	defer table.Close()
+
+	value, err := table.Get(ctx, []byte(lib_store.SlidingExpirationKey(cast.ToString(key))), empty)
+	if err != nil {
+		return 0, mapError(err)
+	}
+	if value == nil {
+		return 0, nil
+	}
+
+	return lib_store.ParseSlidingExpiration(value)
+}
+
+// Capabilities returns the features supported by Pegasus
+func (p *PegasusStore) Capabilities() lib_store.Capabilities {
+	return lib_store.Capabilities{
+		TTLPrecision:      time.Second,
+		Tags:              true,
+		Scan:              true,
+		SlidingExpiration: p.slidingExpirationSupport(),
+	}
 }
 
 // GetType returns the store type
 func (p *PegasusStore) GetType() string {
 	return PegasusType
 }
+
+// mapError maps the errors returned by the Pegasus client onto the store errors.
+// Pegasus errors are returned as PError values holding the error code of the operation.
+func mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	cause := err
+	var pegasusErr *pegasus.PError
+	if errors.As(err, &pegasusErr) {
+		cause = pegasusErr.Err
+	}
+
+	switch cause {
+	case base.ERR_TIMEOUT:
+		return lib_store.TimeoutWithCause(err)
+	case base.ERR_BUSY, base.ERR_NETWORK_FAILURE, base.ERR_NOT_ENOUGH_MEMBER,
+		base.ERR_SERVICE_NOT_ACTIVE, base.ERR_INACTIVE_STATE:
+		return lib_store.UnavailableWithCause(err)
+	case base.ERR_INVALID_DATA:
+		return lib_store.InvalidValueWithCause(err)
+	}
+
+	switch classified := lib_store.ClassifyError(cause); {
+	case errors.Is(classified, lib_store.ErrTimeout):
+		return lib_store.TimeoutWithCause(err)
+	case errors.Is(classified, lib_store.ErrUnavailable):
+		return lib_store.UnavailableWithCause(err)
+	}
+
+	return err
+}
@@ -0,0 +1,198 @@
+package pegasus
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/XiaoMi/pegasus-go-client/pegasus"
+)
+
+// fakeClient is a pegasus.Client opening a single in-memory table
+type fakeClient struct {
+	table *fakeTable
+}
+
+func (c *fakeClient) OpenTable(_ context.Context, _ string) (pegasus.TableConnector, error) {
+	return c.table, nil
+}
+
+func (c *fakeClient) Close() error {
+	return nil
+}
+
+type fakeEntry struct {
+	value    []byte
+	expireAt time.Time
+}
+
+// fakeTable is an in-memory pegasus.TableConnector ignoring sort keys and counting
+// the calls of each operation. Operations not used by the store are not implemented.
+type fakeTable struct {
+	pegasus.TableConnector
+
+	mu      sync.Mutex
+	entries map[string]fakeEntry
+	calls   map[string]int
+	// err is returned by all the operations when set
+	err error
+}
+
+func newFakeTable() *fakeTable {
+	return &fakeTable{
+		entries: map[string]fakeEntry{},
+		calls:   map[string]int{},
+	}
+}
+
+// newFakePegasus creates a store using an in-memory table
+func newFakePegasus(options *OptionsPegasus) (*PegasusStore, *fakeTable) {
+	if options == nil {
+		options = testPegasusOptions()
+	}
+	table := newFakeTable()
+
+	return newPegasusStore(&fakeClient{table: table}, options), table
+}
+
+func (t *fakeTable) call(operation string) error {
+	t.calls[operation]++
+	return t.err
+}
+
+func (t *fakeTable) get(hashKey []byte) []byte {
+	entry, ok := t.entries[string(hashKey)]
+	if !ok || (!entry.expireAt.IsZero() && !time.Now().Before(entry.expireAt)) {
+		return nil
+	}
+
+	return entry.value
+}
+
+func (t *fakeTable) Get(_ context.Context, hashKey []byte, _ []byte) ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.call("Get"); err != nil {
+		return nil, err
+	}
+
+	return t.get(hashKey), nil
+}
+
+func (t *fakeTable) BatchGet(_ context.Context, keys []pegasus.CompositeKey) ([][]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.call("BatchGet"); err != nil {
+		return nil, err
+	}
+
+	values := make([][]byte, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, t.get(key.HashKey))
+	}
+
+	return values, nil
+}
+
+func (t *fakeTable) Set(ctx context.Context, hashKey []byte, sortKey []byte, value []byte) error {
+	return t.SetTTL(ctx, hashKey, sortKey, value, 0)
+}
+
+func (t *fakeTable) SetTTL(_ context.Context, hashKey []byte, _ []byte, value []byte, ttl time.Duration) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.call("SetTTL"); err != nil {
+		return err
+	}
+
+	entry := fakeEntry{value: value}
+	if ttl > 0 {
+		entry.expireAt = time.Now().Add(ttl)
+	}
+	t.entries[string(hashKey)] = entry
+
+	return nil
+}
+
+func (t *fakeTable) Del(_ context.Context, hashKey []byte, _ []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.call("Del"); err != nil {
+		return err
+	}
+
+	delete(t.entries, string(hashKey))
+
+	return nil
+}
+
+func (t *fakeTable) TTL(_ context.Context, hashKey []byte, _ []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.call("TTL"); err != nil {
+		return 0, err
+	}
+
+	if t.get(hashKey) == nil {
+		return PegasusNOENTRY, nil
+	}
+
+	entry := t.entries[string(hashKey)]
+	if entry.expireAt.IsZero() {
+		return PegasusNOTTL, nil
+	}
+
+	return int(math.Ceil(time.Until(entry.expireAt).Seconds())), nil
+}
+
+func (t *fakeTable) GetUnorderedScanners(_ context.Context, _ int, _ *pegasus.ScannerOptions) ([]pegasus.Scanner, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err := t.call("GetUnorderedScanners"); err != nil {
+		return nil, err
+	}
+
+	hashKeys := [][]byte{}
+	for key := range t.entries {
+		if t.get([]byte(key)) != nil {
+			hashKeys = append(hashKeys, []byte(key))
+		}
+	}
+	slices.SortFunc(hashKeys, func(a, b []byte) int {
+		return slices.Compare(a, b)
+	})
+
+	return []pegasus.Scanner{&fakeScanner{hashKeys: hashKeys}}, nil
+}
+
+func (t *fakeTable) Close() error {
+	return nil
+}
+
+// fakeScanner iterates over the hash keys of a fakeTable snapshot
+type fakeScanner struct {
+	hashKeys [][]byte
+}
+
+func (s *fakeScanner) Next(_ context.Context) (bool, []byte, []byte, []byte, error) {
+	if len(s.hashKeys) == 0 {
+		return true, nil, nil, nil, nil
+	}
+
+	hashKey := s.hashKeys[0]
+	s.hashKeys = s.hashKeys[1:]
+
+	return false, hashKey, empty, nil, nil
+}
+
+func (s *fakeScanner) Close() error {
+	return nil
+}
@@ -2,10 +2,15 @@ package pegasus
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/XiaoMi/pegasus-go-client/idl/base"
+	"github.com/XiaoMi/pegasus-go-client/pegasus"
 	lib_store "github.com/eko/gocache/lib/v4/store"
+	"github.com/eko/gocache/lib/v4/store/storetest"
 	"github.com/smartystreets/assertions/should"
 	. "github.com/smartystreets/goconvey/convey"
 	"github.com/spf13/cast"
@@ -153,8 +158,39 @@ func TestPegasusStore_Set(t *testing.T) {
 	})
 }
 
-func TestPegasusStore_setTags(t *testing.T) {
-	Convey("Pegasus Test set tags for pegasus store", t, func() {
+func TestPegasusStore_SetWithSlidingExpiration(t *testing.T) {
+	Convey("Pegasus Test set with sliding expiration for pegasus store", t, func() {
+		skipPegasusTest(t)
+
+		ctx := context.Background()
+
+		options := testPegasusOptions()
+		options.Options = lib_store.ApplyOptions(lib_store.WithSlidingExpirationSupport())
+
+		p, _ := NewPegasus(ctx, options)
+		defer p.Close()
+
+		k, v, sliding := "test-gocache-key-sliding", "test-gocache-value", time.Second*2
+		err := p.Set(ctx, k, v, lib_store.WithSlidingExpiration(sliding))
+		So(err, ShouldBeNil)
+
+		for i := 0; i < 3; i++ {
+			time.Sleep(time.Second * 1)
+
+			value, ttl, err := p.GetWithTTL(ctx, k)
+			So(cast.ToString(value), ShouldEqual, v)
+			So(ttl, ShouldEqual, sliding)
+			So(err, ShouldBeNil)
+		}
+
+		expiration, err := p.SlidingExpiration(ctx, k)
+		So(expiration, ShouldEqual, sliding)
+		So(err, ShouldBeNil)
+	})
+}
+
+func TestPegasusStore_SetWithTags(t *testing.T) {
+	Convey("Pegasus Test set with tags for pegasus store", t, func() {
 		skipPegasusTest(t)
 
 		ctx := context.Background()
@@ -162,12 +198,26 @@ func TestPegasusStore_setTags(t *testing.T) {
 		p, _ := NewPegasus(ctx, testPegasusOptions())
 		defer p.Close()
 
-		k, tags := "test-gocache-tags-key", []string{"test01", "test02"}
-		err := p.setTags(ctx, k, tags)
+		k, v, tags := "test-gocache-tags-key", "test-gocache-value", []string{"test01", "test02"}
+		err := p.Set(ctx, k, v, lib_store.WithTags(tags))
 		So(err, ShouldBeNil)
 	})
 }
 
+func TestPegasusStore_TagIndexBackend(t *testing.T) {
+	skipPegasusTest(t)
+
+	storetest.TagIndexBackend(t, func(t *testing.T) lib_store.TagIndexBackend {
+		p, err := NewPegasus(context.Background(), testPegasusOptions())
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { p.Close() })
+
+		return lib_store.NewValueTagIndexBackend(p.getTagValue, p.setTagValue, p.deleteTagValue)
+	})
+}
+
 func TestPegasusStore_Delete(t *testing.T) {
 	Convey("Pegasus TestDelete for pegasus store", t, func() {
 		skipPegasusTest(t)
@@ -199,6 +249,72 @@ func TestPegasusStore_Invalidate(t *testing.T) {
 	})
 }
 
+func TestPegasusStore_InvalidateWithPrefix(t *testing.T) {
+	Convey("Pegasus TestInvalidateWithPrefix for pegasus store", t, func() {
+		skipPegasusTest(t)
+
+		ctx := context.Background()
+
+		p, _ := NewPegasus(ctx, testPegasusOptions())
+		defer p.Close()
+
+		p.Set(ctx, "test-gocache-prefix-01", "test-gocache-value")
+		p.Set(ctx, "test-gocache-prefix-02", "test-gocache-value")
+
+		err := p.Invalidate(ctx, lib_store.WithInvalidatePrefix("test-gocache-prefix-"))
+		So(err, ShouldBeNil)
+
+		_, err = p.Get(ctx, "test-gocache-prefix-01")
+		So(err, ShouldHaveSameTypeAs, &lib_store.NotFound{})
+	})
+}
+
+func TestPegasusStore_Keys(t *testing.T) {
+	Convey("Pegasus TestKeys for pegasus store", t, func() {
+		skipPegasusTest(t)
+
+		ctx := context.Background()
+
+		p, _ := NewPegasus(ctx, testPegasusOptions())
+		defer p.Close()
+
+		p.Set(ctx, "test-gocache-key-01", "test-gocache-value")
+		p.Set(ctx, "test-gocache-key-02", "test-gocache-value")
+		p.Set(ctx, "other-gocache-key", "test-gocache-value")
+
+		keys := []any{}
+		for key, err := range p.Keys(ctx, lib_store.WithScanMatch("test-gocache-key-*")) {
+			So(err, ShouldBeNil)
+			keys = append(keys, key)
+		}
+		So(keys, ShouldHaveLength, 2)
+	})
+}
+
+func TestPegasusStore_Touch(t *testing.T) {
+	Convey("Pegasus TestTouch for pegasus store", t, func() {
+		skipPegasusTest(t)
+
+		ctx := context.Background()
+
+		p, _ := NewPegasus(ctx, testPegasusOptions())
+		defer p.Close()
+
+		k, v := "test-gocache-key", "test-gocache-value"
+		p.Set(ctx, k, v)
+
+		err := p.Touch(ctx, k, time.Minute)
+		So(err, ShouldBeNil)
+
+		_, ttl, err := p.GetWithTTL(ctx, k)
+		So(err, ShouldBeNil)
+		So(ttl, ShouldBeGreaterThan, 0)
+
+		err = p.Touch(ctx, "test-gocache-missing-key", time.Minute)
+		So(err, ShouldHaveSameTypeAs, &lib_store.NotFound{})
+	})
+}
+
 func TestPegasusStore_Clear(t *testing.T) {
 	Convey("Pegasus TestClear for pegasus store", t, func() {
 		skipPegasusTest(t)
@@ -217,3 +333,174 @@ func TestPegasusStore_Clear(t *testing.T) {
 		So(err, ShouldBeNil)
 	})
 }
+
+func TestPegasusStore_ClearWithFakeTable(t *testing.T) {
+	Convey("Pegasus TestClear deletes every key of the table once", t, func() {
+		ctx := context.Background()
+
+		options := testPegasusOptions()
+		options.Options = lib_store.ApplyOptions(lib_store.WithSlidingExpirationSupport())
+
+		p, table := newFakePegasus(options)
+
+		So(p.Set(ctx, "test-gocache-key-01", "test-gocache-value", lib_store.WithTags([]string{"tag"})), ShouldBeNil)
+		So(p.Set(ctx, "test-gocache-key-02", "test-gocache-value", lib_store.WithSlidingExpiration(time.Minute)), ShouldBeNil)
+		entries := len(table.entries)
+		table.calls = map[string]int{}
+
+		err := p.Clear(ctx)
+		So(err, ShouldBeNil)
+		So(table.entries, ShouldBeEmpty)
+		So(table.calls["Del"], ShouldEqual, entries)
+		So(table.calls["Get"], ShouldEqual, 0)
+	})
+}
+
+func TestPegasusStore_InvalidateWithPrefixWithFakeTable(t *testing.T) {
+	Convey("Pegasus TestInvalidateWithPrefix removes the keys from the tag index", t, func() {
+		ctx := context.Background()
+
+		p, _ := newFakePegasus(nil)
+
+		tags := lib_store.WithTags([]string{"tag"})
+		So(p.Set(ctx, "test-gocache-prefix-01", "test-gocache-value", tags), ShouldBeNil)
+		So(p.Set(ctx, "test-gocache-prefix-02", "test-gocache-value"), ShouldBeNil)
+		So(p.Set(ctx, "other-gocache-key", "test-gocache-value", tags), ShouldBeNil)
+
+		err := p.Invalidate(ctx, lib_store.WithInvalidatePrefix("test-gocache-prefix-"))
+		So(err, ShouldBeNil)
+
+		_, err = p.Get(ctx, "test-gocache-prefix-01")
+		So(err, ShouldHaveSameTypeAs, &lib_store.NotFound{})
+
+		members, err := lib_store.NewValueTagIndexBackend(p.getTagValue, p.setTagValue, p.deleteTagValue).
+			Members(ctx, fmt.Sprintf(PegasusTagPattern, "tag"))
+		So(err, ShouldBeNil)
+		So(members, ShouldResemble, []string{"other-gocache-key"})
+	})
+}
+
+func TestPegasusStore_KeysWithFakeTable(t *testing.T) {
+	Convey("Pegasus TestKeys skips the tag index and sliding expiration keys", t, func() {
+		ctx := context.Background()
+
+		options := testPegasusOptions()
+		options.Options = lib_store.ApplyOptions(lib_store.WithSlidingExpirationSupport())
+
+		p, _ := newFakePegasus(options)
+
+		So(p.Set(ctx, "test-gocache-key-01", "test-gocache-value", lib_store.WithTags([]string{"tag"})), ShouldBeNil)
+		So(p.Set(ctx, "test-gocache-key-02", "test-gocache-value", lib_store.WithSlidingExpiration(time.Minute)), ShouldBeNil)
+		So(p.Set(ctx, "other-gocache-key", "test-gocache-value"), ShouldBeNil)
+
+		keys := []any{}
+		for key, err := range p.Keys(ctx, lib_store.WithScanMatch("test-gocache-key-*")) {
+			So(err, ShouldBeNil)
+			keys = append(keys, key)
+		}
+		So(keys, ShouldResemble, []any{"test-gocache-key-01", "test-gocache-key-02"})
+	})
+}
+
+func TestPegasusStore_SlidingExpirationWithFakeTable(t *testing.T) {
+	Convey("Pegasus TestSlidingExpiration renews the value and its sliding expiration on reads", t, func() {
+		ctx := context.Background()
+
+		options := testPegasusOptions()
+		options.Options = lib_store.ApplyOptions(lib_store.WithSlidingExpirationSupport())
+
+		p, table := newFakePegasus(options)
+
+		k, v, sliding := "test-gocache-key-sliding", "test-gocache-value", time.Minute
+		So(p.Set(ctx, k, v, lib_store.WithSlidingExpiration(sliding)), ShouldBeNil)
+
+		// bring both keys close to their expiration
+		for key, entry := range table.entries {
+			entry.expireAt = time.Now().Add(time.Second)
+			table.entries[key] = entry
+		}
+
+		value, ttl, err := p.GetWithTTL(ctx, k)
+		So(err, ShouldBeNil)
+		So(value, ShouldResemble, []byte(v))
+		So(ttl, ShouldEqual, sliding)
+		So(time.Until(table.entries[k].expireAt), ShouldBeGreaterThan, time.Second)
+		So(time.Until(table.entries[lib_store.SlidingExpirationKey(k)].expireAt), ShouldBeGreaterThan, time.Second)
+
+		expiration, err := p.SlidingExpiration(ctx, k)
+		So(err, ShouldBeNil)
+		So(expiration, ShouldEqual, sliding)
+
+		So(p.Set(ctx, k, v, lib_store.WithExpiration(time.Minute)), ShouldBeNil)
+
+		expiration, err = p.SlidingExpiration(ctx, k)
+		So(err, ShouldBeNil)
+		So(expiration, ShouldEqual, 0)
+	})
+}
+
+func TestPegasusStore_TouchWithFakeTable(t *testing.T) {
+	Convey("Pegasus TestTouch sets the value again with the new expiration", t, func() {
+		ctx := context.Background()
+
+		options := testPegasusOptions()
+		options.Options = lib_store.ApplyOptions(lib_store.WithSlidingExpirationSupport())
+
+		p, table := newFakePegasus(options)
+
+		k, v := "test-gocache-key", "test-gocache-value"
+		So(p.Set(ctx, k, v, lib_store.WithSlidingExpiration(time.Minute)), ShouldBeNil)
+
+		err := p.Touch(ctx, k, time.Hour)
+		So(err, ShouldBeNil)
+
+		value, ttl, err := p.GetWithTTL(ctx, k)
+		So(err, ShouldBeNil)
+		So(value, ShouldResemble, []byte(v))
+		So(ttl, ShouldBeGreaterThan, time.Minute)
+		So(table.entries, ShouldNotContainKey, lib_store.SlidingExpirationKey(k))
+
+		err = p.Touch(ctx, "test-gocache-missing-key", time.Minute)
+		So(err, ShouldHaveSameTypeAs, &lib_store.NotFound{})
+	})
+}
+
+func TestPegasusStore_MapErrorWithFakeTable(t *testing.T) {
+	Convey("Pegasus errors are mapped onto the store errors", t, func() {
+		ctx := context.Background()
+
+		p, table := newFakePegasus(nil)
+
+		testCases := []struct {
+			err      error
+			expected error
+		}{
+			{err: base.ERR_TIMEOUT, expected: lib_store.ErrTimeout},
+			{err: &pegasus.PError{Err: base.ERR_TIMEOUT, Op: pegasus.OpGet}, expected: lib_store.ErrTimeout},
+			{err: &pegasus.PError{Err: base.ERR_BUSY, Op: pegasus.OpGet}, expected: lib_store.ErrUnavailable},
+			{err: &pegasus.PError{Err: base.ERR_NETWORK_FAILURE, Op: pegasus.OpGet}, expected: lib_store.ErrUnavailable},
+			{err: &pegasus.PError{Err: base.ERR_INVALID_DATA, Op: pegasus.OpGet}, expected: lib_store.ErrInvalidValue},
+		}
+
+		for _, tc := range testCases {
+			table.err = tc.err
+
+			_, err := p.Get(ctx, "test-gocache-key")
+			So(errors.Is(err, tc.expected), ShouldBeTrue)
+			So(errors.Is(err, tc.err), ShouldBeTrue)
+		}
+
+		table.err = errors.New("unknown error")
+
+		_, err := p.Get(ctx, "test-gocache-key")
+		So(err, ShouldEqual, table.err)
+	})
+}
+
+func TestPegasusStore_TagIndexBackendWithFakeTable(t *testing.T) {
+	storetest.TagIndexBackend(t, func(t *testing.T) lib_store.TagIndexBackend {
+		p, _ := newFakePegasus(nil)
+
+		return lib_store.NewValueTagIndexBackend(p.getTagValue, p.setTagValue, p.deleteTagValue)
+	})
+}
@@ -2,7 +2,9 @@ package redis
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
 	"time"
 
 	lib_store "github.com/eko/gocache/lib/v4/store"
@@ -12,13 +14,26 @@ import (
 // RedisClientInterface represents a go-redis/redis client
 type RedisClientInterface interface {
 	Get(ctx context.Context, key string) *redis.StringCmd
+	MGet(ctx context.Context, keys ...string) *redis.SliceCmd
 	TTL(ctx context.Context, key string) *redis.DurationCmd
 	Expire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd
+	PExpire(ctx context.Context, key string, expiration time.Duration) *redis.BoolCmd
 	Set(ctx context.Context, key string, values any, expiration time.Duration) *redis.StatusCmd
 	Del(ctx context.Context, keys ...string) *redis.IntCmd
 	FlushAll(ctx context.Context) *redis.StatusCmd
 	SAdd(ctx context.Context, key string, members ...any) *redis.IntCmd
 	SMembers(ctx context.Context, key string) *redis.StringSliceCmd
+	SRem(ctx context.Context, key string, members ...any) *redis.IntCmd
+	Pipelined(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error)
+	Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd
+	IncrBy(ctx context.Context, key string, value int64) *redis.IntCmd
+	TxPipelined(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error)
+	SetNX(ctx context.Context, key string, value any, expiration time.Duration) *redis.BoolCmd
+	Eval(ctx context.Context, script string, keys []string, args ...any) *redis.Cmd
+	Persist(ctx context.Context, key string) *redis.BoolCmd
+	Exists(ctx context.Context, keys ...string) *redis.IntCmd
+	Unlink(ctx context.Context, keys ...string) *redis.IntCmd
+	Do(ctx context.Context, args ...any) *redis.Cmd
 }
 
 const (
@@ -27,13 +42,100 @@ const (
 	// RedisTagPattern represents the tag pattern to be used as a key in specified storage
 	RedisTagPattern = "gocache_tag_%s"
 
-	TagKeyExpiry = 720 * time.Hour
+	TagKeyExpiry = lib_store.DefaultTagsTTL
 )
 
+// invalidateBatchSize is the number of keys scanned and unlinked at once when
+// invalidating keys by prefix or pattern
+const invalidateBatchSize = 100
+
+// compareAndSwapScript sets the value of KEYS[1] to ARGV[2] with an optional
+// expiration in milliseconds given by ARGV[3] only if its current value is ARGV[1].
+// When given, KEYS[2] records the sliding expiration ARGV[4] of the new value as
+// nanoseconds, or is removed when it is zero.
+const compareAndSwapScript = `
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+if tonumber(ARGV[3]) > 0 then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+else
+	redis.call("SET", KEYS[1], ARGV[2])
+end
+if KEYS[2] then
+	if tonumber(ARGV[4]) > 0 then
+		redis.call("SET", KEYS[2], ARGV[4], "PX", math.floor(tonumber(ARGV[4]) / 1000000))
+	else
+		redis.call("DEL", KEYS[2])
+	end
+end
+return 1
+`
+
+// setIfNotExistsScript sets the value of KEYS[1] to ARGV[1] only if it does not exist,
+// with an optional expiration given by the SET argument ARGV[2] and its value ARGV[3],
+// zero meaning none. KEYS[2] then records the sliding expiration ARGV[4] of the value as
+// nanoseconds, or is removed when it is zero.
+const setIfNotExistsScript = `
+local set
+if tonumber(ARGV[3]) > 0 then
+	set = redis.call("SET", KEYS[1], ARGV[1], "NX", ARGV[2], ARGV[3])
+else
+	set = redis.call("SET", KEYS[1], ARGV[1], "NX")
+end
+if not set then
+	return 0
+end
+if tonumber(ARGV[4]) > 0 then
+	redis.call("SET", KEYS[2], ARGV[4], "PX", math.floor(tonumber(ARGV[4]) / 1000000))
+else
+	redis.call("DEL", KEYS[2])
+end
+return 1
+`
+
+// incrementScript adds ARGV[1] to the counter KEYS[1]. When the counter does not exist,
+// it is created with an optional expiration given by the SET argument ARGV[2] and its
+// value ARGV[3], zero meaning none, and KEYS[2] records the sliding expiration ARGV[4]
+// of the counter as nanoseconds, or is removed when it is zero.
+const incrementScript = `
+local created = redis.call("EXISTS", KEYS[1]) == 0
+if created and tonumber(ARGV[3]) > 0 then
+	redis.call("SET", KEYS[1], 0, ARGV[2], ARGV[3])
+end
+local counter = redis.call("INCRBY", KEYS[1], ARGV[1])
+if created then
+	if tonumber(ARGV[4]) > 0 then
+		redis.call("SET", KEYS[2], ARGV[4], "PX", math.floor(tonumber(ARGV[4]) / 1000000))
+	else
+		redis.call("DEL", KEYS[2])
+	end
+end
+return counter
+`
+
+// slidingGetScript returns the value of KEYS[1] along with its sliding expiration, held by
+// KEYS[2] as nanoseconds, renewing the expiration of both keys when there is one
+const slidingGetScript = `
+local value = redis.call("GET", KEYS[1])
+if not value then
+	return false
+end
+local sliding = redis.call("GET", KEYS[2])
+if not sliding then
+	return {value}
+end
+local expiration = math.floor(tonumber(sliding) / 1000000)
+redis.call("PEXPIRE", KEYS[1], expiration)
+redis.call("PEXPIRE", KEYS[2], expiration)
+return {value, sliding}
+`
+
 // RedisStore is a store for Redis
 type RedisStore struct {
 	client  RedisClientInterface
 	options *lib_store.Options
+	tags    *lib_store.TagIndex
 }
 
 // NewRedis creates a new store to Redis instance(s)
@@ -41,93 +143,691 @@ func NewRedis(client RedisClientInterface, options ...lib_store.Option) *RedisSt
 	return &RedisStore{
 		client:  client,
 		options: lib_store.ApplyOptions(options...),
+		tags:    lib_store.NewTagIndex(&tagIndexBackend{client: client}, RedisTagPattern),
 	}
 }
 
-// Get returns data stored from a given key
+// Get returns data stored from a given key. When the store supports sliding expirations,
+// the expiration of the key is renewed if it has been set with one.
 func (s *RedisStore) Get(ctx context.Context, key any) (any, error) {
-	object, err := s.client.Get(ctx, key.(string)).Result()
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.options.SlidingExpirationSupport {
+		object, _, err := slidingGetResult(slidingGet(ctx, s.client, redisKey))
+		if err != nil {
+			return nil, mapError(err)
+		}
+		return object, nil
+	}
+
+	object, err := s.client.Get(ctx, redisKey).Result()
 	if err == redis.Nil {
 		return nil, lib_store.NotFoundWithCause(err)
 	}
-	return object, err
+	return object, mapError(err)
 }
 
-// GetWithTTL returns data stored from a given key and its corresponding TTL
+// GetWithTTL returns data stored from a given key and its corresponding TTL.
+// The sliding expiration of the key is returned as TTL when it has one.
 func (s *RedisStore) GetWithTTL(ctx context.Context, key any) (any, time.Duration, error) {
-	object, err := s.client.Get(ctx, key.(string)).Result()
-	if err == redis.Nil {
-		return nil, 0, lib_store.NotFoundWithCause(err)
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var object any
+	if s.options.SlidingExpirationSupport {
+		var sliding time.Duration
+		object, sliding, err = slidingGetResult(slidingGet(ctx, s.client, redisKey))
+		if err == nil && sliding > 0 {
+			return object, sliding, nil
+		}
+	} else {
+		object, err = s.client.Get(ctx, redisKey).Result()
 	}
+	if err != nil {
+		return nil, 0, mapError(err)
+	}
+
+	ttl, err := s.client.TTL(ctx, redisKey).Result()
+	if err != nil {
+		return nil, 0, mapError(err)
+	}
+
+	return object, ttl, nil
+}
+
+// slidingGetter is implemented by the client and its pipelines
+type slidingGetter interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Eval(ctx context.Context, script string, keys []string, args ...any) *redis.Cmd
+}
+
+// slidingGet reads the given key along with its sliding expiration using a script which
+// renews the expiration of both keys atomically. Keys which cannot have a sliding
+// expiration are read using the GET command.
+func slidingGet(ctx context.Context, getter slidingGetter, key string) redis.Cmder {
+	slidingKey, ok := lib_store.SlidingExpirationSlotKey(key)
+	if !ok {
+		return getter.Get(ctx, key)
+	}
+
+	return getter.Eval(ctx, slidingGetScript, []string{key, slidingKey})
+}
+
+// slidingGetResult returns the value and the sliding expiration read by slidingGet
+func slidingGetResult(cmd redis.Cmder) (any, time.Duration, error) {
+	if stringCmd, ok := cmd.(*redis.StringCmd); ok {
+		object, err := stringCmd.Result()
+		return object, 0, err
+	}
+
+	result, err := cmd.(*redis.Cmd).StringSlice()
 	if err != nil {
 		return nil, 0, err
 	}
+	if len(result) < 2 {
+		return result[0], 0, nil
+	}
 
-	ttl, err := s.client.TTL(ctx, key.(string)).Result()
+	sliding, err := lib_store.ParseSlidingExpiration(result[1])
 	if err != nil {
 		return nil, 0, err
 	}
 
-	return object, ttl, err
+	return result[0], sliding, nil
+}
+
+// slidingKey returns the key recording the sliding expiration of the given key, to be
+// updated along with it, or false when the store does not support sliding expirations
+// or the key cannot have one. An error is returned when the options give a sliding
+// expiration to such a key.
+func (s *RedisStore) slidingKey(key string, opts *lib_store.Options) (string, bool, error) {
+	if !s.options.SlidingExpirationSupport {
+		return "", false, nil
+	}
+
+	slidingKey, ok := lib_store.SlidingExpirationSlotKey(key)
+	if !ok && opts.SlidingExpiration > 0 {
+		return "", false, fmt.Errorf("%w: key %q cannot have a sliding expiration", lib_store.ErrUnsupported, key)
+	}
+
+	return slidingKey, ok, nil
+}
+
+// expirationArgs returns the SET argument and its value giving the expiration of the
+// options to a script: their deadline with PXAT, or their expiration in milliseconds
+// with PX, zero meaning no expiration
+func expirationArgs(opts *lib_store.Options) (string, int64) {
+	if deadline := opts.EffectiveExpireAt(); !deadline.IsZero() {
+		return "PXAT", deadline.UnixMilli()
+	}
+
+	return "PX", opts.EffectiveExpiration().Milliseconds()
+}
+
+// valueSetter is implemented by the client and its pipelines
+type valueSetter interface {
+	Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd
+	Do(ctx context.Context, args ...any) *redis.Cmd
+}
+
+// setValue sets the value of the given key. A deadline is given to Redis with the PXAT
+// argument of the SET command so that the expiration is resolved when the value is
+// written, along with it.
+func setValue(ctx context.Context, setter valueSetter, key string, value any, opts *lib_store.Options) redis.Cmder {
+	if deadline := opts.EffectiveExpireAt(); !deadline.IsZero() {
+		return setter.Do(ctx, "set", key, value, "pxat", deadline.UnixMilli())
+	}
+
+	return setter.Set(ctx, key, value, opts.EffectiveExpiration())
 }
 
-// Set defines data in Redis for given key identifier
+// setSlidingExpiration records the sliding expiration of the given key in the pipeline,
+// or removes the one it had when it is set without sliding expiration. An error is
+// returned when the key cannot have a sliding expiration.
+func setSlidingExpiration(ctx context.Context, pipe redis.Pipeliner, key string, opts *lib_store.Options) error {
+	slidingKey, ok := lib_store.SlidingExpirationSlotKey(key)
+	switch {
+	case !ok && opts.SlidingExpiration > 0:
+		return fmt.Errorf("%w: key %q cannot have a sliding expiration", lib_store.ErrUnsupported, key)
+	case !ok:
+		return nil
+	case opts.SlidingExpiration > 0:
+		pipe.Set(ctx, slidingKey, lib_store.SlidingExpirationValue(opts.SlidingExpiration), opts.SlidingExpiration)
+	default:
+		pipe.Del(ctx, slidingKey)
+	}
+
+	return nil
+}
+
+// Set defines data in Redis for given key identifier. When the store supports sliding
+// expirations, the value and its sliding expiration are written in a transaction.
 func (s *RedisStore) Set(ctx context.Context, key any, value any, options ...lib_store.Option) error {
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
 	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return err
+	}
 
-	err := s.client.Set(ctx, key.(string), value, opts.Expiration).Err()
+	if s.options.SlidingExpirationSupport {
+		_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			setValue(ctx, pipe, redisKey, value, opts)
+			return setSlidingExpiration(ctx, pipe, redisKey, opts)
+		})
+	} else {
+		err = setValue(ctx, s.client, redisKey, value, opts).Err()
+	}
 	if err != nil {
+		return mapError(err)
+	}
+
+	return s.tags.Add(ctx, redisKey, opts.Tags, opts.TagsTTL)
+}
+
+// GetMany returns data stored from the given keys using a single MGET command, or a
+// pipeline renewing their sliding expirations when the store supports them.
+// Keys that are not found are omitted from the returned map.
+func (s *RedisStore) GetMany(ctx context.Context, keys []any) (map[any]any, error) {
+	if len(keys) == 0 {
+		return map[any]any{}, nil
+	}
+
+	redisKeys, err := lib_store.KeysAs[string](keys)
+	if err != nil {
+		return nil, err
+	}
+	if s.options.SlidingExpirationSupport {
+		return s.getManySliding(ctx, keys, redisKeys)
+	}
+
+	objects, err := s.client.MGet(ctx, redisKeys...).Result()
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	values := make(map[any]any, len(objects))
+	for i, object := range objects {
+		if object == nil {
+			continue
+		}
+		values[keys[i]] = object
+	}
+
+	return values, nil
+}
+
+// getManySliding reads the given keys along with their sliding expirations using a pipeline,
+// renewing their expirations as Get does. Keys that are not found are omitted.
+func (s *RedisStore) getManySliding(ctx context.Context, keys []any, redisKeys []string) (map[any]any, error) {
+	cmds, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, redisKey := range redisKeys {
+			slidingGet(ctx, pipe, redisKey)
+		}
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return nil, mapError(err)
+	}
+
+	values := make(map[any]any, len(cmds))
+	for i, cmd := range cmds {
+		object, _, err := slidingGetResult(cmd)
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return nil, mapError(err)
+		}
+		values[keys[i]] = object
+	}
+
+	return values, nil
+}
+
+// SetMany defines data in Redis for the given items using a pipeline, or a transaction
+// along with their sliding expirations when the store supports them
+func (s *RedisStore) SetMany(ctx context.Context, items map[any]any, options ...lib_store.Option) error {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
 		return err
 	}
 
-	if tags := opts.Tags; len(tags) > 0 {
-		ttl := opts.TagsTTL
-		if ttl == 0 {
-			ttl = TagKeyExpiry
+	redisItems := make(map[string]any, len(items))
+	for key, value := range items {
+		redisKey, err := lib_store.KeyAs[string](key)
+		if err != nil {
+			return err
+		}
+		redisItems[redisKey] = value
+	}
+
+	pipelined := s.client.Pipelined
+	if s.options.SlidingExpirationSupport {
+		pipelined = s.client.TxPipelined
+	}
+
+	_, err := pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for redisKey, value := range redisItems {
+			setValue(ctx, pipe, redisKey, value, opts)
+			if !s.options.SlidingExpirationSupport {
+				continue
+			}
+			if err := setSlidingExpiration(ctx, pipe, redisKey, opts); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return mapError(err)
+	}
+
+	errs := []error{}
+	for redisKey := range redisItems {
+		if err := s.tags.Add(ctx, redisKey, opts.Tags, opts.TagsTTL); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// DeleteMany removes data from Redis for the given keys using a single DEL command
+func (s *RedisStore) DeleteMany(ctx context.Context, keys []any) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	redisKeys, err := lib_store.KeysAs[string](keys)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.client.Del(ctx, s.withSlidingExpirationKeys(redisKeys...)...).Result(); err != nil {
+		return mapError(err)
+	}
+
+	return s.tags.Remove(ctx, redisKeys...)
+}
+
+// Keys iterates over the keys stored in Redis using the SCAN command.
+// Tag and sliding expiration keys are not returned.
+func (s *RedisStore) Keys(ctx context.Context, options ...lib_store.ScanOption) iter.Seq2[any, error] {
+	opts := lib_store.ApplyScanOptions(options...)
+	return func(yield func(any, error) bool) {
+		var cursor uint64
+		for {
+			keys, next, err := s.client.Scan(ctx, cursor, opts.Match, opts.Count).Result()
+			if err != nil {
+				yield(nil, mapError(err))
+				return
+			}
+
+			for _, key := range keys {
+				if s.tags.IsIndexKey(key) || lib_store.IsSlidingExpirationKey(key) {
+					continue
+				}
+				if !yield(key, nil) {
+					return
+				}
+			}
+
+			if next == 0 {
+				return
+			}
+			cursor = next
+		}
+	}
+}
+
+// Increment atomically adds the given delta to the counter stored for the given key
+// using the INCRBY command. When an expiration or a deadline is given, the counter
+// is created with it in the same transaction if it does not exist yet. When the store
+// supports sliding expirations, a script also records the sliding expiration of the
+// counter it creates, or removes the one left by a previous value.
+func (s *RedisStore) Increment(ctx context.Context, key any, delta int64, options ...lib_store.Option) (int64, error) {
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return 0, err
+	}
+
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return 0, err
+	}
+
+	slidingKey, sliding, err := s.slidingKey(redisKey, opts)
+	if err != nil {
+		return 0, err
+	}
+	if sliding {
+		mode, expiration := expirationArgs(opts)
+		counter, err := s.client.Eval(ctx, incrementScript, []string{redisKey, slidingKey},
+			delta, mode, expiration, lib_store.SlidingExpirationValue(opts.SlidingExpiration)).Int64()
+		return counter, mapError(err)
+	}
+
+	if opts.Expiration <= 0 && opts.ExpireAt.IsZero() {
+		counter, err := s.client.IncrBy(ctx, redisKey, delta).Result()
+		return counter, mapError(err)
+	}
+
+	cmds, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if deadline := opts.EffectiveExpireAt(); !deadline.IsZero() {
+			pipe.Do(ctx, "set", redisKey, 0, "nx", "pxat", deadline.UnixMilli())
+		} else {
+			pipe.SetNX(ctx, redisKey, 0, opts.EffectiveExpiration())
 		}
-		s.setTags(ctx, key, tags, ttl)
+		pipe.IncrBy(ctx, redisKey, delta)
+		return nil
+	})
+	if err != nil {
+		return 0, mapError(err)
+	}
+
+	counter, err := cmds[len(cmds)-1].(*redis.IntCmd).Result()
+	return counter, mapError(err)
+}
+
+// Decrement atomically subtracts the given delta from the counter stored for the given key
+func (s *RedisStore) Decrement(ctx context.Context, key any, delta int64, options ...lib_store.Option) (int64, error) {
+	return s.Increment(ctx, key, -delta, options...)
+}
+
+// SetIfNotExists defines data in Redis for given key identifier only if
+// it does not exist yet, using the SET NX command. When the store supports sliding
+// expirations, a script also records the sliding expiration of the value it sets, or
+// removes the one left by a previous value.
+func (s *RedisStore) SetIfNotExists(ctx context.Context, key any, value any, options ...lib_store.Option) error {
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return err
+	}
+
+	slidingKey, sliding, err := s.slidingKey(redisKey, opts)
+	if err != nil {
+		return err
+	}
+
+	var set bool
+	if sliding {
+		mode, expiration := expirationArgs(opts)
+		var result int
+		result, err = s.client.Eval(ctx, setIfNotExistsScript, []string{redisKey, slidingKey},
+			value, mode, expiration, lib_store.SlidingExpirationValue(opts.SlidingExpiration)).Int()
+		set = result == 1
+	} else {
+		set, err = s.client.SetNX(ctx, redisKey, value, opts.EffectiveExpiration()).Result()
+	}
+	if err != nil {
+		return mapError(err)
+	}
+	if !set {
+		return lib_store.ConditionFailedWithCause(nil)
+	}
+
+	return s.tags.Add(ctx, redisKey, opts.Tags, opts.TagsTTL)
+}
+
+// GetWithVersion returns data stored from a given key, the value itself
+// being used as version
+func (s *RedisStore) GetWithVersion(ctx context.Context, key any) (any, lib_store.Version, error) {
+	object, err := s.Get(ctx, key)
+	if err != nil {
+		return nil, lib_store.Version{}, err
+	}
+
+	return object, lib_store.NewVersion(object), nil
+}
+
+// CompareAndSwap defines data in Redis for given key identifier only if its
+// current value still is the one the version has been created from.
+// The comparison and the write are done atomically by a Lua script, which also
+// updates the sliding expiration of the key when the store supports them.
+func (s *RedisStore) CompareAndSwap(ctx context.Context, key any, value any, version lib_store.Version, options ...lib_store.Option) error {
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return err
+	}
+
+	expected, ok := version.Value().(string)
+	if !ok {
+		return lib_store.ConditionFailedWithCause(nil)
+	}
+
+	slidingKey, sliding, err := s.slidingKey(redisKey, opts)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{redisKey}
+	args := []any{expected, value, opts.EffectiveExpiration().Milliseconds()}
+	if sliding {
+		keys = append(keys, slidingKey)
+		args = append(args, lib_store.SlidingExpirationValue(opts.SlidingExpiration))
+	}
+
+	swapped, err := s.client.Eval(ctx, compareAndSwapScript, keys, args...).Int()
+	if err != nil {
+		return mapError(err)
+	}
+	if swapped == 0 {
+		return lib_store.ConditionFailedWithCause(nil)
+	}
+
+	return s.tags.Add(ctx, redisKey, opts.Tags, opts.TagsTTL)
+}
+
+// Touch changes the expiration of the given key using the PEXPIRE command,
+// or removes it using the PERSIST command when the ttl is not positive.
+// As the key then has a fixed expiration, its sliding expiration is removed
+// in the same transaction when the store supports them.
+func (s *RedisStore) Touch(ctx context.Context, key any, ttl time.Duration) error {
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Capabilities().CheckOptions(&lib_store.Options{Strict: s.options.Strict, Expiration: ttl}); err != nil {
+		return err
+	}
+
+	slidingKey, sliding, err := s.slidingKey(redisKey, &lib_store.Options{})
+	if err != nil {
+		return err
+	}
+
+	var updated bool
+	switch {
+	case sliding:
+		updated, err = s.touchWithSlidingExpiration(ctx, redisKey, slidingKey, ttl)
+	case ttl > 0:
+		updated, err = s.client.PExpire(ctx, redisKey, ttl).Result()
+	default:
+		updated, err = s.client.Persist(ctx, redisKey).Result()
+	}
+	if err != nil || updated {
+		return mapError(err)
+	}
+	if ttl > 0 {
+		return lib_store.NotFoundWithCause(redis.Nil)
+	}
+
+	// PERSIST also returns false when the key has no expiration
+	exists, err := s.client.Exists(ctx, redisKey).Result()
+	if err != nil {
+		return mapError(err)
+	}
+	if exists == 0 {
+		return lib_store.NotFoundWithCause(redis.Nil)
 	}
 
 	return nil
 }
 
-func (s *RedisStore) setTags(ctx context.Context, key any, tags []string, ttl time.Duration) {
-	for _, tag := range tags {
-		tagKey := fmt.Sprintf(RedisTagPattern, tag)
-		s.client.SAdd(ctx, tagKey, key.(string))
-		s.client.Expire(ctx, tagKey, ttl)
+// touchWithSlidingExpiration changes the expiration of the given key as Touch does and
+// removes its sliding expiration in the same transaction, reporting whether the key has
+// been updated
+func (s *RedisStore) touchWithSlidingExpiration(ctx context.Context, key, slidingKey string, ttl time.Duration) (bool, error) {
+	cmds, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if ttl > 0 {
+			pipe.PExpire(ctx, key, ttl)
+		} else {
+			pipe.Persist(ctx, key)
+		}
+		pipe.Del(ctx, slidingKey)
+		return nil
+	})
+	if err != nil {
+		return false, err
 	}
+
+	return cmds[0].(*redis.BoolCmd).Result()
 }
 
 // Delete removes data from Redis for given key identifier
 func (s *RedisStore) Delete(ctx context.Context, key any) error {
-	_, err := s.client.Del(ctx, key.(string)).Result()
-	return err
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.client.Del(ctx, s.withSlidingExpirationKeys(redisKey)...).Result(); err != nil {
+		return mapError(err)
+	}
+
+	return s.tags.Remove(ctx, redisKey)
 }
 
 // Invalidate invalidates some cache data in Redis for given options
 func (s *RedisStore) Invalidate(ctx context.Context, options ...lib_store.InvalidateOption) error {
 	opts := lib_store.ApplyInvalidateOptions(options...)
 
+	var err error
 	if tags := opts.Tags; len(tags) > 0 {
-		for _, tag := range tags {
-			tagKey := fmt.Sprintf(RedisTagPattern, tag)
-			cacheKeys, err := s.client.SMembers(ctx, tagKey).Result()
-			if err != nil {
-				continue
-			}
+		err = s.tags.Invalidate(ctx, tags, func(ctx context.Context, key string) error {
+			return s.Delete(ctx, key)
+		})
+	}
 
-			for _, cacheKey := range cacheKeys {
-				s.Delete(ctx, cacheKey)
-			}
+	if opts.HasKeyFilter() {
+		err = errors.Join(err, s.invalidateKeys(ctx, opts))
+	}
+
+	return err
+}
+
+// invalidateKeys unlinks the keys matching the prefix or pattern of the given options,
+// scanned server-side. Keys are unlinked by batches in order to reduce round trips.
+func (s *RedisStore) invalidateKeys(ctx context.Context, opts *lib_store.InvalidateOptions) error {
+	unlink := func(keys []string) error {
+		return mapError(s.client.Unlink(ctx, s.withSlidingExpirationKeys(keys...)...).Err())
+	}
+
+	keys := make([]string, 0, invalidateBatchSize)
+	for key, err := range s.Keys(ctx, lib_store.WithScanMatch(opts.KeyPattern()), lib_store.WithScanCount(invalidateBatchSize)) {
+		if err != nil {
+			return err
+		}
+		redisKey, err := lib_store.KeyAs[string](key)
+		if err != nil {
+			return err
+		}
+		if !opts.MatchKey(redisKey) {
+			continue
+		}
 
-			s.Delete(ctx, tagKey)
+		keys = append(keys, redisKey)
+		if len(keys) == invalidateBatchSize {
+			if err := unlink(keys); err != nil {
+				return err
+			}
+			keys = keys[:0]
 		}
 	}
 
+	if len(keys) > 0 {
+		return unlink(keys)
+	}
+
 	return nil
 }
 
+// SlidingExpiration returns the sliding expiration given to the key, if any.
+// No sliding expiration is returned when the store does not support them.
+func (s *RedisStore) SlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return 0, err
+	}
+
+	slidingKey, ok := lib_store.SlidingExpirationSlotKey(redisKey)
+	if !s.options.SlidingExpirationSupport || !ok {
+		return 0, nil
+	}
+
+	value, err := s.client.Get(ctx, slidingKey).Result()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, mapError(err)
+	}
+
+	return lib_store.ParseSlidingExpiration(value)
+}
+
+// withSlidingExpirationKeys returns the given keys along with their sliding expiration keys
+// when the store supports sliding expirations
+func (s *RedisStore) withSlidingExpirationKeys(keys ...string) []string {
+	if !s.options.SlidingExpirationSupport {
+		return keys
+	}
+
+	all := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		all = append(all, key)
+		if slidingKey, ok := lib_store.SlidingExpirationSlotKey(key); ok {
+			all = append(all, slidingKey)
+		}
+	}
+
+	return all
+}
+
+// Capabilities returns the features supported by Redis
+func (s *RedisStore) Capabilities() lib_store.Capabilities {
+	return lib_store.Capabilities{
+		TTLPrecision:      time.Millisecond,
+		Tags:              true,
+		Batch:             true,
+		Scan:              true,
+		AtomicCounters:    true,
+		ConditionalWrites: true,
+		SlidingExpiration: s.options.SlidingExpirationSupport,
+		MaxValueSize:      512 * 1024 * 1024,
+	}
+}
+
 // GetType returns the store type
 func (s *RedisStore) GetType() string {
 	return RedisType
@@ -136,8 +836,28 @@ func (s *RedisStore) GetType() string {
 // Clear resets all data in the store
 func (s *RedisStore) Clear(ctx context.Context) error {
 	if err := s.client.FlushAll(ctx).Err(); err != nil {
-		return err
+		return mapError(err)
 	}
 
 	return nil
 }
+
+// mapError maps the errors returned by the Redis client onto the store errors
+func mapError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, redis.Nil):
+		return lib_store.NotFoundWithCause(err)
+	case errors.Is(err, redis.ErrPoolTimeout):
+		return lib_store.TimeoutWithCause(err)
+	case errors.Is(err, redis.ErrClosed), errors.Is(err, redis.ErrPoolExhausted),
+		redis.HasErrorPrefix(err, "LOADING"), redis.HasErrorPrefix(err, "MASTERDOWN"),
+		redis.HasErrorPrefix(err, "CLUSTERDOWN"), redis.HasErrorPrefix(err, "TRYAGAIN"):
+		return lib_store.UnavailableWithCause(err)
+	case redis.HasErrorPrefix(err, "WRONGTYPE"), redis.HasErrorPrefix(err, "value is not"):
+		return lib_store.InvalidValueWithCause(err)
+	}
+
+	return lib_store.ClassifyError(err)
+}
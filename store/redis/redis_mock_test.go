@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=store/redis/redis.go -destination=store/redis/redis_mock_test.go -package=redis
+//	mockgen -source=store/redis/redis.go -destination=store/redis/redis_mock_test.go -package=redis -exclude_interfaces=slidingGetter,valueSetter
 //
 
 // Package redis is a generated GoMock package.
@@ -61,6 +61,63 @@ func (mr *MockRedisClientInterfaceMockRecorder) Del(ctx any, keys ...any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockRedisClientInterface)(nil).Del), varargs...)
 }
 
+// Do mocks base method.
+func (m *MockRedisClientInterface) Do(ctx context.Context, args ...any) *v9.Cmd {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Do", varargs...)
+	ret0, _ := ret[0].(*v9.Cmd)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockRedisClientInterfaceMockRecorder) Do(ctx any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockRedisClientInterface)(nil).Do), varargs...)
+}
+
+// Eval mocks base method.
+func (m *MockRedisClientInterface) Eval(ctx context.Context, script string, keys []string, args ...any) *v9.Cmd {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, script, keys}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Eval", varargs...)
+	ret0, _ := ret[0].(*v9.Cmd)
+	return ret0
+}
+
+// Eval indicates an expected call of Eval.
+func (mr *MockRedisClientInterfaceMockRecorder) Eval(ctx, script, keys any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, script, keys}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Eval", reflect.TypeOf((*MockRedisClientInterface)(nil).Eval), varargs...)
+}
+
+// Exists mocks base method.
+func (m *MockRedisClientInterface) Exists(ctx context.Context, keys ...string) *v9.IntCmd {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Exists", varargs...)
+	ret0, _ := ret[0].(*v9.IntCmd)
+	return ret0
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockRedisClientInterfaceMockRecorder) Exists(ctx any, keys ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockRedisClientInterface)(nil).Exists), varargs...)
+}
+
 // Expire mocks base method.
 func (m *MockRedisClientInterface) Expire(ctx context.Context, key string, expiration time.Duration) *v9.BoolCmd {
 	m.ctrl.T.Helper()
@@ -103,6 +160,82 @@ func (mr *MockRedisClientInterfaceMockRecorder) Get(ctx, key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRedisClientInterface)(nil).Get), ctx, key)
 }
 
+// IncrBy mocks base method.
+func (m *MockRedisClientInterface) IncrBy(ctx context.Context, key string, value int64) *v9.IntCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrBy", ctx, key, value)
+	ret0, _ := ret[0].(*v9.IntCmd)
+	return ret0
+}
+
+// IncrBy indicates an expected call of IncrBy.
+func (mr *MockRedisClientInterfaceMockRecorder) IncrBy(ctx, key, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrBy", reflect.TypeOf((*MockRedisClientInterface)(nil).IncrBy), ctx, key, value)
+}
+
+// MGet mocks base method.
+func (m *MockRedisClientInterface) MGet(ctx context.Context, keys ...string) *v9.SliceCmd {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MGet", varargs...)
+	ret0, _ := ret[0].(*v9.SliceCmd)
+	return ret0
+}
+
+// MGet indicates an expected call of MGet.
+func (mr *MockRedisClientInterfaceMockRecorder) MGet(ctx any, keys ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MGet", reflect.TypeOf((*MockRedisClientInterface)(nil).MGet), varargs...)
+}
+
+// PExpire mocks base method.
+func (m *MockRedisClientInterface) PExpire(ctx context.Context, key string, expiration time.Duration) *v9.BoolCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PExpire", ctx, key, expiration)
+	ret0, _ := ret[0].(*v9.BoolCmd)
+	return ret0
+}
+
+// PExpire indicates an expected call of PExpire.
+func (mr *MockRedisClientInterfaceMockRecorder) PExpire(ctx, key, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PExpire", reflect.TypeOf((*MockRedisClientInterface)(nil).PExpire), ctx, key, expiration)
+}
+
+// Persist mocks base method.
+func (m *MockRedisClientInterface) Persist(ctx context.Context, key string) *v9.BoolCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Persist", ctx, key)
+	ret0, _ := ret[0].(*v9.BoolCmd)
+	return ret0
+}
+
+// Persist indicates an expected call of Persist.
+func (mr *MockRedisClientInterfaceMockRecorder) Persist(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Persist", reflect.TypeOf((*MockRedisClientInterface)(nil).Persist), ctx, key)
+}
+
+// Pipelined mocks base method.
+func (m *MockRedisClientInterface) Pipelined(ctx context.Context, fn func(v9.Pipeliner) error) ([]v9.Cmder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pipelined", ctx, fn)
+	ret0, _ := ret[0].([]v9.Cmder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pipelined indicates an expected call of Pipelined.
+func (mr *MockRedisClientInterfaceMockRecorder) Pipelined(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pipelined", reflect.TypeOf((*MockRedisClientInterface)(nil).Pipelined), ctx, fn)
+}
+
 // SAdd mocks base method.
 func (m *MockRedisClientInterface) SAdd(ctx context.Context, key string, members ...any) *v9.IntCmd {
 	m.ctrl.T.Helper()
@@ -136,6 +269,39 @@ func (mr *MockRedisClientInterfaceMockRecorder) SMembers(ctx, key any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMembers", reflect.TypeOf((*MockRedisClientInterface)(nil).SMembers), ctx, key)
 }
 
+// SRem mocks base method.
+func (m *MockRedisClientInterface) SRem(ctx context.Context, key string, members ...any) *v9.IntCmd {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key}
+	for _, a := range members {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SRem", varargs...)
+	ret0, _ := ret[0].(*v9.IntCmd)
+	return ret0
+}
+
+// SRem indicates an expected call of SRem.
+func (mr *MockRedisClientInterfaceMockRecorder) SRem(ctx, key any, members ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key}, members...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SRem", reflect.TypeOf((*MockRedisClientInterface)(nil).SRem), varargs...)
+}
+
+// Scan mocks base method.
+func (m *MockRedisClientInterface) Scan(ctx context.Context, cursor uint64, match string, count int64) *v9.ScanCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scan", ctx, cursor, match, count)
+	ret0, _ := ret[0].(*v9.ScanCmd)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockRedisClientInterfaceMockRecorder) Scan(ctx, cursor, match, count any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockRedisClientInterface)(nil).Scan), ctx, cursor, match, count)
+}
+
 // Set mocks base method.
 func (m *MockRedisClientInterface) Set(ctx context.Context, key string, values any, expiration time.Duration) *v9.StatusCmd {
 	m.ctrl.T.Helper()
@@ -150,6 +316,20 @@ func (mr *MockRedisClientInterfaceMockRecorder) Set(ctx, key, values, expiration
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRedisClientInterface)(nil).Set), ctx, key, values, expiration)
 }
 
+// SetNX mocks base method.
+func (m *MockRedisClientInterface) SetNX(ctx context.Context, key string, value any, expiration time.Duration) *v9.BoolCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNX", ctx, key, value, expiration)
+	ret0, _ := ret[0].(*v9.BoolCmd)
+	return ret0
+}
+
+// SetNX indicates an expected call of SetNX.
+func (mr *MockRedisClientInterfaceMockRecorder) SetNX(ctx, key, value, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNX", reflect.TypeOf((*MockRedisClientInterface)(nil).SetNX), ctx, key, value, expiration)
+}
+
 // TTL mocks base method.
 func (m *MockRedisClientInterface) TTL(ctx context.Context, key string) *v9.DurationCmd {
 	m.ctrl.T.Helper()
@@ -163,3 +343,37 @@ func (mr *MockRedisClientInterfaceMockRecorder) TTL(ctx, key any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TTL", reflect.TypeOf((*MockRedisClientInterface)(nil).TTL), ctx, key)
 }
+
+// TxPipelined mocks base method.
+func (m *MockRedisClientInterface) TxPipelined(ctx context.Context, fn func(v9.Pipeliner) error) ([]v9.Cmder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxPipelined", ctx, fn)
+	ret0, _ := ret[0].([]v9.Cmder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TxPipelined indicates an expected call of TxPipelined.
+func (mr *MockRedisClientInterfaceMockRecorder) TxPipelined(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxPipelined", reflect.TypeOf((*MockRedisClientInterface)(nil).TxPipelined), ctx, fn)
+}
+
+// Unlink mocks base method.
+func (m *MockRedisClientInterface) Unlink(ctx context.Context, keys ...string) *v9.IntCmd {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Unlink", varargs...)
+	ret0, _ := ret[0].(*v9.IntCmd)
+	return ret0
+}
+
+// Unlink indicates an expected call of Unlink.
+func (mr *MockRedisClientInterfaceMockRecorder) Unlink(ctx any, keys ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlink", reflect.TypeOf((*MockRedisClientInterface)(nil).Unlink), varargs...)
+}
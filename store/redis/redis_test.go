@@ -3,6 +3,8 @@ package redis
 import (
 	"context"
 	"fmt"
+	"net"
+	"syscall"
 	"testing"
 	"time"
 
@@ -92,6 +94,69 @@ func TestRedisGet(t *testing.T) {
 	}
 }
 
+// serverError is an error replied by the Redis server
+type serverError string
+
+func (e serverError) Error() string { return string(e) }
+
+func (e serverError) RedisError() {}
+
+func TestRedisGetWhenClientError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	store := NewRedis(client)
+
+	tests := []struct {
+		name      string
+		returnErr error
+		expectErr error
+	}{
+		{name: "Not Found", returnErr: redis.Nil, expectErr: lib_store.NotFound{}},
+		{name: "Pool Timeout", returnErr: redis.ErrPoolTimeout, expectErr: lib_store.ErrTimeout},
+		{name: "Client Closed", returnErr: redis.ErrClosed, expectErr: lib_store.ErrUnavailable},
+		{name: "Connection Refused", returnErr: &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED}, expectErr: lib_store.ErrUnavailable},
+		{name: "Server Loading", returnErr: serverError("LOADING Redis is loading the dataset in memory"), expectErr: lib_store.ErrUnavailable},
+		{name: "Wrong Type", returnErr: serverError("WRONGTYPE Operation against a key holding the wrong kind of value"), expectErr: lib_store.ErrInvalidValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client.EXPECT().Get(ctx, "my-key").Return(redis.NewStringResult("", tt.returnErr))
+
+			// When
+			_, err := store.Get(ctx, "my-key")
+
+			// Then
+			assert.ErrorIs(t, err, tt.expectErr)
+			assert.ErrorIs(t, err, tt.returnErr)
+		})
+	}
+}
+
+func TestRedisWhenKeyIsNotAString(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	store := NewRedis(client)
+
+	// When
+	_, getErr := store.Get(ctx, 42)
+	setErr := store.Set(ctx, 42, "my-value")
+	deleteErr := store.Delete(ctx, 42)
+
+	// Then
+	assert.ErrorIs(t, getErr, lib_store.ErrInvalidKeyType)
+	assert.ErrorIs(t, setErr, lib_store.ErrInvalidKeyType)
+	assert.ErrorIs(t, deleteErr, lib_store.ErrInvalidKeyType)
+}
+
 func TestRedisSet(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -113,6 +178,279 @@ func TestRedisSet(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRedisSetWithExpirationJitter(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	jittered := gomock.Cond(func(ttl time.Duration) bool {
+		return ttl > 9*time.Second && ttl <= 10*time.Second
+	})
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Set(ctx, "my-key", "my-cache-value", jittered).Return(&redis.StatusCmd{})
+
+	store := NewRedis(client, lib_store.WithExpiration(10*time.Second), lib_store.WithMaxExpirationJitter(time.Second))
+
+	// When
+	err := store.Set(ctx, "my-key", "my-cache-value")
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRedisSetWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClient(&redis.Options{}).TxPipeline().(*redis.Pipeline)
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().TxPipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
+
+	store := NewRedis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Set(ctx, "my-key", "my-cache-value", lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Len(t, pipe.Cmds(), 2)
+	assert.Equal(t, []any{"set", "my-key", "my-cache-value", "ex", int64(60)}, pipe.Cmds()[0].Args())
+	assert.Equal(t, []any{"set", "gocache_sliding_{my-key}", "60000000000", "ex", int64(60)}, pipe.Cmds()[1].Args())
+}
+
+func TestRedisSetWithSlidingExpirationWhenKeyCannotHaveOne(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClient(&redis.Options{}).TxPipeline().(*redis.Pipeline)
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().TxPipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
+
+	store := NewRedis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Set(ctx, "my}key", "my-cache-value", lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ErrUnsupported)
+}
+
+func TestRedisSetWithExpireAt(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	deadline := time.UnixMilli(1767225600000)
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Do(ctx, "set", "my-key", "my-cache-value", "pxat", int64(1767225600000)).Return(&redis.Cmd{})
+
+	store := NewRedis(client, lib_store.WithExpiration(time.Minute))
+
+	// When
+	err := store.Set(ctx, "my-key", "my-cache-value", lib_store.WithExpireAt(deadline))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRedisGetWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, slidingGetScript, []string{"my-key", "gocache_sliding_{my-key}"}).
+		Return(redis.NewCmdResult([]any{"my-value", "60000000000"}, nil))
+
+	store := NewRedis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	value, ttl, err := store.GetWithTTL(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, time.Minute, ttl)
+}
+
+func TestRedisGetWithSlidingExpirationSupportWhenKeyHasNone(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, slidingGetScript, []string{"my-key", "gocache_sliding_{my-key}"}).
+		Return(redis.NewCmdResult([]any{"my-value"}, nil))
+	client.EXPECT().TTL(ctx, "my-key").Return(redis.NewDurationResult(10*time.Second, nil))
+
+	store := NewRedis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	value, ttl, err := store.GetWithTTL(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, 10*time.Second, ttl)
+}
+
+func TestRedisGetWithSlidingExpirationSupportWhenNotFound(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, slidingGetScript, []string{"my-key", "gocache_sliding_{my-key}"}).
+		Return(redis.NewCmdResult(nil, redis.Nil))
+
+	store := NewRedis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	value, err := store.Get(ctx, "my-key")
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.NotFound{})
+	assert.Nil(t, value)
+}
+
+func TestRedisGetManyWithSlidingExpirationSupport(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClient(&redis.Options{}).Pipeline().(*redis.Pipeline)
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		assert.Nil(t, fn(pipe))
+
+		return []redis.Cmder{
+			redis.NewCmdResult([]any{"value-1", "60000000000"}, nil),
+			redis.NewCmdResult(nil, redis.Nil),
+		}, redis.Nil
+	})
+
+	store := NewRedis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	values, err := store.GetMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1"}, values)
+	assert.Equal(t, []any{"eval", slidingGetScript, 2, "key-1", "gocache_sliding_{key-1}"}, pipe.Cmds()[0].Args())
+}
+
+func TestRedisDeleteWithSlidingExpirationSupport(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Del(ctx, "my-key", "gocache_sliding_{my-key}").Return(&redis.IntCmd{})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).Return([]redis.Cmder{redis.NewStringSliceResult(nil, nil)}, nil)
+
+	store := NewRedis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Delete(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRedisSlidingExpirationWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+
+	store := NewRedis(client)
+
+	// When
+	sliding, err := store.SlidingExpiration(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), sliding)
+	assert.False(t, store.Capabilities().SlidingExpiration)
+}
+
+func TestRedisSetWhenStrictAndOptionNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+
+	store := NewRedis(client, lib_store.WithStrictOptions())
+
+	// When
+	err := store.Set(ctx, "my-key", "my-cache-value", lib_store.WithCost(4))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ErrUnsupported)
+	assert.ErrorContains(t, err, "WithCost")
+}
+
+func TestRedisSetIfNotExistsWhenStrictAndOptionNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+
+	store := NewRedis(client, lib_store.WithStrictOptions())
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-key", "my-cache-value", lib_store.WithCost(4))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ErrUnsupported)
+	assert.ErrorContains(t, err, "WithCost")
+}
+
+func TestRedisTouchWhenStrictAndExpirationTooPrecise(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+
+	store := NewRedis(client, lib_store.WithStrictOptions())
+
+	// When
+	err := store.Touch(ctx, "my-key", 1500*time.Microsecond)
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ErrUnsupported)
+	assert.ErrorContains(t, err, "WithExpiration")
+}
+
 func TestRedisSetWhenNoOptionsGiven(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -147,6 +485,8 @@ func TestRedisSetWithTags(t *testing.T) {
 	client.EXPECT().Set(ctx, cacheKey, cacheValue, time.Duration(0)).Return(&redis.StatusCmd{})
 	client.EXPECT().SAdd(ctx, "gocache_tag_tag1", "my-key").Return(&redis.IntCmd{})
 	client.EXPECT().Expire(ctx, "gocache_tag_tag1", TagKeyExpiry).Return(&redis.BoolCmd{})
+	client.EXPECT().SAdd(ctx, "gocache_tag___key__:my-key", "tag1").Return(&redis.IntCmd{})
+	client.EXPECT().Expire(ctx, "gocache_tag___key__:my-key", TagKeyExpiry).Return(&redis.BoolCmd{})
 
 	store := NewRedis(client)
 
@@ -170,6 +510,8 @@ func TestRedisSetWithTagsAndTagsTTL(t *testing.T) {
 	client.EXPECT().Set(ctx, cacheKey, cacheValue, time.Duration(0)).Return(&redis.StatusCmd{})
 	client.EXPECT().SAdd(ctx, "gocache_tag_tag1", "my-key").Return(&redis.IntCmd{})
 	client.EXPECT().Expire(ctx, "gocache_tag_tag1", time.Hour).Return(&redis.BoolCmd{})
+	client.EXPECT().SAdd(ctx, "gocache_tag___key__:my-key", "tag1").Return(&redis.IntCmd{})
+	client.EXPECT().Expire(ctx, "gocache_tag___key__:my-key", time.Hour).Return(&redis.BoolCmd{})
 
 	store := NewRedis(client)
 
@@ -193,6 +535,7 @@ func TestRedisDelete(t *testing.T) {
 
 	client := NewMockRedisClientInterface(ctrl)
 	client.EXPECT().Del(ctx, "my-key").Return(&redis.IntCmd{})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).Return([]redis.Cmder{redis.NewStringSliceResult(nil, nil)}, nil)
 
 	store := NewRedis(client)
 
@@ -203,25 +546,574 @@ func TestRedisDelete(t *testing.T) {
 	assert.Nil(t, err)
 }
 
-func TestRedisInvalidate(t *testing.T) {
+func TestRedisDeleteWhenTagged(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
 
 	ctx := context.Background()
 
-	cacheKeys := &redis.StringSliceCmd{}
+	pipe := redis.NewClient(&redis.Options{}).Pipeline().(*redis.Pipeline)
 
 	client := NewMockRedisClientInterface(ctrl)
-	client.EXPECT().SMembers(ctx, "gocache_tag_tag1").Return(cacheKeys)
-	client.EXPECT().Del(ctx, "gocache_tag_tag1").Return(&redis.IntCmd{})
+	client.EXPECT().Del(ctx, "my-key").Return(&redis.IntCmd{})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).Return([]redis.Cmder{
+		redis.NewStringSliceResult([]string{"tag1", "tag2"}, nil),
+	}, nil)
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
 
 	store := NewRedis(client)
 
 	// When
-	err := store.Invalidate(ctx, lib_store.WithInvalidateTags([]string{"tag1"}))
+	err := store.Delete(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Len(t, pipe.Cmds(), 3)
+	assert.Equal(t, []any{"srem", "gocache_tag_tag1", "my-key"}, pipe.Cmds()[0].Args())
+	assert.Equal(t, []any{"srem", "gocache_tag_tag2", "my-key"}, pipe.Cmds()[1].Args())
+	assert.Equal(t, []any{"del", "gocache_tag___key__:my-key"}, pipe.Cmds()[2].Args())
+}
+
+func TestRedisGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().MGet(ctx, "key-1", "key-2", "key-3").Return(redis.NewSliceResult([]any{"value-1", nil, "value-3"}, nil))
+
+	store := NewRedis(client)
+
+	// When
+	values, err := store.GetMany(ctx, []any{"key-1", "key-2", "key-3"})
 
 	// Then
 	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1", "key-3": "value-3"}, values)
+}
+
+func TestRedisSetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClient(&redis.Options{}).Pipeline()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
+
+	store := NewRedis(client)
+
+	// When
+	err := store.SetMany(ctx, map[any]any{"key-1": "value-1", "key-2": "value-2"}, lib_store.WithExpiration(5*time.Second))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 2, pipe.Len())
+}
+
+func TestRedisDeleteMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Del(ctx, "key-1", "key-2").Return(&redis.IntCmd{})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).Return([]redis.Cmder{redis.NewStringSliceResult(nil, nil), redis.NewStringSliceResult(nil, nil)}, nil)
+
+	store := NewRedis(client)
+
+	// When
+	err := store.DeleteMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRedisKeys(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	gomock.InOrder(
+		client.EXPECT().Scan(ctx, uint64(0), "key-*", int64(10)).
+			Return(redis.NewScanCmdResult([]string{"key-1", "gocache_tag_tag1"}, 42, nil)),
+		client.EXPECT().Scan(ctx, uint64(42), "key-*", int64(10)).
+			Return(redis.NewScanCmdResult([]string{"key-2"}, 0, nil)),
+	)
+
+	store := NewRedis(client)
+
+	// When
+	keys := []any{}
+	for key, err := range store.Keys(ctx, lib_store.WithScanMatch("key-*"), lib_store.WithScanCount(10)) {
+		assert.Nil(t, err)
+		keys = append(keys, key)
+	}
+
+	// Then
+	assert.Equal(t, []any{"key-1", "key-2"}, keys)
+}
+
+func TestRedisKeysWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := fmt.Errorf("an unexpected error occurred")
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Scan(ctx, uint64(0), "", int64(0)).Return(redis.NewScanCmdResult(nil, 0, expectedErr))
+
+	store := NewRedis(client)
+
+	// When
+	var errs []error
+	for _, err := range store.Keys(ctx) {
+		errs = append(errs, err)
+	}
+
+	// Then
+	assert.Equal(t, []error{expectedErr}, errs)
+}
+
+func TestRedisIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().IncrBy(ctx, "my-counter", int64(2)).Return(redis.NewIntResult(5, nil))
+
+	store := NewRedis(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), value)
+}
+
+func TestRedisIncrementWithExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClient(&redis.Options{}).TxPipeline()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().TxPipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		assert.Nil(t, fn(pipe))
+		assert.Equal(t, 2, pipe.Len())
+
+		return []redis.Cmder{
+			redis.NewBoolResult(true, nil),
+			redis.NewIntResult(1, nil),
+		}, nil
+	})
+
+	store := NewRedis(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 1, lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), value)
+}
+
+func TestRedisIncrementWithExpireAt(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	deadline := time.UnixMilli(1767225600000)
+
+	pipe := redis.NewClient(&redis.Options{}).TxPipeline().(*redis.Pipeline)
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().TxPipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		assert.Nil(t, fn(pipe))
+
+		return []redis.Cmder{
+			redis.NewCmdResult(nil, nil),
+			redis.NewIntResult(1, nil),
+		}, nil
+	})
+
+	store := NewRedis(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 1, lib_store.WithExpireAt(deadline))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), value)
+	assert.Len(t, pipe.Cmds(), 2)
+	assert.Equal(t, []any{"set", "my-counter", 0, "nx", "pxat", int64(1767225600000)}, pipe.Cmds()[0].Args())
+	assert.Equal(t, []any{"incrby", "my-counter", int64(1)}, pipe.Cmds()[1].Args())
+}
+
+func TestRedisIncrementWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, incrementScript, []string{"my-counter", "gocache_sliding_{my-counter}"},
+		int64(1), "PX", int64(60000), "60000000000").Return(redis.NewCmdResult(int64(1), nil))
+
+	store := NewRedis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 1, lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), value)
+}
+
+func TestRedisDecrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().IncrBy(ctx, "my-counter", int64(-2)).Return(redis.NewIntResult(-2, nil))
+
+	store := NewRedis(client)
+
+	// When
+	value, err := store.Decrement(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-2), value)
+}
+
+func TestRedisSetIfNotExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().SetNX(ctx, "my-lock", "owner", time.Minute).Return(redis.NewBoolResult(true, nil))
+
+	store := NewRedis(client)
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-lock", "owner", lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRedisSetIfNotExistsWhenKeyExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().SetNX(ctx, "my-lock", "owner", time.Duration(0)).Return(redis.NewBoolResult(false, nil))
+
+	store := NewRedis(client)
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-lock", "owner")
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ConditionFailed{})
+}
+
+func TestRedisSetIfNotExistsWithSlidingExpirationSupport(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, setIfNotExistsScript, []string{"my-lock", "gocache_sliding_{my-lock}"},
+		"owner", "PX", int64(60000), "0").Return(redis.NewCmdResult(int64(0), nil))
+
+	store := NewRedis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-lock", "owner", lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ConditionFailed{})
+}
+
+func TestRedisGetWithVersion(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Get(ctx, "my-key").Return(redis.NewStringResult("my-value", nil))
+
+	store := NewRedis(client)
+
+	// When
+	value, version, err := store.GetWithVersion(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, "my-value", version.Value())
+}
+
+func TestRedisCompareAndSwap(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, compareAndSwapScript, []string{"my-key"}, "my-value", "new-value", int64(60000)).
+		Return(redis.NewCmdResult(int64(1), nil))
+
+	store := NewRedis(client)
+
+	// When
+	err := store.CompareAndSwap(ctx, "my-key", "new-value", lib_store.NewVersion("my-value"), lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRedisCompareAndSwapWhenValueHasChanged(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, compareAndSwapScript, []string{"my-key"}, "my-value", "new-value", int64(0)).
+		Return(redis.NewCmdResult(int64(0), nil))
+
+	store := NewRedis(client)
+
+	// When
+	err := store.CompareAndSwap(ctx, "my-key", "new-value", lib_store.NewVersion("my-value"))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ConditionFailed{})
+}
+
+func TestRedisCompareAndSwapWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, compareAndSwapScript, []string{"my-key", "gocache_sliding_{my-key}"},
+		"my-value", "new-value", int64(60000), "60000000000").Return(redis.NewCmdResult(int64(1), nil))
+
+	store := NewRedis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.CompareAndSwap(ctx, "my-key", "new-value", lib_store.NewVersion("my-value"), lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRedisTouch(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().PExpire(ctx, "my-key", time.Minute).Return(redis.NewBoolResult(true, nil))
+
+	store := NewRedis(client)
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRedisTouchWhenKeyDoesNotExist(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().PExpire(ctx, "my-key", time.Minute).Return(redis.NewBoolResult(false, nil))
+
+	store := NewRedis(client)
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.NotFound{})
+}
+
+func TestRedisTouchWithoutExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Persist(ctx, "my-key").Return(redis.NewBoolResult(false, nil))
+	client.EXPECT().Exists(ctx, "my-key").Return(redis.NewIntResult(1, nil))
+
+	store := NewRedis(client)
+
+	// When
+	err := store.Touch(ctx, "my-key", 0)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRedisTouchWithSlidingExpirationSupport(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClient(&redis.Options{}).TxPipeline().(*redis.Pipeline)
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().TxPipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		assert.Nil(t, fn(pipe))
+
+		return []redis.Cmder{
+			redis.NewBoolResult(true, nil),
+			redis.NewIntResult(1, nil),
+		}, nil
+	})
+
+	store := NewRedis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Len(t, pipe.Cmds(), 2)
+	assert.Equal(t, []any{"pexpire", "my-key", int64(60000)}, pipe.Cmds()[0].Args())
+	assert.Equal(t, []any{"del", "gocache_sliding_{my-key}"}, pipe.Cmds()[1].Args())
+}
+
+func TestRedisInvalidate(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	cacheKeys := &redis.StringSliceCmd{}
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().SMembers(ctx, "gocache_tag_tag1").Return(cacheKeys)
+	client.EXPECT().Del(ctx, "gocache_tag_tag1").Return(&redis.IntCmd{})
+
+	store := NewRedis(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidateTags([]string{"tag1"}))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRedisInvalidateWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := fmt.Errorf("an unexpected error occurred")
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().SMembers(ctx, "gocache_tag_tag1").Return(redis.NewStringSliceResult([]string{"key-1", "key-2"}, nil))
+	client.EXPECT().Del(ctx, "key-1").Return(redis.NewIntResult(0, expectedErr))
+	client.EXPECT().Del(ctx, "key-2").Return(&redis.IntCmd{})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).Return([]redis.Cmder{redis.NewStringSliceResult([]string{"tag1"}, nil)}, nil)
+	client.EXPECT().Pipelined(ctx, gomock.Any()).Return(nil, nil)
+	client.EXPECT().Del(ctx, "gocache_tag_tag1").Return(&redis.IntCmd{})
+
+	store := NewRedis(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidateTags([]string{"tag1"}))
+
+	// Then
+	assert.ErrorIs(t, err, expectedErr)
+	assert.ErrorContains(t, err, "unable to delete key 'key-1' of tag 'tag1'")
+}
+
+func TestRedisInvalidateWithPrefix(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClientInterface(ctrl)
+	gomock.InOrder(
+		client.EXPECT().Scan(ctx, uint64(0), "user:*", int64(100)).
+			Return(redis.NewScanCmdResult([]string{"user:1", "user:2"}, 42, nil)),
+		client.EXPECT().Scan(ctx, uint64(42), "user:*", int64(100)).
+			Return(redis.NewScanCmdResult([]string{"user:3"}, 0, nil)),
+		client.EXPECT().Unlink(ctx, "user:1", "user:2", "user:3").Return(&redis.IntCmd{}),
+	)
+
+	store := NewRedis(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePrefix("user:"))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRedisInvalidateWithPatternWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := fmt.Errorf("an unexpected error occurred")
+
+	client := NewMockRedisClientInterface(ctrl)
+	client.EXPECT().Scan(ctx, uint64(0), "user:*:profile", int64(100)).
+		Return(redis.NewScanCmdResult(nil, 0, expectedErr))
+
+	store := NewRedis(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePattern("user:*:profile"))
+
+	// Then
+	assert.ErrorIs(t, err, expectedErr)
 }
 
 func TestRedisClear(t *testing.T) {
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen -source=store/rediscluster/rediscluster.go -destination=store/rediscluster/rediscluster_mock_test.go -package=rediscluster
+//	mockgen -source=store/rediscluster/rediscluster.go -destination=store/rediscluster/rediscluster_mock_test.go -package=rediscluster -exclude_interfaces=slidingGetter,valueSetter
 //
 
 // Package rediscluster is a generated GoMock package.
@@ -61,6 +61,63 @@ func (mr *MockRedisClusterClientInterfaceMockRecorder) Del(ctx any, keys ...any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Del", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).Del), varargs...)
 }
 
+// Do mocks base method.
+func (m *MockRedisClusterClientInterface) Do(ctx context.Context, args ...any) *v9.Cmd {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Do", varargs...)
+	ret0, _ := ret[0].(*v9.Cmd)
+	return ret0
+}
+
+// Do indicates an expected call of Do.
+func (mr *MockRedisClusterClientInterfaceMockRecorder) Do(ctx any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Do", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).Do), varargs...)
+}
+
+// Eval mocks base method.
+func (m *MockRedisClusterClientInterface) Eval(ctx context.Context, script string, keys []string, args ...any) *v9.Cmd {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, script, keys}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Eval", varargs...)
+	ret0, _ := ret[0].(*v9.Cmd)
+	return ret0
+}
+
+// Eval indicates an expected call of Eval.
+func (mr *MockRedisClusterClientInterfaceMockRecorder) Eval(ctx, script, keys any, args ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, script, keys}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Eval", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).Eval), varargs...)
+}
+
+// Exists mocks base method.
+func (m *MockRedisClusterClientInterface) Exists(ctx context.Context, keys ...string) *v9.IntCmd {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Exists", varargs...)
+	ret0, _ := ret[0].(*v9.IntCmd)
+	return ret0
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockRedisClusterClientInterfaceMockRecorder) Exists(ctx any, keys ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).Exists), varargs...)
+}
+
 // Expire mocks base method.
 func (m *MockRedisClusterClientInterface) Expire(ctx context.Context, key string, expiration time.Duration) *v9.BoolCmd {
 	m.ctrl.T.Helper()
@@ -89,6 +146,20 @@ func (mr *MockRedisClusterClientInterfaceMockRecorder) FlushAll(ctx any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushAll", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).FlushAll), ctx)
 }
 
+// ForEachMaster mocks base method.
+func (m *MockRedisClusterClientInterface) ForEachMaster(ctx context.Context, fn func(context.Context, *v9.Client) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForEachMaster", ctx, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForEachMaster indicates an expected call of ForEachMaster.
+func (mr *MockRedisClusterClientInterfaceMockRecorder) ForEachMaster(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForEachMaster", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).ForEachMaster), ctx, fn)
+}
+
 // Get mocks base method.
 func (m *MockRedisClusterClientInterface) Get(ctx context.Context, key string) *v9.StringCmd {
 	m.ctrl.T.Helper()
@@ -103,6 +174,63 @@ func (mr *MockRedisClusterClientInterfaceMockRecorder) Get(ctx, key any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).Get), ctx, key)
 }
 
+// IncrBy mocks base method.
+func (m *MockRedisClusterClientInterface) IncrBy(ctx context.Context, key string, value int64) *v9.IntCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrBy", ctx, key, value)
+	ret0, _ := ret[0].(*v9.IntCmd)
+	return ret0
+}
+
+// IncrBy indicates an expected call of IncrBy.
+func (mr *MockRedisClusterClientInterfaceMockRecorder) IncrBy(ctx, key, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrBy", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).IncrBy), ctx, key, value)
+}
+
+// PExpire mocks base method.
+func (m *MockRedisClusterClientInterface) PExpire(ctx context.Context, key string, expiration time.Duration) *v9.BoolCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PExpire", ctx, key, expiration)
+	ret0, _ := ret[0].(*v9.BoolCmd)
+	return ret0
+}
+
+// PExpire indicates an expected call of PExpire.
+func (mr *MockRedisClusterClientInterfaceMockRecorder) PExpire(ctx, key, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PExpire", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).PExpire), ctx, key, expiration)
+}
+
+// Persist mocks base method.
+func (m *MockRedisClusterClientInterface) Persist(ctx context.Context, key string) *v9.BoolCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Persist", ctx, key)
+	ret0, _ := ret[0].(*v9.BoolCmd)
+	return ret0
+}
+
+// Persist indicates an expected call of Persist.
+func (mr *MockRedisClusterClientInterfaceMockRecorder) Persist(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Persist", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).Persist), ctx, key)
+}
+
+// Pipelined mocks base method.
+func (m *MockRedisClusterClientInterface) Pipelined(ctx context.Context, fn func(v9.Pipeliner) error) ([]v9.Cmder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pipelined", ctx, fn)
+	ret0, _ := ret[0].([]v9.Cmder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pipelined indicates an expected call of Pipelined.
+func (mr *MockRedisClusterClientInterfaceMockRecorder) Pipelined(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pipelined", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).Pipelined), ctx, fn)
+}
+
 // SAdd mocks base method.
 func (m *MockRedisClusterClientInterface) SAdd(ctx context.Context, key string, members ...any) *v9.IntCmd {
 	m.ctrl.T.Helper()
@@ -136,6 +264,25 @@ func (mr *MockRedisClusterClientInterfaceMockRecorder) SMembers(ctx, key any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SMembers", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).SMembers), ctx, key)
 }
 
+// SRem mocks base method.
+func (m *MockRedisClusterClientInterface) SRem(ctx context.Context, key string, members ...any) *v9.IntCmd {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, key}
+	for _, a := range members {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SRem", varargs...)
+	ret0, _ := ret[0].(*v9.IntCmd)
+	return ret0
+}
+
+// SRem indicates an expected call of SRem.
+func (mr *MockRedisClusterClientInterfaceMockRecorder) SRem(ctx, key any, members ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, key}, members...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SRem", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).SRem), varargs...)
+}
+
 // Set mocks base method.
 func (m *MockRedisClusterClientInterface) Set(ctx context.Context, key string, values any, expiration time.Duration) *v9.StatusCmd {
 	m.ctrl.T.Helper()
@@ -150,6 +297,20 @@ func (mr *MockRedisClusterClientInterfaceMockRecorder) Set(ctx, key, values, exp
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).Set), ctx, key, values, expiration)
 }
 
+// SetNX mocks base method.
+func (m *MockRedisClusterClientInterface) SetNX(ctx context.Context, key string, value any, expiration time.Duration) *v9.BoolCmd {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNX", ctx, key, value, expiration)
+	ret0, _ := ret[0].(*v9.BoolCmd)
+	return ret0
+}
+
+// SetNX indicates an expected call of SetNX.
+func (mr *MockRedisClusterClientInterfaceMockRecorder) SetNX(ctx, key, value, expiration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNX", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).SetNX), ctx, key, value, expiration)
+}
+
 // TTL mocks base method.
 func (m *MockRedisClusterClientInterface) TTL(ctx context.Context, key string) *v9.DurationCmd {
 	m.ctrl.T.Helper()
@@ -163,3 +324,37 @@ func (mr *MockRedisClusterClientInterfaceMockRecorder) TTL(ctx, key any) *gomock
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TTL", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).TTL), ctx, key)
 }
+
+// TxPipelined mocks base method.
+func (m *MockRedisClusterClientInterface) TxPipelined(ctx context.Context, fn func(v9.Pipeliner) error) ([]v9.Cmder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TxPipelined", ctx, fn)
+	ret0, _ := ret[0].([]v9.Cmder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TxPipelined indicates an expected call of TxPipelined.
+func (mr *MockRedisClusterClientInterfaceMockRecorder) TxPipelined(ctx, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TxPipelined", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).TxPipelined), ctx, fn)
+}
+
+// Unlink mocks base method.
+func (m *MockRedisClusterClientInterface) Unlink(ctx context.Context, keys ...string) *v9.IntCmd {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Unlink", varargs...)
+	ret0, _ := ret[0].(*v9.IntCmd)
+	return ret0
+}
+
+// Unlink indicates an expected call of Unlink.
+func (mr *MockRedisClusterClientInterfaceMockRecorder) Unlink(ctx any, keys ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlink", reflect.TypeOf((*MockRedisClusterClientInterface)(nil).Unlink), varargs...)
+}
@@ -2,6 +2,8 @@ package rediscluster
 
 import (
 	"context"
+	"errors"
+	"net"
 	"testing"
 	"time"
 
@@ -66,6 +68,201 @@ func TestRedisClusterSet(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRedisClusterSetWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClient(&redis.Options{}).TxPipeline()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().TxPipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
+
+	store := NewRedisCluster(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Set(ctx, "my-key", "my-cache-value", lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 2, pipe.Len())
+}
+
+func TestRedisClusterSetWithSlidingExpirationWhenKeyCannotHaveOne(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClient(&redis.Options{}).TxPipeline()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().TxPipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
+
+	store := NewRedisCluster(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Set(ctx, "my}key", "my-cache-value", lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ErrUnsupported)
+}
+
+func TestRedisClusterSetWithExpireAt(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	deadline := time.UnixMilli(1767225600000)
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Do(ctx, "set", "my-key", "my-cache-value", "pxat", int64(1767225600000)).Return(&redis.Cmd{})
+
+	store := NewRedisCluster(client, lib_store.WithExpiration(time.Minute))
+
+	// When
+	err := store.Set(ctx, "my-key", "my-cache-value", lib_store.WithExpireAt(deadline))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRedisClusterGetWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, slidingGetScript, []string{"my-key", "gocache_sliding_{my-key}"}).
+		Return(redis.NewCmdResult([]any{"my-value", "60000000000"}, nil))
+
+	store := NewRedisCluster(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	value, ttl, err := store.GetWithTTL(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, time.Minute, ttl)
+}
+
+func TestRedisClusterGetWithSlidingExpirationSupportWhenKeyHasNone(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, slidingGetScript, []string{"my-key", "gocache_sliding_{my-key}"}).
+		Return(redis.NewCmdResult([]any{"my-value"}, nil))
+	client.EXPECT().TTL(ctx, "my-key").Return(redis.NewDurationResult(10*time.Second, nil))
+
+	store := NewRedisCluster(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	value, ttl, err := store.GetWithTTL(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, 10*time.Second, ttl)
+}
+
+func TestRedisClusterGetWithSlidingExpirationSupportWhenNotFound(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, slidingGetScript, []string{"my-key", "gocache_sliding_{my-key}"}).
+		Return(redis.NewCmdResult(nil, redis.Nil))
+
+	store := NewRedisCluster(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	value, err := store.Get(ctx, "my-key")
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.NotFound{})
+	assert.Nil(t, value)
+}
+
+func TestRedisClusterGetManyWithSlidingExpirationSupport(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClient(&redis.Options{}).Pipeline()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		assert.Nil(t, fn(pipe))
+
+		return []redis.Cmder{
+			redis.NewCmdResult([]any{"value-1", "60000000000"}, nil),
+			redis.NewCmdResult(nil, redis.Nil),
+		}, redis.Nil
+	})
+
+	store := NewRedisCluster(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	values, err := store.GetMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1"}, values)
+	assert.Equal(t, 2, pipe.Len())
+}
+
+func TestRedisClusterDeleteWithSlidingExpirationSupport(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Del(ctx, "my-key", "gocache_sliding_{my-key}").Return(&redis.IntCmd{})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).Return([]redis.Cmder{redis.NewStringSliceResult(nil, nil)}, nil)
+
+	store := NewRedisCluster(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Delete(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRedisClusterSlidingExpirationWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+
+	store := NewRedisCluster(client)
+
+	// When
+	sliding, err := store.SlidingExpiration(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), sliding)
+	assert.False(t, store.Capabilities().SlidingExpiration)
+}
+
 func TestRedisClusterSetWhenNoOptionsGiven(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -100,6 +297,8 @@ func TestRedisClusterSetWithTags(t *testing.T) {
 	client.EXPECT().Set(ctx, cacheKey, cacheValue, time.Duration(0)).Return(&redis.StatusCmd{})
 	client.EXPECT().SAdd(ctx, "gocache_tag_tag1", "my-key").Return(&redis.IntCmd{})
 	client.EXPECT().Expire(ctx, "gocache_tag_tag1", 720*time.Hour).Return(&redis.BoolCmd{})
+	client.EXPECT().SAdd(ctx, "gocache_tag___key__:my-key", "tag1").Return(&redis.IntCmd{})
+	client.EXPECT().Expire(ctx, "gocache_tag___key__:my-key", 720*time.Hour).Return(&redis.BoolCmd{})
 
 	store := NewRedisCluster(client)
 
@@ -120,6 +319,7 @@ func TestRedisClusterDelete(t *testing.T) {
 
 	client := NewMockRedisClusterClientInterface(ctrl)
 	client.EXPECT().Del(ctx, "my-key").Return(&redis.IntCmd{})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).Return([]redis.Cmder{redis.NewStringSliceResult(nil, nil)}, nil)
 
 	store := NewRedisCluster(client)
 
@@ -130,6 +330,392 @@ func TestRedisClusterDelete(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRedisClusterGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Pipelined(ctx, gomock.Any()).Return([]redis.Cmder{
+		redis.NewStringResult("value-1", nil),
+		redis.NewStringResult("", redis.Nil),
+	}, redis.Nil)
+
+	store := NewRedisCluster(client)
+
+	// When
+	values, err := store.GetMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1"}, values)
+}
+
+func TestRedisClusterSetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClient(&redis.Options{}).Pipeline()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
+
+	store := NewRedisCluster(client)
+
+	// When
+	err := store.SetMany(ctx, map[any]any{"key-1": "value-1", "key-2": "value-2"}, lib_store.WithExpiration(5*time.Second))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 2, pipe.Len())
+}
+
+func TestRedisClusterDeleteMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClient(&redis.Options{}).Pipeline()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).Return([]redis.Cmder{redis.NewStringSliceResult(nil, nil), redis.NewStringSliceResult(nil, nil)}, nil)
+
+	store := NewRedisCluster(client)
+
+	// When
+	err := store.DeleteMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 2, pipe.Len())
+}
+
+func TestRedisClusterKeys(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	node := redis.NewClient(&redis.Options{})
+	node.AddHook(scanHook{keys: []string{"key-1", "gocache_tag_tag1", "key-2"}})
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().ForEachMaster(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context, *redis.Client) error) error {
+		return fn(ctx, node)
+	})
+
+	store := NewRedisCluster(client)
+
+	// When
+	keys := []any{}
+	for key, err := range store.Keys(ctx, lib_store.WithScanMatch("key-*")) {
+		assert.Nil(t, err)
+		keys = append(keys, key)
+	}
+
+	// Then
+	assert.Equal(t, []any{"key-1", "key-2"}, keys)
+}
+
+func TestRedisClusterKeysWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("an unexpected error occurred")
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().ForEachMaster(gomock.Any(), gomock.Any()).Return(expectedErr)
+
+	store := NewRedisCluster(client)
+
+	// When
+	var errs []error
+	for _, err := range store.Keys(ctx) {
+		errs = append(errs, err)
+	}
+
+	// Then
+	assert.Equal(t, []error{expectedErr}, errs)
+}
+
+// scanHook answers SCAN commands with the given keys in a single page
+// so that a node client can be used without a running server
+type scanHook struct {
+	keys []string
+}
+
+func (h scanHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, errors.New("unexpected dial")
+	}
+}
+
+func (h scanHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if scanCmd, ok := cmd.(*redis.ScanCmd); ok {
+			scanCmd.SetVal(h.keys, 0)
+			return nil
+		}
+		return next(ctx, cmd)
+	}
+}
+
+func (h scanHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return next
+}
+
+func TestRedisClusterIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().IncrBy(ctx, "my-counter", int64(2)).Return(redis.NewIntResult(5, nil))
+
+	store := NewRedisCluster(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), value)
+}
+
+func TestRedisClusterIncrementWithExpireAt(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClient(&redis.Options{}).TxPipeline()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().TxPipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		assert.Nil(t, fn(pipe))
+
+		return []redis.Cmder{
+			redis.NewCmdResult(nil, nil),
+			redis.NewIntResult(1, nil),
+		}, nil
+	})
+
+	store := NewRedisCluster(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 1, lib_store.WithExpireAt(time.UnixMilli(1767225600000)))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), value)
+	assert.Equal(t, 2, pipe.Len())
+}
+
+func TestRedisClusterDecrementWithExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClient(&redis.Options{}).TxPipeline()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().TxPipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		assert.Nil(t, fn(pipe))
+		assert.Equal(t, 2, pipe.Len())
+
+		return []redis.Cmder{
+			redis.NewBoolResult(true, nil),
+			redis.NewIntResult(-1, nil),
+		}, nil
+	})
+
+	store := NewRedisCluster(client)
+
+	// When
+	value, err := store.Decrement(ctx, "my-counter", 1, lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-1), value)
+}
+
+func TestRedisClusterIncrementWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, incrementScript, []string{"my-counter", "gocache_sliding_{my-counter}"},
+		int64(1), "PX", int64(60000), "60000000000").Return(redis.NewCmdResult(int64(1), nil))
+
+	store := NewRedisCluster(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 1, lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), value)
+}
+
+func TestRedisClusterSetIfNotExistsWhenKeyExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().SetNX(ctx, "my-lock", "owner", 5*time.Second).Return(redis.NewBoolResult(false, nil))
+
+	store := NewRedisCluster(client, lib_store.WithExpiration(5*time.Second))
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-lock", "owner")
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ConditionFailed{})
+}
+
+func TestRedisClusterSetIfNotExistsWithSlidingExpirationSupport(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, setIfNotExistsScript, []string{"my-lock", "gocache_sliding_{my-lock}"},
+		"owner", "PX", int64(60000), "0").Return(redis.NewCmdResult(int64(0), nil))
+
+	store := NewRedisCluster(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-lock", "owner", lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ConditionFailed{})
+}
+
+func TestRedisClusterCompareAndSwap(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Get(ctx, "my-key").Return(redis.NewStringResult("my-value", nil))
+	client.EXPECT().Eval(ctx, compareAndSwapScript, []string{"my-key"}, "my-value", "new-value", int64(0)).
+		Return(redis.NewCmdResult(int64(1), nil))
+
+	store := NewRedisCluster(client)
+
+	_, version, err := store.GetWithVersion(ctx, "my-key")
+	assert.Nil(t, err)
+
+	// When
+	err = store.CompareAndSwap(ctx, "my-key", "new-value", version)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRedisClusterCompareAndSwapWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Eval(ctx, compareAndSwapScript, []string{"my-key", "gocache_sliding_{my-key}"},
+		"my-value", "new-value", int64(60000), "60000000000").Return(redis.NewCmdResult(int64(1), nil))
+
+	store := NewRedisCluster(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.CompareAndSwap(ctx, "my-key", "new-value", lib_store.NewVersion("my-value"), lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRedisClusterTouch(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().PExpire(ctx, "my-key", time.Minute).Return(redis.NewBoolResult(true, nil))
+
+	store := NewRedisCluster(client)
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRedisClusterTouchWithSlidingExpirationSupport(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	pipe := redis.NewClient(&redis.Options{}).TxPipeline()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().TxPipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		assert.Nil(t, fn(pipe))
+
+		return []redis.Cmder{
+			redis.NewBoolResult(true, nil),
+			redis.NewIntResult(1, nil),
+		}, nil
+	})
+
+	store := NewRedisCluster(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 2, pipe.Len())
+}
+
+func TestRedisClusterTouchWithoutExpirationWhenKeyDoesNotExist(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().Persist(ctx, "my-key").Return(redis.NewBoolResult(false, nil))
+	client.EXPECT().Exists(ctx, "my-key").Return(redis.NewIntResult(0, nil))
+
+	store := NewRedisCluster(client)
+
+	// When
+	err := store.Touch(ctx, "my-key", 0)
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.NotFound{})
+}
+
 func TestRedisClusterInvalidate(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -151,6 +737,35 @@ func TestRedisClusterInvalidate(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRedisClusterInvalidateWithPattern(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	node := redis.NewClient(&redis.Options{})
+	node.AddHook(scanHook{keys: []string{"user:1:profile", "user:2:profile"}})
+
+	pipe := redis.NewClient(&redis.Options{}).Pipeline()
+
+	client := NewMockRedisClusterClientInterface(ctrl)
+	client.EXPECT().ForEachMaster(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(context.Context, *redis.Client) error) error {
+		return fn(ctx, node)
+	})
+	client.EXPECT().Pipelined(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(redis.Pipeliner) error) ([]redis.Cmder, error) {
+		return nil, fn(pipe)
+	})
+
+	store := NewRedisCluster(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePattern("user:*:profile"))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, 2, pipe.Len())
+}
+
 func TestRedisClusterClear(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -0,0 +1,86 @@
+package rediscluster
+
+import (
+	"context"
+	"time"
+
+	lib_store "github.com/eko/gocache/lib/v4/store"
+	redis "github.com/redis/go-redis/v9"
+)
+
+// tagIndexBackend stores the sets of the tag index as Redis sets
+type tagIndexBackend struct {
+	client RedisClusterClientInterface
+}
+
+// AddMembers adds the given members to the set using the SADD command and sets its time-to-live
+func (b *tagIndexBackend) AddMembers(ctx context.Context, setKey string, members []string, ttl time.Duration) error {
+	if err := b.client.SAdd(ctx, setKey, anyMembers(members)...).Err(); err != nil {
+		return mapError(err)
+	}
+
+	return mapError(b.client.Expire(ctx, setKey, ttl).Err())
+}
+
+// Members returns the members of the set using the SMEMBERS command
+func (b *tagIndexBackend) Members(ctx context.Context, setKey string) ([]string, error) {
+	members, err := b.client.SMembers(ctx, setKey).Result()
+	return members, mapError(err)
+}
+
+// RemoveMembers removes the given members from the set using the SREM command
+func (b *tagIndexBackend) RemoveMembers(ctx context.Context, setKey string, members []string) error {
+	return mapError(b.client.SRem(ctx, setKey, anyMembers(members)...).Err())
+}
+
+// DeleteSet removes the whole set
+func (b *tagIndexBackend) DeleteSet(ctx context.Context, setKey string) error {
+	return mapError(b.client.Del(ctx, setKey).Err())
+}
+
+// ManyMembers returns the members of each of the given sets using pipelined SMEMBERS commands
+func (b *tagIndexBackend) ManyMembers(ctx context.Context, setKeys []string) ([][]string, error) {
+	cmds, err := b.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, setKey := range setKeys {
+			pipe.SMembers(ctx, setKey)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	members := make([][]string, 0, len(cmds))
+	for _, cmd := range cmds {
+		if cmd, ok := cmd.(*redis.StringSliceCmd); ok {
+			members = append(members, cmd.Val())
+		}
+	}
+
+	return members, nil
+}
+
+// RemoveManyMembers removes the given members from their sets and deletes the given sets
+// using pipelined SREM and DEL commands
+func (b *tagIndexBackend) RemoveManyMembers(ctx context.Context, removals []lib_store.TagSetRemoval, deleteSetKeys []string) error {
+	_, err := b.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, removal := range removals {
+			pipe.SRem(ctx, removal.SetKey, anyMembers(removal.Members)...)
+		}
+		for _, setKey := range deleteSetKeys {
+			pipe.Del(ctx, setKey)
+		}
+		return nil
+	})
+
+	return mapError(err)
+}
+
+func anyMembers(members []string) []any {
+	result := make([]any, 0, len(members))
+	for _, member := range members {
+		result = append(result, member)
+	}
+
+	return result
+}
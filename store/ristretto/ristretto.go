@@ -4,7 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"strings"
+	"reflect"
+	"sync"
 	"time"
 
 	"github.com/dgraph-io/ristretto/v2"
@@ -30,8 +31,11 @@ type RistrettoClientInterface[K ristretto.Key, V any] interface {
 
 // RistrettoStore is a store for Ristretto (memory) library
 type RistrettoStore[K ristretto.Key, V any] struct {
-	client  RistrettoClientInterface[K, V]
-	options *lib_store.Options
+	// counterMu serializes counter updates as Ristretto has no atomic increment
+	counterMu sync.Mutex
+	client    RistrettoClientInterface[K, V]
+	options   *lib_store.Options
+	tags      *lib_store.TagIndex
 }
 
 // NewRistretto creates a new store to Ristretto (memory) library instance
@@ -39,17 +43,88 @@ func NewRistretto[K ristretto.Key, V any](
 	client RistrettoClientInterface[K, V],
 	options ...lib_store.Option,
 ) *RistrettoStore[K, V] {
-	return &RistrettoStore[K, V]{
+	s := &RistrettoStore[K, V]{
 		client:  client,
 		options: lib_store.ApplyOptions(options...),
 	}
+	s.tags = lib_store.NewTagIndex(lib_store.NewValueTagIndexBackend(s.getTagValue, s.setTagValue, s.deleteTagValue), RistrettoTagPattern)
+
+	return s
+}
+
+// errTagsNotSupported is returned when tags or sliding expirations are used with a
+// store whose keys are not strings or whose values cannot hold the metadata entries
+var errTagsNotSupported = fmt.Errorf("%w: tags and sliding expirations require a Ristretto store with string keys and []byte values", lib_store.ErrUnsupported)
+
+// metadataSupported reports whether the tag index and the sliding expirations can be
+// stored, which requires string keys and values able to hold []byte
+func (s *RistrettoStore[K, V]) metadataSupported() bool {
+	_, stringKeys := any("").(K)
+	_, bytesValues := any([]byte{}).(V)
+	return stringKeys && bytesValues
+}
+
+// getTagValue, setTagValue and deleteTagValue store the tag index and the sliding
+// expirations in Ristretto. Entries are set synchronously so that they are visible
+// by the next update.
+func (s *RistrettoStore[K, V]) getTagValue(_ context.Context, key string) ([]byte, time.Duration, error) {
+	tagKey, ok := any(key).(K)
+	if !ok {
+		return nil, 0, errTagsNotSupported
+	}
+
+	value, exists := s.client.Get(tagKey)
+	if !exists {
+		return nil, 0, lib_store.NotFoundWithCause(errors.New("value not found in Ristretto store"))
+	}
+	ttl, _ := s.client.GetTTL(tagKey)
+
+	bytes, _ := any(value).([]byte)
+	return bytes, ttl, nil
+}
+
+func (s *RistrettoStore[K, V]) setTagValue(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	tagKey, ok := any(key).(K)
+	if !ok {
+		return errTagsNotSupported
+	}
+	tagValue, ok := any(value).(V)
+	if !ok {
+		return errTagsNotSupported
+	}
+
+	if set := s.client.SetWithTTL(tagKey, tagValue, s.options.Cost, ttl); !set {
+		return fmt.Errorf("An error has occurred while setting tags on key '%v'", key)
+	}
+	s.client.Wait()
+
+	return nil
 }
 
-// Get returns data stored from a given key
-func (s *RistrettoStore[K, V]) Get(_ context.Context, key any) (any, error) {
-	var err error
+func (s *RistrettoStore[K, V]) deleteTagValue(_ context.Context, key string) error {
+	tagKey, ok := any(key).(K)
+	if !ok {
+		return errTagsNotSupported
+	}
+
+	s.client.Del(tagKey)
+	return nil
+}
 
-	value, exists := s.client.Get(key.(K))
+// Get returns data stored from a given key. When the store supports sliding expirations,
+// the expiration of the key is renewed if it has been set with one.
+func (s *RistrettoStore[K, V]) Get(ctx context.Context, key any) (any, error) {
+	ristrettoKey, err := lib_store.KeyAs[K](key)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.options.SlidingExpirationSupport {
+		value, _, err := s.getWithSlidingExpiration(ctx, key)
+		return value, err
+	}
+
+	value, exists := s.client.Get(ristrettoKey)
 	if !exists {
 		err = lib_store.NotFoundWithCause(errors.New("value not found in Ristretto store"))
 	}
@@ -59,21 +134,94 @@ func (s *RistrettoStore[K, V]) Get(_ context.Context, key any) (any, error) {
 
 // GetWithTTL returns data stored from a given key and its corresponding TTL
 func (s *RistrettoStore[K, V]) GetWithTTL(ctx context.Context, key any) (any, time.Duration, error) {
+	ristrettoKey, err := lib_store.KeyAs[K](key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if s.options.SlidingExpirationSupport {
+		value, sliding, err := s.getWithSlidingExpiration(ctx, key)
+		if err != nil {
+			return value, 0, err
+		}
+		if sliding > 0 {
+			return value, sliding, nil
+		}
+		ttl, _ := s.client.GetTTL(ristrettoKey)
+		return value, ttl, nil
+	}
+
 	value, err := s.Get(ctx, key)
 	if err != nil {
 		return value, 0, err
 	}
-	ttl, _ := s.client.GetTTL(key.(K))
+	ttl, _ := s.client.GetTTL(ristrettoKey)
 	return value, ttl, nil
 }
 
+// getWithSlidingExpiration returns data stored from a given key along with its sliding expiration.
+// Ristretto is not able to change the expiration of an item so the value is set again when it
+// has a sliding expiration, which is not atomic with regard to concurrent writes of the key.
+func (s *RistrettoStore[K, V]) getWithSlidingExpiration(ctx context.Context, key any) (any, time.Duration, error) {
+	ristrettoKey, err := lib_store.KeyAs[K](key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	value, exists := s.client.Get(ristrettoKey)
+	if !exists {
+		return value, 0, lib_store.NotFoundWithCause(errors.New("value not found in Ristretto store"))
+	}
+
+	sliding, _ := s.SlidingExpiration(ctx, key)
+	if sliding <= 0 {
+		return value, 0, nil
+	}
+
+	// Only string keys have a sliding expiration
+	if set := s.client.SetWithTTL(ristrettoKey, value, s.options.Cost, sliding); set {
+		_ = s.setTagValue(ctx, lib_store.SlidingExpirationKey(fmt.Sprint(key)), []byte(lib_store.SlidingExpirationValue(sliding)), sliding)
+	}
+
+	return value, sliding, nil
+}
+
+// setSlidingExpiration records the sliding expiration of the given key, or
+// removes the one previously recorded when the value is set without one
+func (s *RistrettoStore[K, V]) setSlidingExpiration(ctx context.Context, key any, opts *lib_store.Options) error {
+	strKey, ok := key.(string)
+	if !ok {
+		if opts.SlidingExpiration > 0 {
+			return errTagsNotSupported
+		}
+		return nil
+	}
+
+	if opts.SlidingExpiration > 0 {
+		return s.setTagValue(ctx, lib_store.SlidingExpirationKey(strKey), []byte(lib_store.SlidingExpirationValue(opts.SlidingExpiration)), opts.SlidingExpiration)
+	}
+
+	return s.deleteTagValue(ctx, lib_store.SlidingExpirationKey(strKey))
+}
+
 // Set defines data in Ristretto memory cache for given key identifier
 func (s *RistrettoStore[K, V]) Set(ctx context.Context, key any, value any, options ...lib_store.Option) error {
+	ristrettoKey, err := lib_store.KeyAs[K](key)
+	if err != nil {
+		return err
+	}
+
 	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return err
+	}
 
-	var err error
+	ristrettoValue, ok := value.(V)
+	if !ok {
+		return lib_store.InvalidValueWithCause(fmt.Errorf("value of type %T is not a %s", value, reflect.TypeFor[V]()))
+	}
 
-	if set := s.client.SetWithTTL(key.(K), value.(V), opts.Cost, opts.Expiration); !set {
+	if set := s.client.SetWithTTL(ristrettoKey, ristrettoValue, opts.Cost, opts.EffectiveExpiration()); !set {
 		err = fmt.Errorf("An error has occurred while setting value '%v' on key '%v'", value, key)
 	}
 
@@ -81,47 +229,119 @@ func (s *RistrettoStore[K, V]) Set(ctx context.Context, key any, value any, opti
 		return err
 	}
 
+	if s.options.SlidingExpirationSupport {
+		if err := s.setSlidingExpiration(ctx, key, opts); err != nil {
+			return err
+		}
+	}
+
 	if opts.SynchronousSet {
 		s.client.Wait()
 	}
 
 	if tags := opts.Tags; len(tags) > 0 {
-		s.setTags(ctx, key, tags)
+		return s.tags.Add(ctx, fmt.Sprint(key), tags, opts.TagsTTL)
 	}
 
 	return nil
 }
 
-func (s *RistrettoStore[K, V]) setTags(ctx context.Context, key any, tags []string) {
-	for _, tag := range tags {
-		tagKey := fmt.Sprintf(RistrettoTagPattern, tag)
-		cacheKeys := []string{}
+// Increment adds the given delta to the counter stored for the given key.
+// Counter updates are serialized with a mutex and synchronously set so that
+// the new value is visible by the next update. The counter keeps its
+// expiration when it already exists.
+func (s *RistrettoStore[K, V]) Increment(_ context.Context, key any, delta int64, options ...lib_store.Option) (int64, error) {
+	ristrettoKey, err := lib_store.KeyAs[K](key)
+	if err != nil {
+		return 0, err
+	}
 
-		if result, err := s.Get(ctx, tagKey); err == nil {
-			if bytes, ok := result.([]byte); ok {
-				cacheKeys = strings.Split(string(bytes), ",")
-			}
-		}
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return 0, err
+	}
 
-		alreadyInserted := false
-		for _, cacheKey := range cacheKeys {
-			if cacheKey == key.(string) {
-				alreadyInserted = true
-				break
-			}
-		}
+	s.counterMu.Lock()
+	defer s.counterMu.Unlock()
+
+	var counter int64
+	ttl := opts.EffectiveExpiration()
 
-		if !alreadyInserted {
-			cacheKeys = append(cacheKeys, key.(string))
+	if value, exists := s.client.Get(ristrettoKey); exists {
+		current, err := lib_store.CounterValue(value)
+		if err != nil {
+			return 0, err
 		}
+		counter = current
+		ttl, _ = s.client.GetTTL(ristrettoKey)
+	}
+
+	counter += delta
+
+	value, ok := any(counter).(V)
+	if !ok {
+		return 0, fmt.Errorf("%w: counter value cannot be stored as %T", lib_store.ErrInvalidValue, *new(V))
+	}
 
-		s.Set(ctx, tagKey, []byte(strings.Join(cacheKeys, ",")), lib_store.WithExpiration(720*time.Hour))
+	if set := s.client.SetWithTTL(ristrettoKey, value, opts.Cost, ttl); !set {
+		return 0, fmt.Errorf("An error has occurred while setting counter on key '%v'", key)
 	}
+	s.client.Wait()
+
+	return counter, nil
+}
+
+// Decrement subtracts the given delta from the counter stored for the given key
+func (s *RistrettoStore[K, V]) Decrement(ctx context.Context, key any, delta int64, options ...lib_store.Option) (int64, error) {
+	return s.Increment(ctx, key, -delta, options...)
+}
+
+// Touch changes the expiration of the given key.
+// Ristretto is not able to change the expiration of an item so the value is
+// set again with the default cost, which is not atomic with regard to concurrent writes.
+func (s *RistrettoStore[K, V]) Touch(_ context.Context, key any, ttl time.Duration) error {
+	ristrettoKey, err := lib_store.KeyAs[K](key)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Capabilities().CheckOptions(&lib_store.Options{Strict: s.options.Strict, Expiration: ttl}); err != nil {
+		return err
+	}
+
+	value, exists := s.client.Get(ristrettoKey)
+	if !exists {
+		return lib_store.NotFoundWithCause(errors.New("value not found in Ristretto store"))
+	}
+
+	if set := s.client.SetWithTTL(ristrettoKey, value, s.options.Cost, ttl); !set {
+		return fmt.Errorf("An error has occurred while touching key '%v'", key)
+	}
+
+	if s.options.SynchronousSet {
+		s.client.Wait()
+	}
+
+	return nil
 }
 
 // Delete removes data in Ristretto memory cache for given key identifier
-func (s *RistrettoStore[K, V]) Delete(_ context.Context, key any) error {
-	s.client.Del(key.(K))
+func (s *RistrettoStore[K, V]) Delete(ctx context.Context, key any) error {
+	ristrettoKey, err := lib_store.KeyAs[K](key)
+	if err != nil {
+		return err
+	}
+
+	s.client.Del(ristrettoKey)
+
+	// Only string keys can be tagged or have a sliding expiration
+	if key, ok := key.(string); ok {
+		if s.options.SlidingExpirationSupport {
+			_ = s.deleteTagValue(ctx, lib_store.SlidingExpirationKey(key))
+		}
+		return s.tags.Remove(ctx, key)
+	}
+
 	return nil
 }
 
@@ -129,26 +349,32 @@ func (s *RistrettoStore[K, V]) Delete(_ context.Context, key any) error {
 func (s *RistrettoStore[K, V]) Invalidate(ctx context.Context, options ...lib_store.InvalidateOption) error {
 	opts := lib_store.ApplyInvalidateOptions(options...)
 
-	if tags := opts.Tags; len(tags) > 0 {
-		for _, tag := range tags {
-			tagKey := fmt.Sprintf(RistrettoTagPattern, tag)
-			result, err := s.Get(ctx, tagKey)
-			if err != nil {
-				return nil
-			}
-
-			cacheKeys := []string{}
-			if bytes, ok := result.([]byte); ok {
-				cacheKeys = strings.Split(string(bytes), ",")
-			}
-
-			for _, cacheKey := range cacheKeys {
-				s.Delete(ctx, cacheKey)
-			}
-		}
+	// Keys cannot be invalidated by prefix or pattern as Ristretto cannot iterate over its keys
+	if opts.HasKeyFilter() {
+		return lib_store.ErrUnsupported
 	}
 
-	return nil
+	return s.tags.Invalidate(ctx, opts.Tags, func(ctx context.Context, key string) error {
+		return s.Delete(ctx, key)
+	})
+}
+
+// SlidingExpiration returns the sliding expiration given to the key, if any
+func (s *RistrettoStore[K, V]) SlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	strKey, ok := key.(string)
+	if !ok || !s.options.SlidingExpirationSupport {
+		return 0, nil
+	}
+
+	value, _, err := s.getTagValue(ctx, lib_store.SlidingExpirationKey(strKey))
+	if errors.Is(err, lib_store.NotFound{}) || errors.Is(err, errTagsNotSupported) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return lib_store.ParseSlidingExpiration(value)
 }
 
 // Clear resets all data in the store
@@ -157,6 +383,20 @@ func (s *RistrettoStore[K, V]) Clear(_ context.Context) error {
 	return nil
 }
 
+// Capabilities returns the features supported by Ristretto. Tags and sliding
+// expirations are only supported with string keys and []byte values.
+func (s *RistrettoStore[K, V]) Capabilities() lib_store.Capabilities {
+	return lib_store.Capabilities{
+		TTLPrecision:      time.Nanosecond,
+		Tags:              s.metadataSupported(),
+		AtomicCounters:    true,
+		Cost:              true,
+		SynchronousSet:    true,
+		SlidingExpiration: s.options.SlidingExpirationSupport && s.metadataSupported(),
+		KeyTypes:          []reflect.Type{reflect.TypeFor[K]()},
+	}
+}
+
 // GetType returns the store type
 func (s *RistrettoStore[K, V]) GetType() string {
 	return RistrettoType
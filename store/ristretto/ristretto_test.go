@@ -3,11 +3,15 @@ package ristretto
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
+	"github.com/dgraph-io/ristretto/v2"
 	lib_store "github.com/eko/gocache/lib/v4/store"
+	"github.com/eko/gocache/lib/v4/store/storetest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestNewRistretto(t *testing.T) {
@@ -123,6 +127,63 @@ func TestRistrettoSet(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRistrettoSetWithExpirationJitter(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	jittered := mock.MatchedBy(func(ttl time.Duration) bool {
+		return ttl >= 45*time.Second && ttl <= time.Minute
+	})
+
+	client := NewMockRistrettoClientInterface[string, string](t)
+	client.EXPECT().SetWithTTL("my-key", "my-cache-value", int64(0), jittered).Return(true)
+
+	store := NewRistretto(client, lib_store.WithExpirationJitter(0.25))
+
+	// When
+	err := store.Set(ctx, "my-key", "my-cache-value", lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRistrettoSetWhenStrict(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := "my-cache-value"
+
+	client := NewMockRistrettoClientInterface[string, string](t)
+	client.EXPECT().SetWithTTL(cacheKey, cacheValue, int64(4), 0*time.Second).Return(true)
+	client.EXPECT().Wait()
+
+	store := NewRistretto(client, lib_store.WithStrictOptions())
+
+	// When
+	err := store.Set(ctx, cacheKey, cacheValue, lib_store.WithCost(4), lib_store.WithSynchronousSet())
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRistrettoCapabilities(t *testing.T) {
+	// Given
+	stringStore := NewRistretto(NewMockRistrettoClientInterface[string, string](t), lib_store.WithSlidingExpirationSupport())
+	bytesStore := NewRistretto(NewMockRistrettoClientInterface[string, []byte](t), lib_store.WithSlidingExpirationSupport())
+	intKeysStore := NewRistretto(NewMockRistrettoClientInterface[int, []byte](t), lib_store.WithSlidingExpirationSupport())
+
+	// When - Then
+	assert.False(t, stringStore.Capabilities().Tags)
+	assert.False(t, stringStore.Capabilities().SlidingExpiration)
+	assert.True(t, bytesStore.Capabilities().Tags)
+	assert.True(t, bytesStore.Capabilities().SlidingExpiration)
+	assert.False(t, intKeysStore.Capabilities().Tags)
+	assert.False(t, intKeysStore.Capabilities().SlidingExpiration)
+	assert.Equal(t, []reflect.Type{reflect.TypeFor[int]()}, intKeysStore.Capabilities().KeyTypes)
+	assert.False(t, NewRistretto(NewMockRistrettoClientInterface[string, []byte](t)).Capabilities().SlidingExpiration)
+}
+
 func TestRistrettoSetWhenNoOptionsGiven(t *testing.T) {
 	// Given
 	ctx := context.Background()
@@ -190,8 +251,11 @@ func TestRistrettoSetWithTags(t *testing.T) {
 
 	client := NewMockRistrettoClientInterface[string, []byte](t)
 	client.EXPECT().SetWithTTL(cacheKey, cacheValue, int64(0), 0*time.Second).Return(true)
-	client.EXPECT().Get("gocache_tag_tag1").Return(nil, true)
-	client.EXPECT().SetWithTTL("gocache_tag_tag1", []byte(",my-key"), int64(0), 720*time.Hour).Return(true)
+	client.EXPECT().Get("gocache_tag_tag1").Return(nil, false)
+	client.EXPECT().SetWithTTL("gocache_tag_tag1", []byte("my-key"), int64(0), lib_store.DefaultTagsTTL).Return(true)
+	client.EXPECT().Get("gocache_tag___key__:my-key").Return(nil, false)
+	client.EXPECT().SetWithTTL("gocache_tag___key__:my-key", []byte("tag1"), int64(0), lib_store.DefaultTagsTTL).Return(true)
+	client.EXPECT().Wait().Twice()
 
 	store := NewRistretto(client)
 
@@ -212,7 +276,11 @@ func TestRistrettoSetWithTagsWhenAlreadyInserted(t *testing.T) {
 	client := NewMockRistrettoClientInterface[string, []byte](t)
 	client.EXPECT().SetWithTTL(cacheKey, cacheValue, int64(0), 0*time.Second).Return(true)
 	client.EXPECT().Get("gocache_tag_tag1").Return([]byte("my-key,a-second-key"), true)
-	client.EXPECT().SetWithTTL("gocache_tag_tag1", []byte("my-key,a-second-key"), int64(0), 720*time.Hour).Return(true)
+	client.EXPECT().GetTTL("gocache_tag_tag1").Return(time.Hour, true)
+	client.EXPECT().SetWithTTL("gocache_tag_tag1", []byte("my-key,a-second-key"), int64(0), lib_store.DefaultTagsTTL).Return(true)
+	client.EXPECT().Get("gocache_tag___key__:my-key").Return(nil, false)
+	client.EXPECT().SetWithTTL("gocache_tag___key__:my-key", []byte("tag1"), int64(0), lib_store.DefaultTagsTTL).Return(true)
+	client.EXPECT().Wait().Twice()
 
 	store := NewRistretto(client)
 
@@ -223,6 +291,161 @@ func TestRistrettoSetWithTagsWhenAlreadyInserted(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRistrettoSetWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := []byte("my-cache-value")
+
+	client := NewMockRistrettoClientInterface[string, []byte](t)
+	client.EXPECT().SetWithTTL(cacheKey, cacheValue, int64(0), time.Minute).Return(true)
+	client.EXPECT().SetWithTTL("gocache_sliding_my-key", []byte("60000000000"), int64(0), time.Minute).Return(true)
+	client.EXPECT().Wait()
+
+	store := NewRistretto(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Set(ctx, cacheKey, cacheValue, lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRistrettoSetWithSlidingExpirationWhenValuesAreNotBytes(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := "my-cache-value"
+
+	client := NewMockRistrettoClientInterface[string, string](t)
+	client.EXPECT().SetWithTTL(cacheKey, cacheValue, int64(0), time.Minute).Return(true)
+
+	store := NewRistretto(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Set(ctx, cacheKey, cacheValue, lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ErrUnsupported)
+}
+
+func TestRistrettoGetWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	cacheKey := "my-key"
+	cacheValue := []byte("my-cache-value")
+
+	client := NewMockRistrettoClientInterface[string, []byte](t)
+	client.EXPECT().Get(cacheKey).Return(cacheValue, true)
+	client.EXPECT().Get("gocache_sliding_my-key").Return([]byte("60000000000"), true)
+	client.EXPECT().GetTTL("gocache_sliding_my-key").Return(time.Second, true)
+	client.EXPECT().SetWithTTL(cacheKey, cacheValue, int64(0), time.Minute).Return(true)
+	client.EXPECT().SetWithTTL("gocache_sliding_my-key", []byte("60000000000"), int64(0), time.Minute).Return(true)
+	client.EXPECT().Wait()
+
+	store := NewRistretto(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	value, ttl, err := store.GetWithTTL(ctx, cacheKey)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, cacheValue, value)
+	assert.Equal(t, time.Minute, ttl)
+}
+
+func TestRistrettoIncrement(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockRistrettoClientInterface[string, any](t)
+	client.EXPECT().Get("my-counter").Return(int64(3), true)
+	client.EXPECT().GetTTL("my-counter").Return(30*time.Second, true)
+	client.EXPECT().SetWithTTL("my-counter", int64(5), int64(0), 30*time.Second).Return(true)
+	client.EXPECT().Wait()
+
+	store := NewRistretto(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2, lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), value)
+}
+
+func TestRistrettoDecrementWhenCounterDoesNotExist(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockRistrettoClientInterface[string, int64](t)
+	client.EXPECT().Get("my-counter").Return(int64(0), false)
+	client.EXPECT().SetWithTTL("my-counter", int64(-2), int64(0), time.Minute).Return(true)
+	client.EXPECT().Wait()
+
+	store := NewRistretto(client)
+
+	// When
+	value, err := store.Decrement(ctx, "my-counter", 2, lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(-2), value)
+}
+
+func TestRistrettoTouch(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockRistrettoClientInterface[string, any](t)
+	client.EXPECT().Get("my-key").Return("my-value", true)
+	client.EXPECT().SetWithTTL("my-key", "my-value", int64(4), time.Minute).Return(true)
+	client.EXPECT().Wait()
+
+	store := NewRistretto(client, lib_store.WithCost(4), lib_store.WithSynchronousSet())
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRistrettoTouchWhenKeyDoesNotExist(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockRistrettoClientInterface[string, any](t)
+	client.EXPECT().Get("my-key").Return(nil, false)
+
+	store := NewRistretto(client)
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.NotFound{})
+}
+func TestRistrettoIncrementWhenValueCannotBeStored(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockRistrettoClientInterface[string, []byte](t)
+	client.EXPECT().Get("my-counter").Return(nil, false)
+
+	store := NewRistretto(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 1)
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ErrInvalidValue)
+	assert.Equal(t, int64(0), value)
+}
+
 func TestRistrettoDelete(t *testing.T) {
 	// Given
 	ctx := context.Background()
@@ -231,6 +454,7 @@ func TestRistrettoDelete(t *testing.T) {
 
 	client := NewMockRistrettoClientInterface[string, []byte](t)
 	client.EXPECT().Del(cacheKey)
+	client.EXPECT().Get("gocache_tag___key__:my-key").Return(nil, false)
 
 	store := NewRistretto(client)
 
@@ -249,8 +473,12 @@ func TestRistrettoInvalidate(t *testing.T) {
 
 	client := NewMockRistrettoClientInterface[string, []byte](t)
 	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, true)
+	client.EXPECT().GetTTL("gocache_tag_tag1").Return(time.Hour, true)
 	client.EXPECT().Del("a23fdf987h2svc23")
+	client.EXPECT().Get("gocache_tag___key__:a23fdf987h2svc23").Return(nil, false)
 	client.EXPECT().Del("jHG2372x38hf74")
+	client.EXPECT().Get("gocache_tag___key__:jHG2372x38hf74").Return(nil, false)
+	client.EXPECT().Del("gocache_tag_tag1")
 
 	store := NewRistretto(client)
 
@@ -269,6 +497,7 @@ func TestRistrettoInvalidateWhenError(t *testing.T) {
 
 	client := NewMockRistrettoClientInterface[string, []byte](t)
 	client.EXPECT().Get("gocache_tag_tag1").Return(cacheKeys, false)
+	client.EXPECT().Del("gocache_tag_tag1")
 
 	store := NewRistretto(client)
 
@@ -279,6 +508,21 @@ func TestRistrettoInvalidateWhenError(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRistrettoInvalidateWithPattern(t *testing.T) {
+	// Given
+	ctx := context.Background()
+
+	client := NewMockRistrettoClientInterface[string, []byte](t)
+
+	store := NewRistretto(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePattern("user:*"))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ErrUnsupported)
+}
+
 func TestRistrettoClear(t *testing.T) {
 	// Given
 	ctx := context.Background()
@@ -304,3 +548,18 @@ func TestRistrettoGetType(t *testing.T) {
 	// When - Then
 	assert.Equal(t, RistrettoType, store.GetType())
 }
+
+func TestRistrettoTagIndexBackend(t *testing.T) {
+	storetest.TagIndexBackend(t, func(t *testing.T) lib_store.TagIndexBackend {
+		client, err := ristretto.NewCache(&ristretto.Config[string, []byte]{
+			NumCounters: 1000,
+			MaxCost:     1 << 20,
+			BufferItems: 64,
+		})
+		assert.Nil(t, err)
+		t.Cleanup(client.Close)
+
+		s := NewRistretto[string, []byte](client, lib_store.WithCost(1))
+		return lib_store.NewValueTagIndexBackend(s.getTagValue, s.setTagValue, s.deleteTagValue)
+	})
+}
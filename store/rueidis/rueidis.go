@@ -2,7 +2,13 @@ package rueidis
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	lib_store "github.com/eko/gocache/lib/v4/store"
@@ -19,10 +25,97 @@ const (
 	defaultClientSideCacheExpiration = 10 * time.Second
 )
 
+// invalidateBatchSize is the number of keys scanned and unlinked at once when
+// invalidating keys by prefix or pattern
+const invalidateBatchSize = 100
+
+// compareAndSwapScript sets the value of KEYS[1] to ARGV[2] with an optional
+// expiration in milliseconds given by ARGV[3] only if its current value is ARGV[1].
+// When given, KEYS[2] records the sliding expiration ARGV[4] of the new value as
+// nanoseconds, or is removed when it is zero.
+const compareAndSwapScript = `
+if redis.call("GET", KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+if tonumber(ARGV[3]) > 0 then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+else
+	redis.call("SET", KEYS[1], ARGV[2])
+end
+if KEYS[2] then
+	if tonumber(ARGV[4]) > 0 then
+		redis.call("SET", KEYS[2], ARGV[4], "PX", math.floor(tonumber(ARGV[4]) / 1000000))
+	else
+		redis.call("DEL", KEYS[2])
+	end
+end
+return 1
+`
+
+// setIfNotExistsScript sets the value of KEYS[1] to ARGV[1] only if it does not exist,
+// with an optional expiration given by the SET argument ARGV[2] and its value ARGV[3],
+// zero meaning none. KEYS[2] then records the sliding expiration ARGV[4] of the value as
+// nanoseconds, or is removed when it is zero.
+const setIfNotExistsScript = `
+local set
+if tonumber(ARGV[3]) > 0 then
+	set = redis.call("SET", KEYS[1], ARGV[1], "NX", ARGV[2], ARGV[3])
+else
+	set = redis.call("SET", KEYS[1], ARGV[1], "NX")
+end
+if not set then
+	return 0
+end
+if tonumber(ARGV[4]) > 0 then
+	redis.call("SET", KEYS[2], ARGV[4], "PX", math.floor(tonumber(ARGV[4]) / 1000000))
+else
+	redis.call("DEL", KEYS[2])
+end
+return 1
+`
+
+// incrementScript adds ARGV[1] to the counter KEYS[1]. When the counter does not exist,
+// it is created with an optional expiration given by the SET argument ARGV[2] and its
+// value ARGV[3], zero meaning none, and KEYS[2] records the sliding expiration ARGV[4]
+// of the counter as nanoseconds, or is removed when it is zero.
+const incrementScript = `
+local created = redis.call("EXISTS", KEYS[1]) == 0
+if created and tonumber(ARGV[3]) > 0 then
+	redis.call("SET", KEYS[1], 0, ARGV[2], ARGV[3])
+end
+local counter = redis.call("INCRBY", KEYS[1], ARGV[1])
+if created then
+	if tonumber(ARGV[4]) > 0 then
+		redis.call("SET", KEYS[2], ARGV[4], "PX", math.floor(tonumber(ARGV[4]) / 1000000))
+	else
+		redis.call("DEL", KEYS[2])
+	end
+end
+return counter
+`
+
+// slidingGetScript returns the value of KEYS[1] along with its sliding expiration, held by
+// KEYS[2] as nanoseconds, renewing the expiration of both keys when there is one
+const slidingGetScript = `
+local value = redis.call("GET", KEYS[1])
+if not value then
+	return false
+end
+local sliding = redis.call("GET", KEYS[2])
+if not sliding then
+	return {value}
+end
+local expiration = math.floor(tonumber(sliding) / 1000000)
+redis.call("PEXPIRE", KEYS[1], expiration)
+redis.call("PEXPIRE", KEYS[2], expiration)
+return {value, sliding}
+`
+
 // RueidisStore is a store for Redis
 type RueidisStore struct {
 	client  rueidis.Client
 	options *lib_store.Options
+	tags    *lib_store.TagIndex
 }
 
 // NewRueidis creates a new store to Redis instance(s)
@@ -37,23 +130,51 @@ func NewRueidis(client rueidis.Client, options ...lib_store.Option) *RueidisStor
 	return &RueidisStore{
 		client:  client,
 		options: appliedOptions,
+		tags:    lib_store.NewTagIndex(&tagIndexBackend{client: client}, RueidisTagPattern),
 	}
 }
 
-// Get returns data stored from a given key
+// Get returns data stored from a given key. When the store supports sliding expirations,
+// the expiration of the key is renewed if it has been set with one.
 func (s *RueidisStore) Get(ctx context.Context, key any) (any, error) {
-	cmd := s.client.B().Get().Key(key.(string)).Cache()
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.options.SlidingExpirationSupport {
+		str, _, err := s.getWithSlidingExpiration(ctx, redisKey)
+		return str, err
+	}
+
+	cmd := s.client.B().Get().Key(redisKey).Cache()
 	res := s.client.DoCache(ctx, cmd, s.options.ClientSideCacheExpiration)
 	str, err := res.ToString()
-	if rueidis.IsRedisNil(err) {
-		err = lib_store.NotFoundWithCause(err)
-	}
-	return str, err
+	return str, mapError(err)
 }
 
-// GetWithTTL returns data stored from a given key and its corresponding TTL
+// GetWithTTL returns data stored from a given key and its corresponding TTL.
+// The sliding expiration of the key is returned as TTL when it has one.
 func (s *RueidisStore) GetWithTTL(ctx context.Context, key any) (any, time.Duration, error) {
-	cmd := s.client.B().Get().Key(key.(string)).Cache()
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if s.options.SlidingExpirationSupport {
+		str, sliding, err := s.getWithSlidingExpiration(ctx, redisKey)
+		if err != nil {
+			return nil, 0, err
+		}
+		if sliding > 0 {
+			return str, sliding, nil
+		}
+
+		ttl, _ := s.GetTTL(ctx, key)
+		return str, ttl, nil
+	}
+
+	cmd := s.client.B().Get().Key(redisKey).Cache()
 	res := s.client.DoCache(ctx, cmd, s.options.ClientSideCacheExpiration)
 	str, err := res.ToString()
 	if rueidis.IsRedisNil(err) {
@@ -61,83 +182,781 @@ func (s *RueidisStore) GetWithTTL(ctx context.Context, key any) (any, time.Durat
 	}
 
 	ttl, _ := s.GetTTL(ctx, key)
-	return str, ttl, err
+	return str, ttl, mapError(err)
+}
+
+// getWithSlidingExpiration reads the given key along with its sliding expiration using a
+// script which renews the expiration of both keys atomically. Client side caching is only
+// used for the keys which cannot have a sliding expiration.
+func (s *RueidisStore) getWithSlidingExpiration(ctx context.Context, key string) (string, time.Duration, error) {
+	var res rueidis.RedisResult
+	if cmd, ok := s.slidingGetCommand(key); ok {
+		res = s.client.Do(ctx, cmd)
+	} else {
+		res = s.client.DoCache(ctx, s.client.B().Get().Key(key).Cache(), s.options.ClientSideCacheExpiration)
+	}
+
+	str, sliding, err := slidingGetResult(res)
+	return str, sliding, mapError(err)
+}
+
+// slidingGetCommand builds the command running the sliding get script on the given key.
+// False is returned when the key cannot have a sliding expiration.
+func (s *RueidisStore) slidingGetCommand(key string) (rueidis.Completed, bool) {
+	slidingKey, ok := lib_store.SlidingExpirationSlotKey(key)
+	if !ok {
+		return rueidis.Completed{}, false
+	}
+
+	return s.client.B().Eval().Script(slidingGetScript).Numkeys(2).Key(key, slidingKey).Build(), true
+}
+
+// slidingGetResult returns the value and the sliding expiration read by the sliding get
+// script, or the value read by a GET command
+func slidingGetResult(res rueidis.RedisResult) (string, time.Duration, error) {
+	message, err := res.ToMessage()
+	if err != nil {
+		return "", 0, err
+	}
+	if !message.IsArray() {
+		str, err := message.ToString()
+		return str, 0, err
+	}
+
+	values, err := message.AsStrSlice()
+	if err != nil {
+		return "", 0, err
+	}
+	if len(values) < 2 {
+		return values[0], 0, nil
+	}
+
+	sliding, err := lib_store.ParseSlidingExpiration(values[1])
+	if err != nil {
+		return "", 0, err
+	}
+
+	return values[0], sliding, nil
 }
 
 func (s *RueidisStore) GetTTL(ctx context.Context, key any) (time.Duration, error) {
-	cmd := s.client.B().Ttl().Key(key.(string)).Cache()
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return 0, err
+	}
+
+	cmd := s.client.B().Ttl().Key(redisKey).Cache()
 	res := s.client.DoCache(ctx, cmd, s.options.ClientSideCacheExpiration)
 	castResult, err := res.ToInt64()
-	if rueidis.IsRedisNil(err) {
-		err = lib_store.NotFoundWithCause(err)
-	}
 
-	return time.Duration(castResult) * time.Second, err
+	return time.Duration(castResult) * time.Second, mapError(err)
 }
 
-// Set defines data in Redis for given key identifier
+// Set defines data in Redis for given key identifier. When the store supports sliding
+// expirations, the value and its sliding expiration are written in a transaction.
 func (s *RueidisStore) Set(ctx context.Context, key any, value any, options ...lib_store.Option) error {
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
 	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
-	ttl := int64(opts.Expiration.Seconds())
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return err
+	}
+
+	if s.options.SlidingExpirationSupport {
+		err = s.setWithSlidingExpiration(ctx, redisKey, value, opts)
+	} else {
+		err = mapError(s.client.Do(ctx, s.setCommand(redisKey, value, opts)).Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.tags.Add(ctx, redisKey, opts.Tags, opts.TagsTTL)
+}
+
+// setWithSlidingExpiration writes the value of the given key along with its sliding
+// expiration, or removes the one it had when it is set without sliding expiration.
+// Both keys belong to the same hash slot so that they are written in a transaction.
+func (s *RueidisStore) setWithSlidingExpiration(ctx context.Context, key string, value any, opts *lib_store.Options) error {
+	slidingKey, ok := lib_store.SlidingExpirationSlotKey(key)
+	if !ok {
+		if opts.SlidingExpiration > 0 {
+			return fmt.Errorf("%w: key %q cannot have a sliding expiration", lib_store.ErrUnsupported, key)
+		}
+		return mapError(s.client.Do(ctx, s.setCommand(key, value, opts)).Error())
+	}
+
+	sliding := s.client.B().Del().Key(slidingKey).Build()
+	if opts.SlidingExpiration > 0 {
+		sliding = s.client.B().Set().Key(slidingKey).Value(lib_store.SlidingExpirationValue(opts.SlidingExpiration)).
+			PxMilliseconds(opts.SlidingExpiration.Milliseconds()).Build()
+	}
+
+	results := s.client.DoMulti(ctx,
+		s.client.B().Multi().Build(),
+		s.setCommand(key, value, opts),
+		sliding,
+		s.client.B().Exec().Build(),
+	)
+	for _, res := range results {
+		if err := res.Error(); err != nil {
+			return mapError(err)
+		}
+	}
+
+	replies, err := results[len(results)-1].ToArray()
+	if err != nil {
+		return mapError(err)
+	}
+	for _, reply := range replies {
+		if err := reply.Error(); err != nil {
+			return mapError(err)
+		}
+	}
+
+	return nil
+}
+
+// slidingKey returns the key recording the sliding expiration of the given key, to be
+// updated along with it, or false when the store does not support sliding expirations
+// or the key cannot have one. An error is returned when the options give a sliding
+// expiration to such a key.
+func (s *RueidisStore) slidingKey(key string, opts *lib_store.Options) (string, bool, error) {
+	if !s.options.SlidingExpirationSupport {
+		return "", false, nil
+	}
+
+	slidingKey, ok := lib_store.SlidingExpirationSlotKey(key)
+	if !ok && opts.SlidingExpiration > 0 {
+		return "", false, fmt.Errorf("%w: key %q cannot have a sliding expiration", lib_store.ErrUnsupported, key)
+	}
+
+	return slidingKey, ok, nil
+}
+
+// expirationArgs returns the SET argument and its value giving the expiration of the
+// options to a script: their deadline with PXAT, or their expiration in milliseconds
+// with PX, zero meaning no expiration
+func expirationArgs(opts *lib_store.Options) (string, string) {
+	if deadline := opts.EffectiveExpireAt(); !deadline.IsZero() {
+		return "PXAT", strconv.FormatInt(deadline.UnixMilli(), 10)
+	}
+
+	return "PX", strconv.FormatInt(opts.EffectiveExpiration().Milliseconds(), 10)
+}
+
+// setCommand builds the SET command of the given key and value. A deadline is given
+// to Redis with PXAT so that the expiration is resolved when the value is written.
+func (s *RueidisStore) setCommand(key string, value any, opts *lib_store.Options) rueidis.Completed {
 	var cmd rueidis.Completed
 	switch value.(type) {
-	case string:
-		cmd = s.client.B().Set().Key(key.(string)).Value(value.(string)).ExSeconds(ttl).Build()
+	case string, []byte:
+		set := s.client.B().Set().Key(key).Value(stringValue(value))
+		if deadline := opts.EffectiveExpireAt(); !deadline.IsZero() {
+			cmd = set.PxatMillisecondsTimestamp(deadline.UnixMilli()).Build()
+		} else {
+			cmd = set.ExSeconds(int64(opts.EffectiveExpirationWithPrecision(time.Second).Seconds())).Build()
+		}
+	}
+	return cmd
+}
+
+// GetMany returns data stored from the given keys, using client side caching.
+// Their sliding expirations are renewed when the store supports them.
+// Keys that are not found are omitted from the returned map.
+func (s *RueidisStore) GetMany(ctx context.Context, keys []any) (map[any]any, error) {
+	redisKeys, err := lib_store.KeysAs[string](keys)
+	if err != nil {
+		return nil, err
+	}
+	if s.options.SlidingExpirationSupport {
+		return s.getManyWithSlidingExpiration(ctx, keys, redisKeys)
+	}
+
+	messages, err := rueidis.MGetCache(s.client, ctx, s.options.ClientSideCacheExpiration, redisKeys)
+	if err != nil {
+		return nil, mapError(err)
+	}
+
+	values := make(map[any]any, len(messages))
+	for i, key := range keys {
+		message, ok := messages[redisKeys[i]]
+		if !ok {
+			continue
+		}
+
+		str, err := message.ToString()
+		if rueidis.IsRedisNil(err) {
+			continue
+		}
+		if err != nil {
+			return nil, mapError(err)
+		}
+		values[key] = str
+	}
+
+	return values, nil
+}
+
+// getManyWithSlidingExpiration reads the given keys along with their sliding expirations
+// in a single round trip, renewing their expirations as Get does
+func (s *RueidisStore) getManyWithSlidingExpiration(ctx context.Context, keys []any, redisKeys []string) (map[any]any, error) {
+	cmds := make(rueidis.Commands, 0, len(keys))
+	for _, redisKey := range redisKeys {
+		cmd, ok := s.slidingGetCommand(redisKey)
+		if !ok {
+			cmd = s.client.B().Get().Key(redisKey).Build()
+		}
+		cmds = append(cmds, cmd)
+	}
+
+	values := make(map[any]any, len(keys))
+	for i, res := range s.client.DoMulti(ctx, cmds...) {
+		str, _, err := slidingGetResult(res)
+		if rueidis.IsRedisNil(err) {
+			continue
+		}
+		if err != nil {
+			return nil, mapError(err)
+		}
+		values[keys[i]] = str
+	}
+
+	return values, nil
+}
+
+// SetMany defines data in Redis for the given items using a single round trip, or one
+// transaction per item along with their sliding expirations when the store supports them
+func (s *RueidisStore) SetMany(ctx context.Context, items map[any]any, options ...lib_store.Option) error {
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return err
+	}
+
+	redisKeyItems := make(map[string]any, len(items))
+	for key, value := range items {
+		redisKey, err := lib_store.KeyAs[string](key)
+		if err != nil {
+			return err
+		}
+		redisKeyItems[redisKey] = value
+	}
+
+	if s.options.SlidingExpirationSupport {
+		for redisKey, value := range redisKeyItems {
+			if err := s.setWithSlidingExpiration(ctx, redisKey, value, opts); err != nil {
+				return err
+			}
+		}
+	} else {
+		cmds := make(rueidis.Commands, 0, len(items))
+		for redisKey, value := range redisKeyItems {
+			cmds = append(cmds, s.setCommand(redisKey, value, opts))
+		}
+
+		for _, res := range s.client.DoMulti(ctx, cmds...) {
+			if err := res.Error(); err != nil {
+				return mapError(err)
+			}
+		}
+	}
+
+	errs := []error{}
+	for redisKey := range redisKeyItems {
+		if err := s.tags.Add(ctx, redisKey, opts.Tags, opts.TagsTTL); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// DeleteMany removes data from Redis for the given keys
+func (s *RueidisStore) DeleteMany(ctx context.Context, keys []any) error {
+	redisKeys, err := lib_store.KeysAs[string](keys)
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+	for _, err := range rueidis.MDel(s.client, ctx, s.withSlidingExpirationKeys(redisKeys...)) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	return s.tags.Remove(ctx, redisKeys...)
+}
+
+// Keys iterates over the keys stored in Redis using the SCAN command.
+// When connected to a cluster, every primary node is scanned in turn.
+// Tag and sliding expiration keys are not returned.
+func (s *RueidisStore) Keys(ctx context.Context, options ...lib_store.ScanOption) iter.Seq2[any, error] {
+	opts := lib_store.ApplyScanOptions(options...)
+	return func(yield func(any, error) bool) {
+		nodes := s.client.Nodes()
+
+		for _, addr := range slices.Sorted(maps.Keys(nodes)) {
+			node := nodes[addr]
+
+			role, err := node.Do(ctx, node.B().Role().Build()).ToArray()
+			if err != nil {
+				yield(nil, mapError(err))
+				return
+			}
+			if len(role) == 0 {
+				continue
+			}
+			if name, _ := role[0].ToString(); name != "master" {
+				continue
+			}
+
+			var cursor uint64
+			for {
+				entry, err := node.Do(ctx, s.scanCommand(node, cursor, opts)).AsScanEntry()
+				if err != nil {
+					yield(nil, mapError(err))
+					return
+				}
+
+				for _, key := range entry.Elements {
+					if s.tags.IsIndexKey(key) || lib_store.IsSlidingExpirationKey(key) {
+						continue
+					}
+					if !yield(key, nil) {
+						return
+					}
+				}
+
+				if entry.Cursor == 0 {
+					break
+				}
+				cursor = entry.Cursor
+			}
+		}
+	}
+}
+
+func (s *RueidisStore) scanCommand(client rueidis.Client, cursor uint64, opts *lib_store.ScanOptions) rueidis.Completed {
+	cmd := client.B().Scan().Cursor(cursor)
+
+	switch {
+	case opts.Match != "" && opts.Count > 0:
+		return cmd.Match(opts.Match).Count(opts.Count).Build()
+	case opts.Match != "":
+		return cmd.Match(opts.Match).Build()
+	case opts.Count > 0:
+		return cmd.Count(opts.Count).Build()
+	}
+
+	return cmd.Build()
+}
 
+// Increment atomically adds the given delta to the counter stored for the given key
+// using the INCRBY command. When an expiration is given, the counter is first created
+// with it using SET NX so that the expiration of an existing counter is kept. When the
+// store supports sliding expirations, a script also records the sliding expiration of
+// the counter it creates, or removes the one left by a previous value.
+func (s *RueidisStore) Increment(ctx context.Context, key any, delta int64, options ...lib_store.Option) (int64, error) {
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return 0, err
+	}
+
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return 0, err
+	}
+
+	slidingKey, sliding, err := s.slidingKey(redisKey, opts)
+	if err != nil {
+		return 0, err
+	}
+	if sliding {
+		mode, expiration := expirationArgs(opts)
+		cmd := s.client.B().Eval().Script(incrementScript).Numkeys(2).Key(redisKey, slidingKey).
+			Arg(strconv.FormatInt(delta, 10), mode, expiration, lib_store.SlidingExpirationValue(opts.SlidingExpiration)).Build()
+		counter, err := s.client.Do(ctx, cmd).AsInt64()
+		return counter, mapError(err)
+	}
+
+	incr := s.client.B().Incrby().Key(redisKey).Increment(delta).Build()
+
+	if opts.Expiration <= 0 && opts.ExpireAt.IsZero() {
+		counter, err := s.client.Do(ctx, incr).AsInt64()
+		return counter, mapError(err)
+	}
+
+	results := s.client.DoMulti(ctx, s.setNxCommand(redisKey, "0", opts), incr)
+	if err := results[0].Error(); err != nil && !rueidis.IsRedisNil(err) {
+		return 0, mapError(err)
+	}
+
+	counter, err := results[1].AsInt64()
+	return counter, mapError(err)
+}
+
+// Decrement atomically subtracts the given delta from the counter stored for the given key
+func (s *RueidisStore) Decrement(ctx context.Context, key any, delta int64, options ...lib_store.Option) (int64, error) {
+	return s.Increment(ctx, key, -delta, options...)
+}
+
+// SetIfNotExists defines data in Redis for given key identifier only if
+// it does not exist yet, using the SET NX command. When the store supports sliding
+// expirations, a script also records the sliding expiration of the value it sets, or
+// removes the one left by a previous value.
+func (s *RueidisStore) SetIfNotExists(ctx context.Context, key any, value any, options ...lib_store.Option) error {
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return err
+	}
+
+	slidingKey, sliding, err := s.slidingKey(redisKey, opts)
+	if err != nil {
+		return err
+	}
+
+	if sliding {
+		err = s.setIfNotExistsWithSlidingExpiration(ctx, redisKey, slidingKey, value, opts)
+	} else {
+		err = s.client.Do(ctx, s.setNxCommand(redisKey, stringValue(value), opts)).Error()
+	}
+	if rueidis.IsRedisNil(err) {
+		return lib_store.ConditionFailedWithCause(err)
+	}
+	if err != nil {
+		return mapError(err)
+	}
+
+	return s.tags.Add(ctx, redisKey, opts.Tags, opts.TagsTTL)
+}
+
+// setIfNotExistsWithSlidingExpiration sets the value of the given key if it does not exist
+// yet along with its sliding expiration, or removes the one left by a previous value, using
+// a script. Nil is returned as error when the key exists, as for the SET NX command.
+func (s *RueidisStore) setIfNotExistsWithSlidingExpiration(ctx context.Context, key, slidingKey string, value any, opts *lib_store.Options) error {
+	mode, expiration := expirationArgs(opts)
+	cmd := s.client.B().Eval().Script(setIfNotExistsScript).Numkeys(2).Key(key, slidingKey).
+		Arg(stringValue(value), mode, expiration, lib_store.SlidingExpirationValue(opts.SlidingExpiration)).Build()
+
+	set, err := s.client.Do(ctx, cmd).AsInt64()
+	if err != nil {
+		return err
+	}
+	if set == 0 {
+		return rueidis.Nil
+	}
+
+	return nil
+}
+
+// setNxCommand builds the SET NX command of the given key and value, giving it the
+// deadline of the options with PXAT, or their expiration
+func (s *RueidisStore) setNxCommand(key string, value string, opts *lib_store.Options) rueidis.Completed {
+	set := s.client.B().Set().Key(key).Value(value).Nx()
+	if deadline := opts.EffectiveExpireAt(); !deadline.IsZero() {
+		return set.PxatMillisecondsTimestamp(deadline.UnixMilli()).Build()
+	}
+	if opts.Expiration > 0 {
+		return set.PxMilliseconds(opts.EffectiveExpiration().Milliseconds()).Build()
+	}
+
+	return set.Build()
+}
+
+// GetWithVersion returns data stored from a given key, the value itself being
+// used as version. The client side cache is bypassed so that the version is current.
+func (s *RueidisStore) GetWithVersion(ctx context.Context, key any) (any, lib_store.Version, error) {
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return nil, lib_store.Version{}, err
+	}
+
+	str, err := s.client.Do(ctx, s.client.B().Get().Key(redisKey).Build()).ToString()
+	if rueidis.IsRedisNil(err) {
+		return nil, lib_store.Version{}, lib_store.NotFoundWithCause(err)
+	}
+	if err != nil {
+		return nil, lib_store.Version{}, mapError(err)
+	}
+
+	return str, lib_store.NewVersion(str), nil
+}
+
+// CompareAndSwap defines data in Redis for given key identifier only if its
+// current value still is the one the version has been created from.
+// The comparison and the write are done atomically by a Lua script, which also
+// updates the sliding expiration of the key when the store supports them.
+func (s *RueidisStore) CompareAndSwap(ctx context.Context, key any, value any, version lib_store.Version, options ...lib_store.Option) error {
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
+	opts := lib_store.ApplyOptionsWithDefault(s.options, options...)
+	if err := s.Capabilities().CheckOptions(opts); err != nil {
+		return err
+	}
+
+	expected, ok := version.Value().(string)
+	if !ok {
+		return lib_store.ConditionFailedWithCause(nil)
+	}
+
+	slidingKey, sliding, err := s.slidingKey(redisKey, opts)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{redisKey}
+	args := []string{expected, stringValue(value), strconv.FormatInt(opts.EffectiveExpiration().Milliseconds(), 10)}
+	if sliding {
+		keys = append(keys, slidingKey)
+		args = append(args, lib_store.SlidingExpirationValue(opts.SlidingExpiration))
+	}
+
+	cmd := s.client.B().Eval().Script(compareAndSwapScript).Numkeys(int64(len(keys))).Key(keys...).Arg(args...).Build()
+
+	swapped, err := s.client.Do(ctx, cmd).AsInt64()
+	if err != nil {
+		return mapError(err)
+	}
+	if swapped == 0 {
+		return lib_store.ConditionFailedWithCause(nil)
+	}
+
+	return s.tags.Add(ctx, redisKey, opts.Tags, opts.TagsTTL)
+}
+
+// stringValue returns the given string or []byte value as a string
+// to be sent to Redis
+func stringValue(value any) string {
+	switch v := value.(type) {
 	case []byte:
-		cmd = s.client.B().Set().Key(key.(string)).Value(rueidis.BinaryString(value.([]byte))).ExSeconds(ttl).Build()
+		return rueidis.BinaryString(v)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
 	}
-	err := s.client.Do(ctx, cmd).Error()
+}
+
+// Touch changes the expiration of the given key using the PEXPIRE command,
+// or removes it using the PERSIST command when the ttl is not positive.
+// As the key then has a fixed expiration, its sliding expiration is removed
+// in the same transaction when the store supports them.
+func (s *RueidisStore) Touch(ctx context.Context, key any, ttl time.Duration) error {
+	redisKey, err := lib_store.KeyAs[string](key)
 	if err != nil {
 		return err
 	}
-	if tags := opts.Tags; len(tags) > 0 {
-		s.setTags(ctx, key, tags)
+
+	if err := s.Capabilities().CheckOptions(&lib_store.Options{Strict: s.options.Strict, Expiration: ttl}); err != nil {
+		return err
+	}
+
+	slidingKey, sliding, err := s.slidingKey(redisKey, &lib_store.Options{})
+	if err != nil {
+		return err
+	}
+
+	touch := s.client.B().Persist().Key(redisKey).Build()
+	if ttl > 0 {
+		touch = s.client.B().Pexpire().Key(redisKey).Milliseconds(ttl.Milliseconds()).Build()
+	}
+
+	var updated int64
+	if sliding {
+		updated, err = s.touchWithSlidingExpiration(ctx, touch, slidingKey)
+	} else {
+		updated, err = s.client.Do(ctx, touch).AsInt64()
+	}
+	if err != nil || updated == 1 {
+		return mapError(err)
+	}
+	if ttl > 0 {
+		return lib_store.NotFoundWithCause(errors.New("key not found in Redis"))
+	}
+
+	// PERSIST also returns 0 when the key has no expiration
+	exists, err := s.client.Do(ctx, s.client.B().Exists().Key(redisKey).Build()).AsInt64()
+	if err != nil {
+		return mapError(err)
+	}
+	if exists == 0 {
+		return lib_store.NotFoundWithCause(errors.New("key not found in Redis"))
 	}
 
 	return nil
 }
 
-func (s *RueidisStore) setTags(ctx context.Context, key any, tags []string) {
-	ttl := 720 * time.Hour
-	for _, tag := range tags {
-		tagKey := fmt.Sprintf(RueidisTagPattern, tag)
-		s.client.DoMulti(ctx,
-			s.client.B().Sadd().Key(tagKey).Member(key.(string)).Build(),
-			s.client.B().Expire().Key(tagKey).Seconds(int64(ttl.Seconds())).Build(),
-		)
+// touchWithSlidingExpiration runs the given PEXPIRE or PERSIST command and removes the
+// given sliding expiration key in a transaction, returning the result of the command
+func (s *RueidisStore) touchWithSlidingExpiration(ctx context.Context, touch rueidis.Completed, slidingKey string) (int64, error) {
+	results := s.client.DoMulti(ctx,
+		s.client.B().Multi().Build(),
+		touch,
+		s.client.B().Del().Key(slidingKey).Build(),
+		s.client.B().Exec().Build(),
+	)
+	for _, res := range results {
+		if err := res.Error(); err != nil {
+			return 0, err
+		}
+	}
+
+	replies, err := results[len(results)-1].ToArray()
+	if err != nil {
+		return 0, err
 	}
+
+	return replies[0].AsInt64()
 }
 
-// Delete removes data from Redis for given key identifier
+// Delete removes data from Redis for given key identifier, along with its sliding
+// expiration which belongs to the same hash slot
 func (s *RueidisStore) Delete(ctx context.Context, key any) error {
-	return s.client.Do(ctx, s.client.B().Del().Key(key.(string)).Build()).Error()
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return err
+	}
+
+	cmd := s.client.B().Del().Key(s.withSlidingExpirationKeys(redisKey)...).Build()
+	if err := s.client.Do(ctx, cmd).Error(); err != nil {
+		return mapError(err)
+	}
+
+	return s.tags.Remove(ctx, redisKey)
 }
 
 // Invalidate invalidates some cache data in Redis for given options
 func (s *RueidisStore) Invalidate(ctx context.Context, options ...lib_store.InvalidateOption) error {
 	opts := lib_store.ApplyInvalidateOptions(options...)
 
+	var err error
 	if tags := opts.Tags; len(tags) > 0 {
-		for _, tag := range tags {
-			tagKey := fmt.Sprintf(RueidisTagPattern, tag)
+		err = s.tags.Invalidate(ctx, tags, func(ctx context.Context, key string) error {
+			return s.Delete(ctx, key)
+		})
+	}
 
-			cacheKeys, err := s.client.Do(ctx, s.client.B().Smembers().Key(tagKey).Build()).AsStrSlice()
-			if err != nil {
-				continue
-			}
+	if opts.HasKeyFilter() {
+		err = errors.Join(err, s.invalidateKeys(ctx, opts))
+	}
+
+	return err
+}
 
-			for _, cacheKey := range cacheKeys {
-				s.Delete(ctx, cacheKey)
+// invalidateKeys unlinks the keys matching the prefix or pattern of the given options,
+// scanned on every master node. Keys are unlinked by batches, one command per key as
+// they may belong to different hash slots.
+func (s *RueidisStore) invalidateKeys(ctx context.Context, opts *lib_store.InvalidateOptions) error {
+	unlink := func(cmds rueidis.Commands) error {
+		for _, res := range s.client.DoMulti(ctx, cmds...) {
+			if err := res.Error(); err != nil {
+				return mapError(err)
 			}
+		}
+		return nil
+	}
 
-			s.Delete(ctx, tagKey)
+	cmds := make(rueidis.Commands, 0, invalidateBatchSize)
+	for key, err := range s.Keys(ctx, lib_store.WithScanMatch(opts.KeyPattern()), lib_store.WithScanCount(invalidateBatchSize)) {
+		if err != nil {
+			return err
+		}
+		redisKey, err := lib_store.KeyAs[string](key)
+		if err != nil {
+			return err
 		}
+		if !opts.MatchKey(redisKey) {
+			continue
+		}
+
+		cmds = append(cmds, s.client.B().Unlink().Key(s.withSlidingExpirationKeys(redisKey)...).Build())
+		if len(cmds) >= invalidateBatchSize {
+			if err := unlink(cmds); err != nil {
+				return err
+			}
+			cmds = cmds[:0]
+		}
+	}
+
+	if len(cmds) > 0 {
+		return unlink(cmds)
 	}
 
 	return nil
 }
 
+// SlidingExpiration returns the sliding expiration given to the key, if any.
+// No sliding expiration is returned when the store does not support them.
+func (s *RueidisStore) SlidingExpiration(ctx context.Context, key any) (time.Duration, error) {
+	redisKey, err := lib_store.KeyAs[string](key)
+	if err != nil {
+		return 0, err
+	}
+
+	slidingKey, ok := lib_store.SlidingExpirationSlotKey(redisKey)
+	if !s.options.SlidingExpirationSupport || !ok {
+		return 0, nil
+	}
+
+	value, err := s.client.Do(ctx, s.client.B().Get().Key(slidingKey).Build()).ToString()
+	if rueidis.IsRedisNil(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, mapError(err)
+	}
+
+	return lib_store.ParseSlidingExpiration(value)
+}
+
+// withSlidingExpirationKeys returns the given keys along with their sliding expiration keys
+// when the store supports sliding expirations
+func (s *RueidisStore) withSlidingExpirationKeys(keys ...string) []string {
+	if !s.options.SlidingExpirationSupport {
+		return keys
+	}
+
+	all := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		all = append(all, key)
+		if slidingKey, ok := lib_store.SlidingExpirationSlotKey(key); ok {
+			all = append(all, slidingKey)
+		}
+	}
+
+	return all
+}
+
+// Capabilities returns the features supported by Redis using rueidis
+func (s *RueidisStore) Capabilities() lib_store.Capabilities {
+	return lib_store.Capabilities{
+		TTLPrecision:      time.Second,
+		Tags:              true,
+		Batch:             true,
+		Scan:              true,
+		AtomicCounters:    true,
+		ConditionalWrites: true,
+		MaxValueSize:      512 * 1024 * 1024,
+		ClientSideCaching: true,
+		SlidingExpiration: s.options.SlidingExpirationSupport,
+	}
+}
+
 // GetType returns the store type
 func (s *RueidisStore) GetType() string {
 	return RueidisType
@@ -145,5 +964,32 @@ func (s *RueidisStore) GetType() string {
 
 // Clear resets all data in the store
 func (s *RueidisStore) Clear(ctx context.Context) error {
-	return rueidiscompat.NewAdapter(s.client).FlushAll(ctx).Err()
+	return mapError(rueidiscompat.NewAdapter(s.client).FlushAll(ctx).Err())
+}
+
+// mapError maps the errors returned by the rueidis client onto the store errors
+func mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if rueidis.IsRedisNil(err) {
+		return lib_store.NotFoundWithCause(err)
+	}
+	if errors.Is(err, rueidis.ErrClosing) || errors.Is(err, rueidis.ErrNoAddr) ||
+		errors.Is(err, rueidis.ErrNoSlot) || errors.Is(err, rueidis.ErrDoCacheAborted) {
+		return lib_store.UnavailableWithCause(err)
+	}
+
+	if redisErr, ok := rueidis.IsRedisErr(err); ok {
+		message := strings.TrimPrefix(redisErr.Error(), "ERR ")
+		switch {
+		case redisErr.IsTryAgain(), redisErr.IsClusterDown(),
+			strings.HasPrefix(message, "LOADING"), strings.HasPrefix(message, "MASTERDOWN"):
+			return lib_store.UnavailableWithCause(err)
+		case strings.HasPrefix(message, "WRONGTYPE"), strings.HasPrefix(message, "value is not"):
+			return lib_store.InvalidValueWithCause(err)
+		}
+	}
+
+	return lib_store.ClassifyError(err)
 }
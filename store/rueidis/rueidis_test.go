@@ -2,6 +2,7 @@ package rueidis
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -91,6 +92,156 @@ func TestRueidisSet(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRueidisSetWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("MULTI"),
+		mock.Match("SET", "my-key", "my-cache-value", "EX", "60"),
+		mock.Match("SET", "gocache_sliding_{my-key}", "60000000000", "PX", "60000"),
+		mock.Match("EXEC"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisString("OK")),
+		mock.Result(mock.RedisString("QUEUED")),
+		mock.Result(mock.RedisString("QUEUED")),
+		mock.Result(mock.RedisArray(mock.RedisString("OK"), mock.RedisString("OK"))),
+	})
+
+	store := NewRueidis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Set(ctx, "my-key", "my-cache-value", lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisSetWithSlidingExpirationWhenKeyCannotHaveOne(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+
+	store := NewRueidis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Set(ctx, "my}key", "my-cache-value", lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ErrUnsupported)
+}
+
+func TestRueidisSetWithExpireAt(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("SET", "my-key", "my-cache-value", "PXAT", "1767225600000")).
+		Return(mock.Result(mock.RedisString("OK")))
+
+	store := NewRueidis(client, lib_store.WithExpiration(time.Minute))
+
+	// When
+	err := store.Set(ctx, "my-key", "my-cache-value", lib_store.WithExpireAt(time.UnixMilli(1767225600000)))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisGetWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("EVAL", slidingGetScript, "2", "my-key", "gocache_sliding_{my-key}")).
+		Return(mock.Result(mock.RedisArray(mock.RedisString("my-value"), mock.RedisString("60000000000"))))
+
+	store := NewRueidis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	value, ttl, err := store.GetWithTTL(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, time.Minute, ttl)
+}
+
+func TestRueidisGetManyWithSlidingExpirationSupport(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("EVAL", slidingGetScript, "2", "key-1", "gocache_sliding_{key-1}"),
+		mock.Match("EVAL", slidingGetScript, "2", "key-2", "gocache_sliding_{key-2}"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisArray(mock.RedisString("value-1"))),
+		mock.Result(mock.RedisNil()),
+	})
+
+	store := NewRueidis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	values, err := store.GetMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1"}, values)
+}
+
+func TestRueidisDeleteWithSlidingExpirationSupport(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("DEL", "my-key", "gocache_sliding_{my-key}")).Return(mock.Result(mock.RedisInt64(1)))
+	client.EXPECT().DoMulti(ctx, mock.Match("SMEMBERS", "gocache_tag___key__:my-key")).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisArray()),
+	})
+
+	store := NewRueidis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Delete(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisSlidingExpirationWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+
+	store := NewRueidis(client)
+
+	// When
+	sliding, err := store.SlidingExpiration(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), sliding)
+	assert.False(t, store.Capabilities().SlidingExpiration)
+}
+
 func TestRueidisSetWhenNoOptionsGiven(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -127,8 +278,15 @@ func TestRedisSetWithTags(t *testing.T) {
 		mock.Match("SADD", "gocache_tag_tag1", "my-key"),
 		mock.Match("EXPIRE", "gocache_tag_tag1", "2592000"),
 	).Return([]rueidis.RedisResult{
-		mock.Result(mock.RedisString("")),
-		mock.Result(mock.RedisString("")),
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(1)),
+	})
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SADD", "gocache_tag___key__:my-key", "tag1"),
+		mock.Match("EXPIRE", "gocache_tag___key__:my-key", "2592000"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(1)),
 	})
 
 	store := NewRueidis(client, lib_store.WithExpiration(time.Second*10))
@@ -150,6 +308,9 @@ func TestRedisDelete(t *testing.T) {
 
 	client := mock.NewClient(ctrl)
 	client.EXPECT().Do(ctx, mock.Match("DEL", cacheKey)).Return(mock.Result(mock.RedisInt64(1)))
+	client.EXPECT().DoMulti(ctx, mock.Match("SMEMBERS", "gocache_tag___key__:my-key")).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisArray()),
+	})
 
 	store := NewRueidis(client)
 
@@ -160,6 +321,452 @@ func TestRedisDelete(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRueidisGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMultiCache(ctx, gomock.Any(), gomock.Any()).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisString("value-1")),
+		mock.Result(mock.RedisNil()),
+	})
+
+	store := NewRueidis(client)
+
+	// When
+	values, err := store.GetMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1"}, values)
+}
+
+func TestRueidisSetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx, mock.Match("SET", "my-key", "my-value", "EX", "10")).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisString("OK")),
+	})
+
+	store := NewRueidis(client, lib_store.WithExpiration(time.Second*10))
+
+	// When
+	err := store.SetMany(ctx, map[any]any{"my-key": "my-value"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisDeleteMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx, mock.Match("DEL", "key-1"), mock.Match("DEL", "key-2")).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(1)),
+	})
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SMEMBERS", "gocache_tag___key__:key-1"),
+		mock.Match("SMEMBERS", "gocache_tag___key__:key-2"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisArray()),
+		mock.Result(mock.RedisArray(mock.RedisString("tag1"))),
+	})
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SREM", "gocache_tag_tag1", "key-2"),
+		mock.Match("DEL", "gocache_tag___key__:key-2"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(1)),
+	})
+
+	store := NewRueidis(client)
+
+	// When
+	err := store.DeleteMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("INCRBY", "my-counter", "2")).Return(mock.Result(mock.RedisInt64(5)))
+
+	store := NewRueidis(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), value)
+}
+
+func TestRueidisDecrementWithExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SET", "my-counter", "0", "NX", "PX", "60000"),
+		mock.Match("INCRBY", "my-counter", "-1"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisNil()),
+		mock.Result(mock.RedisInt64(4)),
+	})
+
+	store := NewRueidis(client)
+
+	// When
+	value, err := store.Decrement(ctx, "my-counter", 1, lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), value)
+}
+
+func TestRueidisIncrementWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("EVAL", incrementScript, "2", "my-counter", "gocache_sliding_{my-counter}", "1", "PX", "60000", "60000000000")).
+		Return(mock.Result(mock.RedisInt64(1)))
+
+	store := NewRueidis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 1, lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), value)
+}
+
+func TestRueidisSetIfNotExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("SET", "my-lock", "owner", "NX", "PX", "60000")).Return(mock.Result(mock.RedisString("OK")))
+
+	store := NewRueidis(client)
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-lock", "owner", lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisIncrementWithExpireAt(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SET", "my-counter", "0", "NX", "PXAT", "1767225600000"),
+		mock.Match("INCRBY", "my-counter", "1"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisString("OK")),
+		mock.Result(mock.RedisInt64(1)),
+	})
+
+	store := NewRueidis(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 1, lib_store.WithExpireAt(time.UnixMilli(1767225600000)))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), value)
+}
+
+func TestRueidisSetIfNotExistsWithExpireAt(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("SET", "my-lock", "owner", "NX", "PXAT", "1767225600000")).Return(mock.Result(mock.RedisString("OK")))
+
+	store := NewRueidis(client)
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-lock", "owner", lib_store.WithExpireAt(time.UnixMilli(1767225600000)))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisSetIfNotExistsWhenKeyExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("SET", "my-lock", "owner", "NX")).Return(mock.Result(mock.RedisNil()))
+
+	store := NewRueidis(client, lib_store.WithExpiration(0))
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-lock", "owner")
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ConditionFailed{})
+}
+
+func TestRueidisSetIfNotExistsWithSlidingExpirationSupportWhenKeyExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("EVAL", setIfNotExistsScript, "2", "my-lock", "gocache_sliding_{my-lock}", "owner", "PX", "60000", "0")).
+		Return(mock.Result(mock.RedisInt64(0)))
+
+	store := NewRueidis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-lock", "owner", lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ConditionFailed{})
+}
+
+func TestRueidisCompareAndSwap(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("GET", "my-key")).Return(mock.Result(mock.RedisString("my-value")))
+	client.EXPECT().Do(ctx, mock.Match("EVAL", compareAndSwapScript, "1", "my-key", "my-value", "new-value", "60000")).
+		Return(mock.Result(mock.RedisInt64(1)))
+
+	store := NewRueidis(client)
+
+	_, version, err := store.GetWithVersion(ctx, "my-key")
+	assert.Nil(t, err)
+
+	// When
+	err = store.CompareAndSwap(ctx, "my-key", "new-value", version, lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisCompareAndSwapWhenValueHasChanged(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("EVAL", compareAndSwapScript, "1", "my-key", "my-value", "new-value", "0")).
+		Return(mock.Result(mock.RedisInt64(0)))
+
+	store := NewRueidis(client, lib_store.WithExpiration(0))
+
+	// When
+	err := store.CompareAndSwap(ctx, "my-key", "new-value", lib_store.NewVersion("my-value"))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ConditionFailed{})
+}
+
+func TestRueidisCompareAndSwapWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("EVAL", compareAndSwapScript, "2", "my-key", "gocache_sliding_{my-key}", "my-value", "new-value", "60000", "60000000000")).
+		Return(mock.Result(mock.RedisInt64(1)))
+
+	store := NewRueidis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.CompareAndSwap(ctx, "my-key", "new-value", lib_store.NewVersion("my-value"), lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisTouch(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("PEXPIRE", "my-key", "60000")).Return(mock.Result(mock.RedisInt64(1)))
+
+	store := NewRueidis(client)
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestRueidisTouchWithSlidingExpirationSupport(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("MULTI"),
+		mock.Match("PEXPIRE", "my-key", "60000"),
+		mock.Match("DEL", "gocache_sliding_{my-key}"),
+		mock.Match("EXEC"),
+	).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisString("OK")),
+		mock.Result(mock.RedisString("QUEUED")),
+		mock.Result(mock.RedisString("QUEUED")),
+		mock.Result(mock.RedisArray(mock.RedisInt64(0), mock.RedisInt64(1))),
+	})
+
+	store := NewRueidis(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.NotFound{})
+}
+
+func TestRueidisTouchWithoutExpirationWhenKeyDoesNotExist(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("PERSIST", "my-key")).Return(mock.Result(mock.RedisInt64(0)))
+	client.EXPECT().Do(ctx, mock.Match("EXISTS", "my-key")).Return(mock.Result(mock.RedisInt64(0)))
+
+	store := NewRueidis(client)
+
+	// When
+	err := store.Touch(ctx, "my-key", 0)
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.NotFound{})
+}
+
+func TestRueidisKeys(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Nodes().Return(map[string]rueidis.Client{
+		"client1": client,
+	})
+	client.EXPECT().Do(ctx, mock.Match("ROLE")).Return(mock.Result(mock.RedisArray(mock.RedisString("master"))))
+	gomock.InOrder(
+		client.EXPECT().Do(ctx, mock.Match("SCAN", "0", "MATCH", "key-*", "COUNT", "10")).Return(mock.Result(mock.RedisArray(
+			mock.RedisString("42"),
+			mock.RedisArray(mock.RedisString("key-1"), mock.RedisString("gocache_tag_tag1")),
+		))),
+		client.EXPECT().Do(ctx, mock.Match("SCAN", "42", "MATCH", "key-*", "COUNT", "10")).Return(mock.Result(mock.RedisArray(
+			mock.RedisString("0"),
+			mock.RedisArray(mock.RedisString("key-2")),
+		))),
+	)
+
+	store := NewRueidis(client)
+
+	// When
+	keys := []any{}
+	for key, err := range store.Keys(ctx, lib_store.WithScanMatch("key-*"), lib_store.WithScanCount(10)) {
+		assert.Nil(t, err)
+		keys = append(keys, key)
+	}
+
+	// Then
+	assert.Equal(t, []any{"key-1", "key-2"}, keys)
+}
+
+func TestRueidisKeysWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("an unexpected error occurred")
+
+	// rueidis mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Nodes().Return(map[string]rueidis.Client{
+		"client1": client,
+	})
+	client.EXPECT().Do(ctx, mock.Match("ROLE")).Return(mock.Result(mock.RedisArray(mock.RedisString("master"))))
+	client.EXPECT().Do(ctx, mock.Match("SCAN", "0")).Return(mock.ErrorResult(expectedErr))
+
+	store := NewRueidis(client)
+
+	// When
+	var errs []error
+	for _, err := range store.Keys(ctx) {
+		errs = append(errs, err)
+	}
+
+	// Then
+	assert.Equal(t, []error{expectedErr}, errs)
+}
+
 func TestRedisInvalidate(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -179,6 +786,35 @@ func TestRedisInvalidate(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestRedisInvalidateWithPrefix(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Nodes().Return(map[string]rueidis.Client{
+		"client1": client,
+	})
+	client.EXPECT().Do(ctx, mock.Match("ROLE")).Return(mock.Result(mock.RedisArray(mock.RedisString("master"))))
+	client.EXPECT().Do(ctx, mock.Match("SCAN", "0", "MATCH", "user:*", "COUNT", "100")).Return(mock.Result(mock.RedisArray(
+		mock.RedisString("0"),
+		mock.RedisArray(mock.RedisString("user:1"), mock.RedisString("user:2")),
+	)))
+	client.EXPECT().DoMulti(ctx, mock.Match("UNLINK", "user:1"), mock.Match("UNLINK", "user:2")).Return([]rueidis.RedisResult{
+		mock.Result(mock.RedisInt64(1)),
+		mock.Result(mock.RedisInt64(1)),
+	})
+
+	store := NewRueidis(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePrefix("user:"))
+
+	// Then
+	assert.Nil(t, err)
+}
+
 func TestRedisClear(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
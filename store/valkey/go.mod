@@ -4,17 +4,21 @@ go 1.25
 
 require (
 	github.com/eko/gocache/lib/v4 v4.2.0
-	github.com/stretchr/testify v1.9.0
+	github.com/stretchr/testify v1.11.1
 	github.com/valkey-io/valkey-go v1.0.59
 	github.com/valkey-io/valkey-go/mock v1.0.59
 	github.com/valkey-io/valkey-go/valkeycompat v1.0.59
-	go.uber.org/mock v0.5.0
+	go.uber.org/mock v0.6.0
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	golang.org/x/exp v0.0.0-20240416160154-fe59bbe5cc7f // indirect
-	golang.org/x/sys v0.31.0 // indirect
+	golang.org/x/exp v0.0.0-20251209150349-8475f28825e9 // indirect
+	golang.org/x/sys v0.39.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+// Building against the local lib module, as the other stores do, requires the
+// testify, mock, x/exp and x/sys versions it depends on.
+replace github.com/eko/gocache/lib/v4 => ../../lib/
@@ -0,0 +1,91 @@
+package valkey
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	lib_store "github.com/eko/gocache/lib/v4/store"
+	"github.com/valkey-io/valkey-go"
+)
+
+// tagIndexBackend stores the sets of the tag index as Valkey sets
+type tagIndexBackend struct {
+	client valkey.Client
+}
+
+// AddMembers adds the given members to the set using the SADD command and sets its time-to-live
+func (b *tagIndexBackend) AddMembers(ctx context.Context, setKey string, members []string, ttl time.Duration) error {
+	for _, res := range b.client.DoMulti(ctx,
+		b.client.B().Sadd().Key(setKey).Member(members...).Build(),
+		b.client.B().Expire().Key(setKey).Seconds(int64(ttl.Seconds())).Build(),
+	) {
+		if err := res.Error(); err != nil {
+			return mapError(err)
+		}
+	}
+
+	return nil
+}
+
+// Members returns the members of the set using the SMEMBERS command
+func (b *tagIndexBackend) Members(ctx context.Context, setKey string) ([]string, error) {
+	members, err := b.client.Do(ctx, b.client.B().Smembers().Key(setKey).Build()).AsStrSlice()
+	if valkey.IsValkeyNil(err) {
+		return nil, nil
+	}
+
+	return members, mapError(err)
+}
+
+// RemoveMembers removes the given members from the set using the SREM command
+func (b *tagIndexBackend) RemoveMembers(ctx context.Context, setKey string, members []string) error {
+	return mapError(b.client.Do(ctx, b.client.B().Srem().Key(setKey).Member(members...).Build()).Error())
+}
+
+// DeleteSet removes the whole set
+func (b *tagIndexBackend) DeleteSet(ctx context.Context, setKey string) error {
+	return mapError(b.client.Do(ctx, b.client.B().Del().Key(setKey).Build()).Error())
+}
+
+// ManyMembers returns the members of each of the given sets using SMEMBERS commands
+// sent in a single round-trip
+func (b *tagIndexBackend) ManyMembers(ctx context.Context, setKeys []string) ([][]string, error) {
+	cmds := make([]valkey.Completed, 0, len(setKeys))
+	for _, setKey := range setKeys {
+		cmds = append(cmds, b.client.B().Smembers().Key(setKey).Build())
+	}
+
+	errs := []error{}
+	members := make([][]string, 0, len(setKeys))
+	for _, res := range b.client.DoMulti(ctx, cmds...) {
+		setMembers, err := res.AsStrSlice()
+		if err != nil && !valkey.IsValkeyNil(err) {
+			errs = append(errs, mapError(err))
+		}
+		members = append(members, setMembers)
+	}
+
+	return members, errors.Join(errs...)
+}
+
+// RemoveManyMembers removes the given members from their sets and deletes the given sets
+// using SREM and DEL commands sent in a single round-trip
+func (b *tagIndexBackend) RemoveManyMembers(ctx context.Context, removals []lib_store.TagSetRemoval, deleteSetKeys []string) error {
+	cmds := make([]valkey.Completed, 0, len(removals)+len(deleteSetKeys))
+	for _, removal := range removals {
+		cmds = append(cmds, b.client.B().Srem().Key(removal.SetKey).Member(removal.Members...).Build())
+	}
+	for _, setKey := range deleteSetKeys {
+		cmds = append(cmds, b.client.B().Del().Key(setKey).Build())
+	}
+
+	errs := []error{}
+	for _, res := range b.client.DoMulti(ctx, cmds...) {
+		if err := res.Error(); err != nil {
+			errs = append(errs, mapError(err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
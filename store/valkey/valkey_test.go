@@ -2,6 +2,7 @@ package valkey
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -91,6 +92,156 @@ func TestValkeySet(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestValkeySetWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("MULTI"),
+		mock.Match("SET", "my-key", "my-cache-value", "EX", "60"),
+		mock.Match("SET", "gocache_sliding_{my-key}", "60000000000", "PX", "60000"),
+		mock.Match("EXEC"),
+	).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyString("OK")),
+		mock.Result(mock.ValkeyString("QUEUED")),
+		mock.Result(mock.ValkeyString("QUEUED")),
+		mock.Result(mock.ValkeyArray(mock.ValkeyString("OK"), mock.ValkeyString("OK"))),
+	})
+
+	store := NewValkey(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Set(ctx, "my-key", "my-cache-value", lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestValkeySetWithSlidingExpirationWhenKeyCannotHaveOne(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+
+	store := NewValkey(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Set(ctx, "my}key", "my-cache-value", lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ErrUnsupported)
+}
+
+func TestValkeySetWithExpireAt(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("SET", "my-key", "my-cache-value", "PXAT", "1767225600000")).
+		Return(mock.Result(mock.ValkeyString("OK")))
+
+	store := NewValkey(client, lib_store.WithExpiration(time.Minute))
+
+	// When
+	err := store.Set(ctx, "my-key", "my-cache-value", lib_store.WithExpireAt(time.UnixMilli(1767225600000)))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestValkeyGetWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("EVAL", slidingGetScript, "2", "my-key", "gocache_sliding_{my-key}")).
+		Return(mock.Result(mock.ValkeyArray(mock.ValkeyString("my-value"), mock.ValkeyString("60000000000"))))
+
+	store := NewValkey(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	value, ttl, err := store.GetWithTTL(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, "my-value", value)
+	assert.Equal(t, time.Minute, ttl)
+}
+
+func TestValkeyGetManyWithSlidingExpirationSupport(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("EVAL", slidingGetScript, "2", "key-1", "gocache_sliding_{key-1}"),
+		mock.Match("EVAL", slidingGetScript, "2", "key-2", "gocache_sliding_{key-2}"),
+	).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyArray(mock.ValkeyString("value-1"))),
+		mock.Result(mock.ValkeyNil()),
+	})
+
+	store := NewValkey(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	values, err := store.GetMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1"}, values)
+}
+
+func TestValkeyDeleteWithSlidingExpirationSupport(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("DEL", "my-key", "gocache_sliding_{my-key}")).Return(mock.Result(mock.ValkeyInt64(1)))
+	client.EXPECT().DoMulti(ctx, mock.Match("SMEMBERS", "gocache_tag___key__:my-key")).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyArray()),
+	})
+
+	store := NewValkey(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Delete(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestValkeySlidingExpirationWhenNotSupported(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+
+	store := NewValkey(client)
+
+	// When
+	sliding, err := store.SlidingExpiration(ctx, "my-key")
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, time.Duration(0), sliding)
+	assert.False(t, store.Capabilities().SlidingExpiration)
+}
+
 func TestValkeySetWhenNoOptionsGiven(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -127,8 +278,15 @@ func TestValkeySetWithTags(t *testing.T) {
 		mock.Match("SADD", "gocache_tag_tag1", "my-key"),
 		mock.Match("EXPIRE", "gocache_tag_tag1", "2592000"),
 	).Return([]valkey.ValkeyResult{
-		mock.Result(mock.ValkeyString("")),
-		mock.Result(mock.ValkeyString("")),
+		mock.Result(mock.ValkeyInt64(1)),
+		mock.Result(mock.ValkeyInt64(1)),
+	})
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SADD", "gocache_tag___key__:my-key", "tag1"),
+		mock.Match("EXPIRE", "gocache_tag___key__:my-key", "2592000"),
+	).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyInt64(1)),
+		mock.Result(mock.ValkeyInt64(1)),
 	})
 
 	store := NewValkey(client, lib_store.WithExpiration(time.Second*10))
@@ -150,6 +308,9 @@ func TestValkeyDelete(t *testing.T) {
 
 	client := mock.NewClient(ctrl)
 	client.EXPECT().Do(ctx, mock.Match("DEL", cacheKey)).Return(mock.Result(mock.ValkeyInt64(1)))
+	client.EXPECT().DoMulti(ctx, mock.Match("SMEMBERS", "gocache_tag___key__:my-key")).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyArray()),
+	})
 
 	store := NewValkey(client)
 
@@ -160,6 +321,452 @@ func TestValkeyDelete(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestValkeyGetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMultiCache(ctx, gomock.Any(), gomock.Any()).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyString("value-1")),
+		mock.Result(mock.ValkeyNil()),
+	})
+
+	store := NewValkey(client)
+
+	// When
+	values, err := store.GetMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, map[any]any{"key-1": "value-1"}, values)
+}
+
+func TestValkeySetMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx, mock.Match("SET", "my-key", "my-value", "EX", "10")).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyString("OK")),
+	})
+
+	store := NewValkey(client, lib_store.WithExpiration(time.Second*10))
+
+	// When
+	err := store.SetMany(ctx, map[any]any{"my-key": "my-value"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestValkeyDeleteMany(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx, mock.Match("DEL", "key-1"), mock.Match("DEL", "key-2")).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyInt64(1)),
+		mock.Result(mock.ValkeyInt64(1)),
+	})
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SMEMBERS", "gocache_tag___key__:key-1"),
+		mock.Match("SMEMBERS", "gocache_tag___key__:key-2"),
+	).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyArray()),
+		mock.Result(mock.ValkeyArray(mock.ValkeyString("tag1"))),
+	})
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SREM", "gocache_tag_tag1", "key-2"),
+		mock.Match("DEL", "gocache_tag___key__:key-2"),
+	).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyInt64(1)),
+		mock.Result(mock.ValkeyInt64(1)),
+	})
+
+	store := NewValkey(client)
+
+	// When
+	err := store.DeleteMany(ctx, []any{"key-1", "key-2"})
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestValkeyIncrement(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("INCRBY", "my-counter", "2")).Return(mock.Result(mock.ValkeyInt64(5)))
+
+	store := NewValkey(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 2)
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(5), value)
+}
+
+func TestValkeyDecrementWithExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SET", "my-counter", "0", "NX", "PX", "60000"),
+		mock.Match("INCRBY", "my-counter", "-1"),
+	).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyNil()),
+		mock.Result(mock.ValkeyInt64(4)),
+	})
+
+	store := NewValkey(client)
+
+	// When
+	value, err := store.Decrement(ctx, "my-counter", 1, lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), value)
+}
+
+func TestValkeyIncrementWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("EVAL", incrementScript, "2", "my-counter", "gocache_sliding_{my-counter}", "1", "PX", "60000", "60000000000")).
+		Return(mock.Result(mock.ValkeyInt64(1)))
+
+	store := NewValkey(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 1, lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), value)
+}
+
+func TestValkeySetIfNotExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("SET", "my-lock", "owner", "NX", "PX", "60000")).Return(mock.Result(mock.ValkeyString("OK")))
+
+	store := NewValkey(client)
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-lock", "owner", lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestValkeyIncrementWithExpireAt(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("SET", "my-counter", "0", "NX", "PXAT", "1767225600000"),
+		mock.Match("INCRBY", "my-counter", "1"),
+	).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyString("OK")),
+		mock.Result(mock.ValkeyInt64(1)),
+	})
+
+	store := NewValkey(client)
+
+	// When
+	value, err := store.Increment(ctx, "my-counter", 1, lib_store.WithExpireAt(time.UnixMilli(1767225600000)))
+
+	// Then
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), value)
+}
+
+func TestValkeySetIfNotExistsWithExpireAt(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("SET", "my-lock", "owner", "NX", "PXAT", "1767225600000")).Return(mock.Result(mock.ValkeyString("OK")))
+
+	store := NewValkey(client)
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-lock", "owner", lib_store.WithExpireAt(time.UnixMilli(1767225600000)))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestValkeySetIfNotExistsWhenKeyExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("SET", "my-lock", "owner", "NX")).Return(mock.Result(mock.ValkeyNil()))
+
+	store := NewValkey(client, lib_store.WithExpiration(0))
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-lock", "owner")
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ConditionFailed{})
+}
+
+func TestValkeySetIfNotExistsWithSlidingExpirationSupportWhenKeyExists(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("EVAL", setIfNotExistsScript, "2", "my-lock", "gocache_sliding_{my-lock}", "owner", "PX", "60000", "0")).
+		Return(mock.Result(mock.ValkeyInt64(0)))
+
+	store := NewValkey(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.SetIfNotExists(ctx, "my-lock", "owner", lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ConditionFailed{})
+}
+
+func TestValkeyCompareAndSwap(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("GET", "my-key")).Return(mock.Result(mock.ValkeyString("my-value")))
+	client.EXPECT().Do(ctx, mock.Match("EVAL", compareAndSwapScript, "1", "my-key", "my-value", "new-value", "60000")).
+		Return(mock.Result(mock.ValkeyInt64(1)))
+
+	store := NewValkey(client)
+
+	_, version, err := store.GetWithVersion(ctx, "my-key")
+	assert.Nil(t, err)
+
+	// When
+	err = store.CompareAndSwap(ctx, "my-key", "new-value", version, lib_store.WithExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestValkeyCompareAndSwapWhenValueHasChanged(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("EVAL", compareAndSwapScript, "1", "my-key", "my-value", "new-value", "0")).
+		Return(mock.Result(mock.ValkeyInt64(0)))
+
+	store := NewValkey(client, lib_store.WithExpiration(0))
+
+	// When
+	err := store.CompareAndSwap(ctx, "my-key", "new-value", lib_store.NewVersion("my-value"))
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.ConditionFailed{})
+}
+
+func TestValkeyCompareAndSwapWithSlidingExpiration(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("EVAL", compareAndSwapScript, "2", "my-key", "gocache_sliding_{my-key}", "my-value", "new-value", "60000", "60000000000")).
+		Return(mock.Result(mock.ValkeyInt64(1)))
+
+	store := NewValkey(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.CompareAndSwap(ctx, "my-key", "new-value", lib_store.NewVersion("my-value"), lib_store.WithSlidingExpiration(time.Minute))
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestValkeyTouch(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("PEXPIRE", "my-key", "60000")).Return(mock.Result(mock.ValkeyInt64(1)))
+
+	store := NewValkey(client)
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.Nil(t, err)
+}
+
+func TestValkeyTouchWithSlidingExpirationSupport(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().DoMulti(ctx,
+		mock.Match("MULTI"),
+		mock.Match("PEXPIRE", "my-key", "60000"),
+		mock.Match("DEL", "gocache_sliding_{my-key}"),
+		mock.Match("EXEC"),
+	).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyString("OK")),
+		mock.Result(mock.ValkeyString("QUEUED")),
+		mock.Result(mock.ValkeyString("QUEUED")),
+		mock.Result(mock.ValkeyArray(mock.ValkeyInt64(0), mock.ValkeyInt64(1))),
+	})
+
+	store := NewValkey(client, lib_store.WithSlidingExpirationSupport())
+
+	// When
+	err := store.Touch(ctx, "my-key", time.Minute)
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.NotFound{})
+}
+
+func TestValkeyTouchWithoutExpirationWhenKeyDoesNotExist(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Do(ctx, mock.Match("PERSIST", "my-key")).Return(mock.Result(mock.ValkeyInt64(0)))
+	client.EXPECT().Do(ctx, mock.Match("EXISTS", "my-key")).Return(mock.Result(mock.ValkeyInt64(0)))
+
+	store := NewValkey(client)
+
+	// When
+	err := store.Touch(ctx, "my-key", 0)
+
+	// Then
+	assert.ErrorIs(t, err, lib_store.NotFound{})
+}
+
+func TestValkeyKeys(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Nodes().Return(map[string]valkey.Client{
+		"client1": client,
+	})
+	client.EXPECT().Do(ctx, mock.Match("ROLE")).Return(mock.Result(mock.ValkeyArray(mock.ValkeyString("master"))))
+	gomock.InOrder(
+		client.EXPECT().Do(ctx, mock.Match("SCAN", "0", "MATCH", "key-*", "COUNT", "10")).Return(mock.Result(mock.ValkeyArray(
+			mock.ValkeyString("42"),
+			mock.ValkeyArray(mock.ValkeyString("key-1"), mock.ValkeyString("gocache_tag_tag1")),
+		))),
+		client.EXPECT().Do(ctx, mock.Match("SCAN", "42", "MATCH", "key-*", "COUNT", "10")).Return(mock.Result(mock.ValkeyArray(
+			mock.ValkeyString("0"),
+			mock.ValkeyArray(mock.ValkeyString("key-2")),
+		))),
+	)
+
+	store := NewValkey(client)
+
+	// When
+	keys := []any{}
+	for key, err := range store.Keys(ctx, lib_store.WithScanMatch("key-*"), lib_store.WithScanCount(10)) {
+		assert.Nil(t, err)
+		keys = append(keys, key)
+	}
+
+	// Then
+	assert.Equal(t, []any{"key-1", "key-2"}, keys)
+}
+
+func TestValkeyKeysWhenError(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	expectedErr := errors.New("an unexpected error occurred")
+
+	// valkey mock client
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Nodes().Return(map[string]valkey.Client{
+		"client1": client,
+	})
+	client.EXPECT().Do(ctx, mock.Match("ROLE")).Return(mock.Result(mock.ValkeyArray(mock.ValkeyString("master"))))
+	client.EXPECT().Do(ctx, mock.Match("SCAN", "0")).Return(mock.ErrorResult(expectedErr))
+
+	store := NewValkey(client)
+
+	// When
+	var errs []error
+	for _, err := range store.Keys(ctx) {
+		errs = append(errs, err)
+	}
+
+	// Then
+	assert.Equal(t, []error{expectedErr}, errs)
+}
+
 func TestValkeyInvalidate(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)
@@ -179,6 +786,35 @@ func TestValkeyInvalidate(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestValkeyInvalidateWithPrefix(t *testing.T) {
+	// Given
+	ctrl := gomock.NewController(t)
+
+	ctx := context.Background()
+
+	client := mock.NewClient(ctrl)
+	client.EXPECT().Nodes().Return(map[string]valkey.Client{
+		"client1": client,
+	})
+	client.EXPECT().Do(ctx, mock.Match("ROLE")).Return(mock.Result(mock.ValkeyArray(mock.ValkeyString("master"))))
+	client.EXPECT().Do(ctx, mock.Match("SCAN", "0", "MATCH", "user:*", "COUNT", "100")).Return(mock.Result(mock.ValkeyArray(
+		mock.ValkeyString("0"),
+		mock.ValkeyArray(mock.ValkeyString("user:1"), mock.ValkeyString("user:2")),
+	)))
+	client.EXPECT().DoMulti(ctx, mock.Match("UNLINK", "user:1"), mock.Match("UNLINK", "user:2")).Return([]valkey.ValkeyResult{
+		mock.Result(mock.ValkeyInt64(1)),
+		mock.Result(mock.ValkeyInt64(1)),
+	})
+
+	store := NewValkey(client)
+
+	// When
+	err := store.Invalidate(ctx, lib_store.WithInvalidatePrefix("user:"))
+
+	// Then
+	assert.Nil(t, err)
+}
+
 func TestValkeyClear(t *testing.T) {
 	// Given
 	ctrl := gomock.NewController(t)